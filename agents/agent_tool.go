@@ -0,0 +1,116 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/jsonschema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+// AgentToolInput is the typed input of an AgentTool.
+type AgentToolInput struct {
+	Input string `json:"input" description:"The task for the agent, with all the information it needs."`
+}
+
+// AgentTool is a tool running an Executor on the task given as input, to compose agents:
+// an agent using the tool hands the task off to the agent of the executor.
+//
+// The nested run is given a context under the name of the tool, so that the callbacks and
+// the tools of the nested agent, and the events of a streamed run, can tell which agent
+// did what, see AgentPath and AgentDepth. It has its own run id and budget, and its usage
+// also counts in the budget of the outer run.
+type AgentTool struct {
+	name        string
+	description string
+	schema      jsonschema.Definition
+	// Executor is the executor run by the tool.
+	Executor *Executor
+}
+
+var _ tools.StructuredTool = &AgentTool{}
+
+// NewAgentTool creates a tool with the given name and description running the executor.
+// The description tells the calling agent what the agent of the executor is able to do.
+func NewAgentTool(name, description string, executor *Executor) *AgentTool {
+	return &AgentTool{
+		name:        name,
+		description: description,
+		schema:      agentToolSchema(),
+		Executor:    executor,
+	}
+}
+
+// agentToolSchema returns the schema of AgentToolInput. The struct has a single string
+// field, so generating its schema cannot fail.
+func agentToolSchema() jsonschema.Definition {
+	schema, err := jsonschema.GenerateSchemaForType(AgentToolInput{})
+	if err != nil {
+		panic(err)
+	}
+	return *schema
+}
+
+// Name returns the name of the tool.
+func (t *AgentTool) Name() string {
+	return t.name
+}
+
+// Description returns the description of the tool.
+func (t *AgentTool) Description() string {
+	return t.description
+}
+
+// Schema returns the JSON schema of AgentToolInput.
+func (t *AgentTool) Schema() jsonschema.Definition {
+	return t.schema
+}
+
+// Call runs the executor on the task and returns its output. The input is an
+// AgentToolInput JSON object, or the task as plain text for agents without structured
+// tool arguments.
+func (t *AgentTool) Call(ctx context.Context, input string) (string, error) {
+	args := AgentToolInput{Input: input}
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		var err error
+		if args, err = tools.DecodeArguments[AgentToolInput](t.schema, input); err != nil {
+			return "", err
+		}
+	}
+
+	ctx = ContextWithRunID(withAgent(ctx, t.name), "")
+	outputs, err := chains.Call(ctx, t.Executor, map[string]any{"input": args.Input})
+	if err != nil {
+		return "", fmt.Errorf("agent %s: %w", t.name, err)
+	}
+	output, ok := outputs[t.Executor.outputKey()].(string)
+	if !ok {
+		return "", ErrInvalidChainReturnType
+	}
+	return output, nil
+}
+
+type agentPathKey struct{}
+
+// AgentPath returns the names of the agent tools the run of the context is nested in,
+// from the outermost. It is empty for the context of a top-level run.
+func AgentPath(ctx context.Context) []string {
+	path, _ := ctx.Value(agentPathKey{}).([]string)
+	return slices.Clone(path)
+}
+
+// AgentDepth returns the nesting depth of the run of the context, 0 for a top-level run
+// and 1 for a run of an agent tool called by a top-level agent.
+func AgentDepth(ctx context.Context) int {
+	path, _ := ctx.Value(agentPathKey{}).([]string)
+	return len(path)
+}
+
+// withAgent returns the context of a run of the named agent tool, nested in the run of ctx.
+func withAgent(ctx context.Context, name string) context.Context {
+	path, _ := ctx.Value(agentPathKey{}).([]string)
+	return context.WithValue(ctx, agentPathKey{}, append(slices.Clip(path), name))
+}
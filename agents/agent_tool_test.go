@@ -0,0 +1,90 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+// echoAgent finishes with its input, recording the nesting of its runs.
+type echoAgent struct {
+	depths []int
+	paths  [][]string
+}
+
+func (a *echoAgent) Plan(
+	ctx context.Context,
+	_ []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	a.depths = append(a.depths, agents.AgentDepth(ctx))
+	a.paths = append(a.paths, agents.AgentPath(ctx))
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done: " + inputs["input"]}}, nil
+}
+
+func (a *echoAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *echoAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *echoAgent) GetTools() []tools.Tool  { return nil }
+
+func TestAgentTool(t *testing.T) {
+	t.Parallel()
+
+	agent := &echoAgent{}
+	tool := agents.NewAgentTool("sql", "Answers questions about the database.", agents.NewExecutor(agent))
+	assert.Equal(t, []string{"input"}, tool.Schema().Required)
+	assert.Equal(t, "The task for the agent, with all the information it needs.",
+		tool.Schema().Properties["input"].Description)
+
+	output, err := tool.Call(t.Context(), `{"input": "count the users"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "done: count the users", output)
+
+	output, err = tool.Call(t.Context(), "count the orders")
+	require.NoError(t, err)
+	assert.Equal(t, "done: count the orders", output)
+
+	_, err = tool.Call(t.Context(), `{"task": "count the users"}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	assert.Equal(t, []int{1, 1}, agent.depths)
+	assert.Equal(t, []string{"sql"}, agent.paths[0])
+	assert.Empty(t, agents.AgentPath(t.Context()))
+}
+
+func TestAgentToolNested(t *testing.T) {
+	t.Parallel()
+
+	inner := &echoAgent{}
+	innerTool := agents.NewAgentTool("sql", "Answers questions about the database.", agents.NewExecutor(inner))
+	outer := agents.NewAgentTool("analyst", "Analyzes data.", agents.NewExecutor(&testAgent{
+		actions: []schema.AgentAction{{Tool: "sql", ToolInput: "count the users"}},
+		tools:   []tools.Tool{innerTool},
+	}, agents.WithMaxIterations(1), agents.WithEarlyStopping(agents.EarlyStoppingPartial)))
+
+	output, err := outer.Call(t.Context(), "how many users?")
+	require.NoError(t, err)
+	assert.Equal(t, "done: count the users", output)
+	assert.Equal(t, []int{2}, inner.depths)
+	assert.Equal(t, []string{"analyst", "sql"}, inner.paths[0])
+}
+
+func TestAgentToolError(t *testing.T) {
+	t.Parallel()
+
+	tool := agents.NewAgentTool("looping", "Never finishes.", agents.NewExecutor(&testAgent{
+		actions: []schema.AgentAction{{Tool: "unknown"}},
+	}, agents.WithMaxIterations(1)))
+
+	_, err := chains.Call(t.Context(), agents.NewExecutor(&testAgent{
+		actions: []schema.AgentAction{{Tool: "looping"}},
+		tools:   []tools.Tool{tool},
+	}), map[string]any{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.ErrorContains(t, err, "agent looping")
+}
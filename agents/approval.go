@@ -0,0 +1,158 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+// _defaultRejectionObservation is the observation of a rejected action without feedback.
+const _defaultRejectionObservation = "The action was rejected by the user."
+
+// ErrApprovalPending is returned by an Approver to suspend the run until the actions are
+// approved, and wrapped by the ApprovalPendingError the executor then returns.
+var ErrApprovalPending = errors.New("agent action approval pending")
+
+// Approval is the decision of an Approver about an action.
+type Approval struct {
+	// Approved runs the action, else the action is skipped and Feedback is its observation.
+	Approved bool `json:"approved"`
+	// Input replaces the tool input of an approved action when not empty.
+	Input string `json:"input,omitempty"`
+	// Feedback is given to the agent as the observation of a rejected action.
+	Feedback string `json:"feedback,omitempty"`
+}
+
+// Approve returns an approval running the action as is.
+func Approve() Approval {
+	return Approval{Approved: true}
+}
+
+// ApproveWithInput returns an approval running the action with an edited tool input.
+func ApproveWithInput(input string) Approval {
+	return Approval{Approved: true, Input: input}
+}
+
+// Reject returns a rejection giving the feedback to the agent as the observation.
+func Reject(feedback string) Approval {
+	return Approval{Feedback: feedback}
+}
+
+// Approver decides whether the executor can run an action of the agent.
+type Approver interface {
+	// Approve returns the decision about the action. It may block, e.g. waiting for a person
+	// to answer a prompt, or return ErrApprovalPending to suspend the run, which is then
+	// continued with Executor.Resume once a decision is taken.
+	Approve(ctx context.Context, action schema.AgentAction) (Approval, error)
+}
+
+// ApproverFunc is an adapter to allow the use of ordinary functions as Approvers.
+type ApproverFunc func(ctx context.Context, action schema.AgentAction) (Approval, error)
+
+// Approve calls f(ctx, action).
+func (f ApproverFunc) Approve(ctx context.Context, action schema.AgentAction) (Approval, error) {
+	return f(ctx, action)
+}
+
+// NewSuspendApprover returns an Approver suspending the run for every action needing an
+// approval, for approvals given asynchronously, e.g. from a web UI.
+func NewSuspendApprover() Approver {
+	return ApproverFunc(func(context.Context, schema.AgentAction) (Approval, error) {
+		return Approval{}, ErrApprovalPending
+	})
+}
+
+// SuspendedRun is the state of an executor run suspended until actions are approved.
+// It can be serialized to JSON to resume the run later, possibly in another process.
+type SuspendedRun struct {
+	// RunID is the id of the run, kept by Executor.Resume. It is empty if the run had none.
+	RunID string `json:"run_id,omitempty"`
+	// Inputs are the inputs of the run.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps of the iterations before the suspended one.
+	Steps []schema.AgentStep `json:"steps"`
+	// Iteration is the number of the suspended iteration, starting from 0.
+	Iteration int `json:"iteration"`
+	// Actions are the actions of the suspended iteration, none of them was run.
+	Actions []schema.AgentAction `json:"actions"`
+	// Approvals are the decisions already taken, by index in Actions.
+	Approvals map[int]Approval `json:"approvals"`
+	// Pending are the indexes in Actions of the actions waiting for an approval.
+	Pending []int `json:"pending"`
+	// Usage is the usage of the models recorded during the run so far.
+	Usage RunUsage `json:"usage"`
+	// StartedAt is the time the run started. The budget of the resumed run is counted
+	// from this usage and this time.
+	StartedAt time.Time `json:"started_at"`
+}
+
+// PendingActions returns the actions waiting for an approval, by index in Actions.
+func (r *SuspendedRun) PendingActions() map[int]schema.AgentAction {
+	actions := make(map[int]schema.AgentAction, len(r.Pending))
+	for _, i := range r.Pending {
+		actions[i] = r.Actions[i]
+	}
+	return actions
+}
+
+// ApprovalPendingError is returned by the executor when a run is suspended until actions
+// are approved. It wraps ErrApprovalPending.
+type ApprovalPendingError struct {
+	Run *SuspendedRun
+}
+
+func (e *ApprovalPendingError) Error() string {
+	return fmt.Sprintf("%s for %d actions", ErrApprovalPending, len(e.Run.Pending))
+}
+
+func (e *ApprovalPendingError) Unwrap() error {
+	return ErrApprovalPending
+}
+
+// approve asks the approver about the actions needing an approval and without decision.
+// It returns the decisions and the indexes of the actions whose approval is pending.
+func (e *Executor) approve(
+	ctx context.Context,
+	actions []schema.AgentAction,
+	approvals map[int]Approval,
+) (map[int]Approval, []int, error) {
+	if approvals == nil {
+		approvals = make(map[int]Approval)
+	}
+	if e.Approver == nil {
+		return approvals, nil, nil
+	}
+
+	var pending []int
+	for i, action := range actions {
+		if _, ok := approvals[i]; ok || !e.requiresApproval(action) {
+			continue
+		}
+		approval, err := e.Approver.Approve(ctx, action)
+		if errors.Is(err, ErrApprovalPending) {
+			pending = append(pending, i)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		approvals[i] = approval
+	}
+	return approvals, pending, nil
+}
+
+func (e *Executor) requiresApproval(action schema.AgentAction) bool {
+	if len(e.ApprovalTools) == 0 {
+		return true
+	}
+	for _, name := range e.ApprovalTools {
+		if strings.EqualFold(name, action.Tool) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package agents_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTool records its inputs and echoes them.
+type recordingTool struct {
+	name   string
+	inputs []string
+}
+
+func (r *recordingTool) Name() string        { return r.name }
+func (r *recordingTool) Description() string { return "Records its inputs." }
+
+func (r *recordingTool) Call(_ context.Context, input string) (string, error) {
+	r.inputs = append(r.inputs, input)
+	return r.name + " ran " + input, nil
+}
+
+// twoStepAgent returns its actions, then finishes with the observations.
+type twoStepAgent struct {
+	actions []schema.AgentAction
+	tools   []tools.Tool
+	plans   int
+}
+
+func (a *twoStepAgent) Plan(
+	_ context.Context,
+	steps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	a.plans++
+	if len(steps) == 0 {
+		return a.actions, nil, nil
+	}
+	observations := make([]string, 0, len(steps))
+	for _, step := range steps {
+		observations = append(observations, step.Observation)
+	}
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": observations}}, nil
+}
+
+func (a *twoStepAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *twoStepAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *twoStepAgent) GetTools() []tools.Tool  { return a.tools }
+
+func newApprovalTestAgent() (*twoStepAgent, *recordingTool, *recordingTool) {
+	sql := &recordingTool{name: "sql"}
+	search := &recordingTool{name: "search"}
+	return &twoStepAgent{
+		actions: []schema.AgentAction{
+			{Tool: "search", ToolInput: "customers"},
+			{Tool: "SQL", ToolInput: "DELETE FROM customers"},
+			{Tool: "sql", ToolInput: "UPDATE customers SET active = false"},
+		},
+		tools: []tools.Tool{sql, search},
+	}, sql, search
+}
+
+func TestExecutorApprover(t *testing.T) {
+	t.Parallel()
+
+	agent, sql, search := newApprovalTestAgent()
+	var asked []string
+	approver := agents.ApproverFunc(func(_ context.Context, action schema.AgentAction) (agents.Approval, error) {
+		asked = append(asked, action.ToolInput)
+		if action.ToolInput == "DELETE FROM customers" {
+			return agents.Reject("Deleting customers is not allowed."), nil
+		}
+		return agents.ApproveWithInput("UPDATE customers SET active = false WHERE id = 1"), nil
+	})
+	executor := agents.NewExecutor(agent, agents.WithApprover(approver, "sql"))
+
+	outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "deactivate customer 1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"DELETE FROM customers", "UPDATE customers SET active = false"}, asked)
+	require.Equal(t, []string{"customers"}, search.inputs)
+	require.Equal(t, []string{"UPDATE customers SET active = false WHERE id = 1"}, sql.inputs)
+	require.Equal(t, []string{
+		"search ran customers",
+		"Deleting customers is not allowed.",
+		"sql ran UPDATE customers SET active = false WHERE id = 1",
+	}, outputs["output"])
+
+	failing := agents.ApproverFunc(func(context.Context, schema.AgentAction) (agents.Approval, error) {
+		return agents.Approval{}, errors.New("approval service down")
+	})
+	executor = agents.NewExecutor(agent, agents.WithApprover(failing))
+	_, err = chains.Call(t.Context(), executor, map[string]any{"input": "deactivate customer 1"})
+	require.EqualError(t, err, "approval service down")
+}
+
+func TestExecutorSuspendForApproval(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	agent, sql, search := newApprovalTestAgent()
+	executor := agents.NewExecutor(agent, agents.WithApprover(agents.NewSuspendApprover(), "sql"))
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "deactivate customer 1"})
+	require.ErrorIs(t, err, agents.ErrApprovalPending)
+	var pendingErr *agents.ApprovalPendingError
+	require.ErrorAs(t, err, &pendingErr)
+	require.Equal(t, map[int]schema.AgentAction{
+		1: agent.actions[1],
+		2: agent.actions[2],
+	}, pendingErr.Run.PendingActions())
+	require.Empty(t, search.inputs, "no action runs before the approvals")
+
+	// the run is stored while waiting for the approvals
+	data, err := json.Marshal(pendingErr.Run)
+	require.NoError(t, err)
+	var run agents.SuspendedRun
+	require.NoError(t, json.Unmarshal(data, &run))
+
+	// a missing decision suspends the run again
+	_, err = executor.Resume(ctx, &run, map[int]agents.Approval{1: agents.Reject("")})
+	require.ErrorAs(t, err, &pendingErr)
+	require.Equal(t, []int{2}, pendingErr.Run.Pending)
+
+	outputs, err := executor.Resume(ctx, pendingErr.Run, map[int]agents.Approval{2: agents.Approve()})
+	require.NoError(t, err)
+	require.Equal(t, []string{"customers"}, search.inputs)
+	require.Equal(t, []string{"UPDATE customers SET active = false"}, sql.inputs)
+	require.Equal(t, []string{
+		"search ran customers",
+		"The action was rejected by the user.",
+		"sql ran UPDATE customers SET active = false",
+	}, outputs["output"])
+}
+
+func TestExecutorSuspendForApprovalCheckpoints(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(t.Context(), "run-1")
+
+	store := checkpoint.NewInMemory()
+	agent, sql, _ := newApprovalTestAgent()
+	executor := agents.NewExecutor(agent,
+		agents.WithApprover(agents.NewSuspendApprover(), "sql"), agents.WithCheckpointStore(store))
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "deactivate customer 1"})
+	var pendingErr *agents.ApprovalPendingError
+	require.ErrorAs(t, err, &pendingErr)
+	require.Equal(t, "run-1", pendingErr.Run.RunID)
+
+	cp, err := store.Load(ctx, "run-1")
+	require.NoError(t, err)
+	require.Equal(t, 0, cp.Iteration)
+	require.Equal(t, agent.actions, cp.Actions)
+
+	// the resumed run keeps its id and its checkpoints
+	outputs, err := executor.Resume(t.Context(), pendingErr.Run, map[int]agents.Approval{
+		1: agents.Reject(""),
+		2: agents.Approve(),
+	})
+	require.NoError(t, err)
+	require.Len(t, outputs["output"], 3)
+	require.Equal(t, []string{"UPDATE customers SET active = false"}, sql.inputs)
+	ids, err := store.List(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, []string{"run-1"}, ids)
+	cp, err = store.Load(t.Context(), "run-1")
+	require.NoError(t, err)
+	require.True(t, cp.Finished)
+	require.Empty(t, cp.Actions)
+}
+
+func TestExecutorResumeRunSuspended(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(t.Context(), "run-1")
+
+	store := checkpoint.NewInMemory()
+	agent, sql, search := newApprovalTestAgent()
+	executor := agents.NewExecutor(agent,
+		agents.WithApprover(agents.NewSuspendApprover(), "sql"), agents.WithCheckpointStore(store))
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "deactivate customer 1"})
+	require.ErrorIs(t, err, agents.ErrApprovalPending)
+	require.Equal(t, 1, agent.plans)
+
+	// a new process resumes the suspended iteration without planning it again
+	approver := agents.ApproverFunc(func(context.Context, schema.AgentAction) (agents.Approval, error) {
+		return agents.Approve(), nil
+	})
+	executor = agents.NewExecutor(agent, agents.WithApprover(approver, "sql"), agents.WithCheckpointStore(store))
+	outputs, err := executor.ResumeRun(t.Context(), "run-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, agent.plans)
+	require.Equal(t, []string{"customers"}, search.inputs)
+	require.Equal(t, []string{"DELETE FROM customers", "UPDATE customers SET active = false"}, sql.inputs)
+	require.Len(t, outputs["output"], 3)
+}
+
+func TestExecutorLoadSuspendedRun(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(t.Context(), "run-1")
+
+	store := checkpoint.NewInMemory()
+	agent, sql, _ := newApprovalTestAgent()
+	executor := agents.NewExecutor(agent,
+		agents.WithApprover(agents.NewSuspendApprover(), "sql"), agents.WithCheckpointStore(store))
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "deactivate customer 1"})
+	var pendingErr *agents.ApprovalPendingError
+	require.ErrorAs(t, err, &pendingErr)
+	_, err = executor.Resume(t.Context(), pendingErr.Run, map[int]agents.Approval{1: agents.Reject("no deletes")})
+	require.ErrorAs(t, err, &pendingErr)
+
+	// a new process loads the run with the decisions already taken
+	executor = agents.NewExecutor(agent,
+		agents.WithApprover(agents.NewSuspendApprover(), "sql"), agents.WithCheckpointStore(store))
+	run, err := executor.LoadSuspendedRun(t.Context(), "run-1")
+	require.NoError(t, err)
+	require.Equal(t, map[int]agents.Approval{1: agents.Reject("no deletes")}, run.Approvals)
+	require.Equal(t, []int{2}, run.Pending)
+
+	// resuming without the pending decision keeps the decisions taken
+	_, err = executor.ResumeRun(t.Context(), "run-1")
+	require.ErrorAs(t, err, &pendingErr)
+	require.Equal(t, []int{2}, pendingErr.Run.Pending)
+	require.Equal(t, run.Approvals, pendingErr.Run.Approvals)
+
+	outputs, err := executor.Resume(t.Context(), run, map[int]agents.Approval{2: agents.Approve()})
+	require.NoError(t, err)
+	require.Equal(t, []string{"UPDATE customers SET active = false"}, sql.inputs)
+	require.Equal(t, "no deletes", outputs["output"].([]string)[1])
+
+	_, err = executor.LoadSuspendedRun(t.Context(), "run-1")
+	require.ErrorIs(t, err, agents.ErrRunNotSuspended)
+	_, err = agents.NewExecutor(agent).LoadSuspendedRun(t.Context(), "run-1")
+	require.ErrorIs(t, err, agents.ErrNoCheckpointStore)
+}
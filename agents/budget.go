@@ -0,0 +1,314 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	// _finalAnswerThought leads the completion agents to a final answer.
+	_finalAnswerThought = "I now need to return a final answer based on the previous steps."
+	// _finalAnswerRequest asks the chat agents for a final answer.
+	_finalAnswerRequest = "Stop using tools and give your final answer based on the previous steps."
+)
+
+// Budget limits the resources used by a run of an Executor. The zero value of a field
+// means no limit. The tokens and the cost are those of the model responses recorded in
+// the context of the run, see RecordUsage. The usage of a run is saved in its
+// checkpoints and suspended state, and counted again when the run is resumed.
+type Budget struct {
+	// MaxDuration is the maximum elapsed time of the run, counted from its start, also
+	// when it is resumed from a checkpoint. The context of the agent and the tools is
+	// canceled when it is reached.
+	MaxDuration time.Duration
+	// MaxPromptTokens is the maximum number of prompt tokens of the run.
+	MaxPromptTokens int
+	// MaxCompletionTokens is the maximum number of completion tokens of the run.
+	MaxCompletionTokens int
+	// MaxTokens is the maximum number of prompt and completion tokens of the run.
+	MaxTokens int
+	// MaxCost is the maximum estimated cost of the run in dollars.
+	MaxCost float64
+	// Prices are the prices of the models, used to estimate the cost of each response
+	// as its model, see callbacks.ResponseModel. Responses of models missing from the
+	// table cost nothing.
+	Prices callbacks.PriceTable
+	// Model is the model the responses are priced as when their provider reports none.
+	Model string
+}
+
+// RunUsage is the usage of the models recorded during a run.
+type RunUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	// Cost is the cost in dollars estimated with the prices of the budget.
+	Cost float64 `json:"cost"`
+}
+
+// EarlyStoppingMethod is how an Executor ends a run stopped by its max iterations or
+// its budget.
+type EarlyStoppingMethod string
+
+const (
+	// EarlyStoppingNone returns ErrNotFinished, or an error wrapping ErrBudgetExceeded.
+	EarlyStoppingNone EarlyStoppingMethod = ""
+	// EarlyStoppingPartial returns the observation of the last step as the answer.
+	EarlyStoppingPartial EarlyStoppingMethod = "partial"
+	// EarlyStoppingGenerate asks the agent for a last answer from the steps, if the agent
+	// is a FinalAnswerer, and returns the observation of the last step otherwise.
+	EarlyStoppingGenerate EarlyStoppingMethod = "generate"
+)
+
+// FinalAnswerer is implemented by the agents able to answer from the previous steps
+// without using more tools, as done by the EarlyStoppingGenerate method.
+type FinalAnswerer interface {
+	FinalAnswer(ctx context.Context, intermediateSteps []schema.AgentStep, inputs map[string]string) (*schema.AgentFinish, error) //nolint:lll
+}
+
+type runMeterKey struct{}
+
+// runMeter records the usage of a run, and of the runs it is nested in.
+type runMeter struct {
+	mu       sync.Mutex
+	budget   Budget
+	start    time.Time
+	usage    RunUsage
+	recorded map[*llms.ContentResponse]struct{}
+	parent   *runMeter
+}
+
+// RecordUsage adds the token usage of the model response to the usage of the run of the
+// context, and of the runs it is nested in. The agents of this package record the
+// responses of their models; the responses of other agents are recorded by setting a
+// UsageHandler as callbacks handler of their model. A response is only counted once.
+func RecordUsage(ctx context.Context, resp *llms.ContentResponse) {
+	meter, ok := ctx.Value(runMeterKey{}).(*runMeter)
+	if !ok || resp == nil {
+		return
+	}
+	if resp.Usage == nil {
+		return
+	}
+	for ; meter != nil; meter = meter.parent {
+		meter.record(resp)
+	}
+}
+
+// RunUsageFromContext returns the usage of the run of the context so far.
+func RunUsageFromContext(ctx context.Context) (RunUsage, bool) {
+	meter, ok := ctx.Value(runMeterKey{}).(*runMeter)
+	if !ok {
+		return RunUsage{}, false
+	}
+	meter.mu.Lock()
+	defer meter.mu.Unlock()
+	return meter.usage, true
+}
+
+// UsageHandler is a callbacks handler recording the usage of the model responses in
+// the run of their context, see RecordUsage.
+type UsageHandler struct {
+	callbacks.SimpleHandler
+}
+
+var _ callbacks.Handler = UsageHandler{}
+
+func (UsageHandler) HandleLLMGenerateContentEnd(ctx context.Context, res *llms.ContentResponse) {
+	RecordUsage(ctx, res)
+}
+
+// usageRecordingModel is a model recording the usage of its responses in the run of the
+// context, for the agents calling their model through a chain.
+type usageRecordingModel struct {
+	llms.Model
+}
+
+func recordingUsage(llm llms.Model) llms.Model { //nolint:ireturn
+	if llm == nil {
+		return nil
+	}
+	return usageRecordingModel{Model: llm}
+}
+
+func (m usageRecordingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m usageRecordingModel) GenerateContent(
+	ctx context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	resp, err := m.Model.GenerateContent(ctx, messages, options...)
+	if err == nil {
+		RecordUsage(ctx, resp)
+	}
+	return resp, err
+}
+
+// withBudget returns the context of a run metered with the budget, from the usage and
+// the start time of the run so far. A run starting now has a zero start time.
+func (e *Executor) withBudget(ctx context.Context, usage RunUsage, start time.Time) (context.Context, *runMeter) {
+	if start.IsZero() {
+		start = time.Now()
+	}
+	meter := &runMeter{
+		budget:   e.Budget,
+		start:    start,
+		usage:    usage,
+		recorded: make(map[*llms.ContentResponse]struct{}),
+	}
+	meter.parent, _ = ctx.Value(runMeterKey{}).(*runMeter)
+	return context.WithValue(ctx, runMeterKey{}, meter), meter
+}
+
+// runState returns the usage and the start time of the run of the context, to be
+// saved with its state.
+func runState(ctx context.Context) (RunUsage, time.Time) {
+	meter, ok := ctx.Value(runMeterKey{}).(*runMeter)
+	if !ok {
+		return RunUsage{}, time.Time{}
+	}
+	meter.mu.Lock()
+	defer meter.mu.Unlock()
+	return meter.usage, meter.start
+}
+
+// withDeadline returns the context canceled at the max duration of the budget, counted
+// from the start of the run.
+func (m *runMeter) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.budget.MaxDuration > 0 {
+		return context.WithDeadline(ctx, m.start.Add(m.budget.MaxDuration))
+	}
+	return ctx, func() {}
+}
+
+func (m *runMeter) record(resp *llms.ContentResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.recorded[resp]; ok {
+		return
+	}
+	m.recorded[resp] = struct{}{}
+	m.usage.PromptTokens += resp.Usage.PromptTokens
+	m.usage.CompletionTokens += resp.Usage.CompletionTokens
+	if price, ok := m.budget.Prices.Price(callbacks.ResponseModel(resp, m.budget.Model)); ok {
+		m.usage.Cost += price.Cost(*resp.Usage)
+	}
+}
+
+// check returns an error wrapping ErrBudgetExceeded if a limit of the budget is reached.
+func (m *runMeter) check() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, u := m.budget, m.usage
+	switch {
+	case b.MaxDuration > 0 && time.Since(m.start) >= b.MaxDuration:
+		return fmt.Errorf("%w: run took more than %s", ErrBudgetExceeded, b.MaxDuration)
+	case b.MaxPromptTokens > 0 && u.PromptTokens >= b.MaxPromptTokens:
+		return fmt.Errorf("%w: %d prompt tokens used", ErrBudgetExceeded, u.PromptTokens)
+	case b.MaxCompletionTokens > 0 && u.CompletionTokens >= b.MaxCompletionTokens:
+		return fmt.Errorf("%w: %d completion tokens used", ErrBudgetExceeded, u.CompletionTokens)
+	case b.MaxTokens > 0 && u.PromptTokens+u.CompletionTokens >= b.MaxTokens:
+		return fmt.Errorf("%w: %d tokens used", ErrBudgetExceeded, u.PromptTokens+u.CompletionTokens)
+	case b.MaxCost > 0 && u.Cost >= b.MaxCost:
+		return fmt.Errorf("%w: cost of %g", ErrBudgetExceeded, u.Cost)
+	}
+	return nil
+}
+
+// stopEarly ends a run stopped for the reason, an error wrapping ErrNotFinished or
+// ErrBudgetExceeded, as set by the EarlyStopping method of the executor.
+func (e *Executor) stopEarly(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	iteration int,
+	reason error,
+) (map[string]any, error) {
+	var finish *schema.AgentFinish
+	if e.EarlyStopping == EarlyStoppingGenerate {
+		if answerer, ok := e.Agent.(FinalAnswerer); ok {
+			var err error
+			finish, err = answerer.FinalAnswer(ctx, steps, inputs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if finish == nil && e.EarlyStopping != EarlyStoppingNone && len(steps) > 0 {
+		finish = &schema.AgentFinish{ReturnValues: map[string]any{
+			e.outputKey(): steps[len(steps)-1].Observation,
+		}}
+	}
+
+	if finish == nil {
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleAgentFinish(ctx, schema.AgentFinish{
+				ReturnValues: map[string]any{"output": reason.Error()},
+			})
+		}
+		return e.getReturn(&schema.AgentFinish{ReturnValues: make(map[string]any)}, steps), reason
+	}
+
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleAgentFinish(ctx, *finish)
+	}
+	outputs := e.getReturn(finish, steps)
+	if err := e.saveCheckpoint(ctx, inputs, steps, iteration, outputs); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// generateFinalAnswer calls the model with the messages followed by a request for a
+// final answer, whose text is returned as the finish.
+func generateFinalAnswer(
+	ctx context.Context,
+	llm llms.Model,
+	messages []llms.MessageContent,
+	outputKey string,
+	options ...llms.CallOption,
+) (*schema.AgentFinish, error) {
+	messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, _finalAnswerRequest))
+	resp, err := llm.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+	RecordUsage(ctx, resp)
+
+	texts := make([]string, 0, len(resp.Choices))
+	for _, choice := range resp.Choices {
+		if choice.Content != "" {
+			texts = append(texts, choice.Content)
+		}
+	}
+	answer := strings.Join(texts, "\n")
+	return &schema.AgentFinish{ReturnValues: map[string]any{outputKey: answer}, Log: answer}, nil
+}
+
+// completionFinish returns the finish parsed from the output, or the output as the answer.
+func completionFinish(
+	output string,
+	outputKey string,
+	parse func(string) ([]schema.AgentAction, *schema.AgentFinish, error),
+) *schema.AgentFinish {
+	if _, finish, err := parse(output); err == nil && finish != nil {
+		return finish
+	}
+	return &schema.AgentFinish{ReturnValues: map[string]any{outputKey: output}, Log: output}
+}
+
+func (e *Executor) outputKey() string {
+	if keys := e.Agent.GetOutputKeys(); len(keys) > 0 {
+		return keys[0]
+	}
+	return _defaultOutputKey
+}
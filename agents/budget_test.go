@@ -0,0 +1,256 @@
+package agents_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/fake"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+func usageResponse(content string, promptTokens, completionTokens int, calls ...llms.ToolCall) *llms.ContentResponse {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		Content:   content,
+		ToolCalls: calls,
+	}}, Usage: &llms.Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+	}}
+}
+
+func TestExecutorTokenBudget(t *testing.T) {
+	t.Parallel()
+
+	newLLM := func() *scriptedToolsLLM {
+		return &scriptedToolsLLM{responses: []*llms.ContentResponse{
+			usageResponse("", 40, 20, toolCall("call_1", "search", "a")),
+			usageResponse("", 40, 20, toolCall("call_2", "search", "b")),
+			usageResponse("It is b.", 40, 20),
+		}}
+	}
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		search := &recordingTool{name: "search"}
+		executor := agents.NewExecutor(agents.NewToolCallingAgent(newLLM(), []tools.Tool{search}),
+			agents.WithBudget(agents.Budget{MaxTokens: 100}))
+
+		_, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+		require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+		assert.Equal(t, []string{"a", "b"}, search.inputs)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		t.Parallel()
+
+		executor := agents.NewExecutor(
+			agents.NewToolCallingAgent(newLLM(), []tools.Tool{&recordingTool{name: "search"}}),
+			agents.WithBudget(agents.Budget{MaxTokens: 100}),
+			agents.WithEarlyStopping(agents.EarlyStoppingPartial))
+
+		outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+		require.NoError(t, err)
+		assert.Equal(t, "search ran b", outputs["output"])
+	})
+
+	t.Run("generate", func(t *testing.T) {
+		t.Parallel()
+
+		llm := newLLM()
+		executor := agents.NewExecutor(
+			agents.NewToolCallingAgent(llm, []tools.Tool{&recordingTool{name: "search"}}),
+			agents.WithBudget(agents.Budget{MaxTokens: 100}),
+			agents.WithEarlyStopping(agents.EarlyStoppingGenerate))
+
+		outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+		require.NoError(t, err)
+		assert.Equal(t, "It is b.", outputs["output"])
+
+		require.Len(t, llm.messages, 3)
+		last := llm.messages[2][len(llm.messages[2])-1]
+		assert.Equal(t, llms.ChatMessageTypeHuman, last.Role)
+	})
+}
+
+func TestExecutorTokenBudgetOneShotAgent(t *testing.T) {
+	t.Parallel()
+
+	search := &recordingTool{name: "search"}
+	llm := &scriptedToolsLLM{responses: []*llms.ContentResponse{
+		usageResponse("Thought: search\nAction: search\nAction Input: a", 40, 20),
+		usageResponse("Thought: search\nAction: search\nAction Input: b", 40, 20),
+		usageResponse("Final Answer: b", 40, 20),
+	}}
+	executor := agents.NewExecutor(agents.NewOneShotAgent(llm, []tools.Tool{search}),
+		agents.WithBudget(agents.Budget{MaxTokens: 100}))
+
+	// the agent records the usage of its chain without a UsageHandler
+	_, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+	assert.Equal(t, []string{"a", "b"}, search.inputs)
+}
+
+func TestExecutorCostBudget(t *testing.T) {
+	t.Parallel()
+
+	prices := callbacks.PriceTable{
+		"small": {Input: 1, Output: 2},
+		"large": {Input: 10, Output: 30},
+	}
+	newLLM := func(model string) *scriptedToolsLLM {
+		responses := []*llms.ContentResponse{
+			usageResponse("", 1000, 100, toolCall("call_1", "search", "a")),
+			usageResponse("", 1000, 100, toolCall("call_2", "search", "b")),
+			usageResponse("It is b.", 1000, 100),
+		}
+		for _, resp := range responses {
+			resp.Model = model
+		}
+		return &scriptedToolsLLM{responses: responses}
+	}
+
+	// the responses are priced as their model: 0.013 per response of the large model
+	search := &recordingTool{name: "search"}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(newLLM("large-2025-01-01"), []tools.Tool{search}),
+		agents.WithBudget(agents.Budget{MaxCost: 0.01, Prices: prices, Model: "small"}))
+	_, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+	assert.Equal(t, []string{"a"}, search.inputs)
+
+	// and as the model of the budget if they report none: 0.0012 per response
+	search = &recordingTool{name: "search"}
+	executor = agents.NewExecutor(agents.NewToolCallingAgent(newLLM(""), []tools.Tool{search}),
+		agents.WithBudget(agents.Budget{MaxCost: 0.01, Prices: prices, Model: "small"}))
+	outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+	require.NoError(t, err)
+	assert.Equal(t, "It is b.", outputs["output"])
+}
+
+// slowTool blocks until its context is done from its second call.
+type slowTool struct {
+	calls int
+}
+
+func (s *slowTool) Name() string        { return "slow" }
+func (s *slowTool) Description() string { return "Gets slower." }
+
+func (s *slowTool) Call(ctx context.Context, _ string) (string, error) {
+	s.calls++
+	if s.calls == 1 {
+		return "call 1", nil
+	}
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestExecutorDurationBudget(t *testing.T) {
+	t.Parallel()
+
+	tool := &slowTool{}
+	agent := &testAgent{actions: []schema.AgentAction{{Tool: "slow"}}, tools: []tools.Tool{tool}}
+	executor := agents.NewExecutor(agent,
+		agents.WithMaxIterations(5),
+		agents.WithBudget(agents.Budget{MaxDuration: 50 * time.Millisecond}),
+		agents.WithEarlyStopping(agents.EarlyStoppingPartial))
+
+	outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+	require.NoError(t, err)
+	assert.Equal(t, "call 1", outputs["output"])
+	assert.Equal(t, 2, tool.calls)
+}
+
+func TestExecutorBudgetResumeRun(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(t.Context(), "run-1")
+	store := checkpoint.NewInMemory()
+
+	llm := &scriptedToolsLLM{responses: []*llms.ContentResponse{
+		usageResponse("", 40, 20, toolCall("call_1", "search", "a")),
+	}}
+	stopped := agents.NewExecutor(agents.NewToolCallingAgent(llm, []tools.Tool{&recordingTool{name: "search"}}),
+		agents.WithMaxIterations(1),
+		agents.WithCheckpointStore(store))
+	_, err := chains.Call(ctx, stopped, map[string]any{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+
+	cp, err := store.Load(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.Usage{PromptTokens: 40, CompletionTokens: 20}, cp.Usage)
+	assert.False(t, cp.StartedAt.IsZero())
+
+	// the resumed run counts the tokens used before it was stopped
+	llm = &scriptedToolsLLM{responses: []*llms.ContentResponse{
+		usageResponse("", 40, 20, toolCall("call_2", "search", "b")),
+		usageResponse("It is b.", 40, 20),
+	}}
+	search := &recordingTool{name: "search"}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(llm, []tools.Tool{search}),
+		agents.WithCheckpointStore(store),
+		agents.WithBudget(agents.Budget{MaxTokens: 100}))
+	_, err = executor.ResumeRun(t.Context(), "run-1")
+	require.ErrorIs(t, err, agents.ErrBudgetExceeded)
+	assert.Equal(t, []string{"b"}, search.inputs)
+
+	resumed, err := store.Load(ctx, "run-1")
+	require.NoError(t, err)
+	assert.Equal(t, checkpoint.Usage{PromptTokens: 80, CompletionTokens: 40}, resumed.Usage)
+	assert.True(t, resumed.StartedAt.Equal(cp.StartedAt))
+}
+
+func TestExecutorMaxIterationsGenerate(t *testing.T) {
+	t.Parallel()
+
+	responses := make([]string, 0, 3)
+	for i := range 2 {
+		responses = append(responses, "Thought: search\nAction: search\nAction Input: "+strconv.Itoa(i))
+	}
+	responses = append(responses, "Final Answer: found it")
+	agent := agents.NewOneShotAgent(fake.NewFakeLLM(responses), []tools.Tool{&recordingTool{name: "search"}})
+	executor := agents.NewExecutor(agent,
+		agents.WithMaxIterations(2),
+		agents.WithEarlyStopping(agents.EarlyStoppingGenerate))
+
+	outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+	require.NoError(t, err)
+	assert.Equal(t, " found it", outputs["output"])
+}
+
+// usageTool records a model response in the run of its context.
+type usageTool struct {
+	resp  *llms.ContentResponse
+	usage agents.RunUsage
+}
+
+func (u *usageTool) Name() string        { return "usage" }
+func (u *usageTool) Description() string { return "Records a response." }
+
+func (u *usageTool) Call(ctx context.Context, _ string) (string, error) {
+	agents.UsageHandler{}.HandleLLMGenerateContentEnd(ctx, u.resp)
+	// a response is only counted once
+	agents.RecordUsage(ctx, u.resp)
+	u.usage, _ = agents.RunUsageFromContext(ctx)
+	return "recorded", nil
+}
+
+func TestUsageHandler(t *testing.T) {
+	t.Parallel()
+
+	tool := &usageTool{resp: usageResponse("", 10, 5)}
+	agent := &testAgent{actions: []schema.AgentAction{{Tool: "usage"}}, tools: []tools.Tool{tool}}
+	executor := agents.NewExecutor(agent, agents.WithMaxIterations(1))
+
+	_, err := chains.Call(t.Context(), executor, map[string]any{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	assert.Equal(t, agents.RunUsage{PromptTokens: 10, CompletionTokens: 5}, tool.usage)
+}
@@ -0,0 +1,73 @@
+// Package checkpoint contains stores of the checkpoints written by agents.Executor after
+// every iteration of a run, so that runs survive restarts and can be resumed or replayed
+// with agents.Executor.ResumeRun. Stores are available in memory (NewInMemory), as JSON
+// files (NewFile) and in SQLite (package sqlite3).
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+// ErrNotFound is returned when loading a run without checkpoint.
+var ErrNotFound = errors.New("checkpoint not found")
+
+// Checkpoint is the state of an agent run after an iteration.
+type Checkpoint struct {
+	// RunID identifies the run.
+	RunID string `json:"run_id"`
+	// Inputs are the inputs of the run.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps of the run so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// Iteration is the number of iterations done.
+	Iteration int `json:"iteration"`
+	// Actions are the actions of the iteration the run is suspended on until they are
+	// approved. None of them was run.
+	Actions []schema.AgentAction `json:"actions,omitempty"`
+	// Approvals are the decisions already taken about the Actions, by index.
+	Approvals map[int]Approval `json:"approvals,omitempty"`
+	// Pending are the indexes in Actions of the actions waiting for an approval.
+	Pending []int `json:"pending,omitempty"`
+	// Finished is set once the agent returned a finish.
+	Finished bool `json:"finished"`
+	// Outputs are the outputs of a finished run. Persistent stores decode them from JSON.
+	Outputs map[string]any `json:"outputs,omitempty"`
+	// Usage is the usage of the models recorded during the run so far, counted in the
+	// budget of the run when it is resumed.
+	Usage Usage `json:"usage"`
+	// StartedAt is the time the run started, from which the max duration of its budget
+	// is counted.
+	StartedAt time.Time `json:"started_at"`
+	// UpdatedAt is the time the checkpoint was written.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Usage is the usage of the models recorded during a run.
+type Usage struct {
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// Approval is a decision about an action of a suspended run, see agents.Approval.
+type Approval struct {
+	Approved bool   `json:"approved"`
+	Input    string `json:"input,omitempty"`
+	Feedback string `json:"feedback,omitempty"`
+}
+
+// Store keeps the last checkpoint of every run.
+type Store interface {
+	// Save stores the checkpoint, replacing the previous one of the run.
+	Save(ctx context.Context, checkpoint Checkpoint) error
+	// Load returns the last checkpoint of the run, or ErrNotFound.
+	Load(ctx context.Context, runID string) (Checkpoint, error)
+	// List returns the ids of the runs with a checkpoint.
+	List(ctx context.Context) ([]string, error)
+	// Delete removes the checkpoint of the run.
+	Delete(ctx context.Context, runID string) error
+}
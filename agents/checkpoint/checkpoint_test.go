@@ -0,0 +1,62 @@
+package checkpoint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	t.Parallel()
+
+	file, err := NewFile(t.TempDir())
+	require.NoError(t, err)
+
+	for name, store := range map[string]Store{
+		"inmemory": NewInMemory(),
+		"file":     file,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			_, err := store.Load(ctx, "run/1")
+			require.ErrorIs(t, err, ErrNotFound)
+
+			cp := Checkpoint{
+				RunID:  "run/1",
+				Inputs: map[string]string{"input": "question"},
+				Steps: []schema.AgentStep{{
+					Action:      schema.AgentAction{Tool: "search", ToolInput: "query", ToolID: "call_1"},
+					Observation: "result",
+				}},
+				Iteration: 1,
+				UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+			}
+			require.NoError(t, store.Save(ctx, cp))
+			require.NoError(t, store.Save(ctx, Checkpoint{RunID: "run 2", Iteration: 3}))
+
+			got, err := store.Load(ctx, "run/1")
+			require.NoError(t, err)
+			require.Equal(t, cp, got)
+
+			cp.Iteration, cp.Finished, cp.Outputs = 2, true, map[string]any{"output": "answer"}
+			require.NoError(t, store.Save(ctx, cp))
+			got, err = store.Load(ctx, "run/1")
+			require.NoError(t, err)
+			require.Equal(t, cp, got)
+
+			ids, err := store.List(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []string{"run 2", "run/1"}, ids)
+
+			require.NoError(t, store.Delete(ctx, "run/1"))
+			require.NoError(t, store.Delete(ctx, "missing"))
+			_, err = store.Load(ctx, "run/1")
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var _ Store = &File{}
+
+// File is a Store keeping the checkpoint of each run as a JSON file in a directory.
+// File names are the base64url encoded run ids, so any id can be used.
+type File struct {
+	dir string
+}
+
+// NewFile creates a File store in the directory, creating it if needed.
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir}, nil
+}
+
+// Save writes the checkpoint to a temporary file first and then renames it, so a
+// crash while saving leaves the previous checkpoint intact.
+func (s *File) Save(_ context.Context, checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(checkpoint.RunID))
+}
+
+// Load reads the last checkpoint of the run.
+func (s *File) Load(_ context.Context, runID string) (Checkpoint, error) {
+	data, err := os.ReadFile(s.path(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return Checkpoint{}, ErrNotFound
+	}
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return Checkpoint{}, err
+	}
+	return checkpoint, nil
+}
+
+// List returns the sorted ids of the runs with a checkpoint file.
+func (s *File) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		id, err := base64.RawURLEncoding.DecodeString(name)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Delete removes the checkpoint file of the run.
+func (s *File) Delete(_ context.Context, runID string) error {
+	if err := os.Remove(s.path(runID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the path of the checkpoint file of the run.
+func (s *File) path(runID string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(runID))+".json")
+}
@@ -0,0 +1,65 @@
+package checkpoint
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+)
+
+var _ Store = &InMemory{}
+
+// InMemory is a Store keeping the checkpoints in a map, lost when the process exits.
+type InMemory struct {
+	mu          sync.RWMutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewInMemory creates an empty InMemory store.
+func NewInMemory() *InMemory {
+	return &InMemory{checkpoints: make(map[string]Checkpoint)}
+}
+
+// Save stores a copy of the checkpoint.
+func (s *InMemory) Save(_ context.Context, checkpoint Checkpoint) error {
+	checkpoint.Steps = slices.Clone(checkpoint.Steps)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[checkpoint.RunID] = checkpoint
+	return nil
+}
+
+// Load returns the last checkpoint of the run.
+func (s *InMemory) Load(_ context.Context, runID string) (Checkpoint, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	checkpoint, ok := s.checkpoints[runID]
+	if !ok {
+		return Checkpoint{}, ErrNotFound
+	}
+	checkpoint.Steps = slices.Clone(checkpoint.Steps)
+	return checkpoint, nil
+}
+
+// List returns the sorted ids of the runs with a checkpoint.
+func (s *InMemory) List(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := make([]string, 0, len(s.checkpoints))
+	for id := range s.checkpoints {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Delete removes the checkpoint of the run.
+func (s *InMemory) Delete(_ context.Context, runID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkpoints, runID)
+	return nil
+}
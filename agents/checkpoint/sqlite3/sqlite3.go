@@ -0,0 +1,118 @@
+// Package sqlite3 contains a checkpoint.Store keeping agent run checkpoints in SQLite.
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+)
+
+// DefaultTableName is the default name of the checkpoints table.
+const DefaultTableName = "langchaingo_checkpoints"
+
+// _schema creates the checkpoints table.
+const _schema = `CREATE TABLE IF NOT EXISTS %s (
+		run_id TEXT PRIMARY KEY,
+		checkpoint TEXT NOT NULL,
+		updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);`
+
+var _ checkpoint.Store = &Store{}
+
+// Store is a checkpoint.Store keeping the last checkpoint of each run as JSON in a table.
+type Store struct {
+	// DB is the database connection.
+	DB *sql.DB
+	// TableName is the name of the checkpoints table.
+	TableName string
+}
+
+// Option is a function type that can be used to modify the Store.
+type Option func(s *Store)
+
+// WithTableName is an option for specifying the name of the checkpoints table.
+func WithTableName(name string) Option {
+	return func(s *Store) {
+		s.TableName = name
+	}
+}
+
+// New creates a Store in the database, creating the checkpoints table if needed.
+func New(ctx context.Context, db *sql.DB, opts ...Option) (*Store, error) {
+	s := &Store{
+		DB:        db,
+		TableName: DefaultTableName,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if _, err := s.DB.ExecContext(ctx, fmt.Sprintf(_schema, s.TableName)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save stores the checkpoint, replacing the previous one of the run.
+func (s *Store) Save(ctx context.Context, cp checkpoint.Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (run_id, checkpoint, updated) VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT(run_id) DO UPDATE SET checkpoint = excluded.checkpoint, updated = excluded.updated;`, s.TableName)
+	_, err = s.DB.ExecContext(ctx, query, cp.RunID, string(data))
+	return err
+}
+
+// Load returns the last checkpoint of the run.
+func (s *Store) Load(ctx context.Context, runID string) (checkpoint.Checkpoint, error) {
+	query := fmt.Sprintf("SELECT checkpoint FROM %s WHERE run_id = ?;", s.TableName)
+
+	var data string
+	err := s.DB.QueryRowContext(ctx, query, runID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return checkpoint.Checkpoint{}, checkpoint.ErrNotFound
+	}
+	if err != nil {
+		return checkpoint.Checkpoint{}, err
+	}
+
+	var cp checkpoint.Checkpoint
+	if err := json.Unmarshal([]byte(data), &cp); err != nil {
+		return checkpoint.Checkpoint{}, err
+	}
+	return cp, nil
+}
+
+// List returns the sorted ids of the runs with a checkpoint.
+func (s *Store) List(ctx context.Context) ([]string, error) {
+	rows, err := s.DB.QueryContext(ctx, fmt.Sprintf("SELECT run_id FROM %s ORDER BY run_id;", s.TableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Delete removes the checkpoint of the run.
+func (s *Store) Delete(ctx context.Context, runID string) error {
+	_, err := s.DB.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE run_id = ?;", s.TableName), runID)
+	return err
+}
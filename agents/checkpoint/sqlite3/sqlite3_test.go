@@ -0,0 +1,51 @@
+package sqlite3_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
+	"github.com/vxcontrol/langchaingo/agents/checkpoint/sqlite3"
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	store, err := sqlite3.New(ctx, db, sqlite3.WithTableName("runs"))
+	require.NoError(t, err)
+
+	_, err = store.Load(ctx, "run-1")
+	require.ErrorIs(t, err, checkpoint.ErrNotFound)
+
+	cp := checkpoint.Checkpoint{
+		RunID:     "run-1",
+		Inputs:    map[string]string{"input": "question"},
+		Steps:     []schema.AgentStep{{Action: schema.AgentAction{Tool: "search"}, Observation: "result"}},
+		Iteration: 1,
+	}
+	require.NoError(t, store.Save(ctx, cp))
+	cp.Iteration, cp.Finished, cp.Outputs = 2, true, map[string]any{"output": "answer"}
+	require.NoError(t, store.Save(ctx, cp))
+	require.NoError(t, store.Save(ctx, checkpoint.Checkpoint{RunID: "run-0"}))
+
+	got, err := store.Load(ctx, "run-1")
+	require.NoError(t, err)
+	require.Equal(t, cp, got)
+
+	ids, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"run-0", "run-1"}, ids)
+
+	require.NoError(t, store.Delete(ctx, "run-1"))
+	_, err = store.Load(ctx, "run-1")
+	require.ErrorIs(t, err, checkpoint.ErrNotFound)
+}
@@ -0,0 +1,174 @@
+package agents
+
+import (
+	"context"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/google/uuid"
+)
+
+type runIDKey struct{}
+
+// ContextWithRunID returns a context making the executor run under the given id, the id of
+// its checkpoints. Runs get a generated id otherwise, see RunIDFromContext.
+func ContextWithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunIDFromContext returns the id of the run of the context, as given to the tools and
+// the callbacks of an executor run.
+func RunIDFromContext(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(runIDKey{}).(string)
+	return runID, ok && runID != ""
+}
+
+// ResumeRun continues the run with the given id from its last checkpoint, with the same
+// inputs and steps. The outputs of a finished run are returned without running the agent
+// again. A run suspended until actions are approved continues with the actions of the
+// suspended iteration and the decisions already taken, the approver being asked again
+// about the pending actions only. To give the pending decisions instead, load the run
+// with LoadSuspendedRun and continue it with Resume. As the run is not continued through
+// chains.Call, ResumeRun saves its inputs and outputs to the memory.
+func (e *Executor) ResumeRun(ctx context.Context, runID string) (map[string]any, error) {
+	if e.CheckpointStore == nil {
+		return nil, ErrNoCheckpointStore
+	}
+	cp, err := e.CheckpointStore.Load(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Finished {
+		return cp.Outputs, nil
+	}
+	if len(cp.Actions) != 0 {
+		run := suspendedRun(cp)
+		return e.resume(ctx, run, run.Approvals)
+	}
+
+	runCtx, meter := e.withBudget(ContextWithRunID(ctx, runID), runUsage(cp.Usage), cp.StartedAt)
+	outputs, err := e.run(runCtx, meter, cp.Inputs, cp.Steps, cp.Iteration)
+	if err != nil {
+		return outputs, err
+	}
+	return outputs, e.saveMemory(ctx, cp.Inputs, outputs)
+}
+
+// LoadSuspendedRun returns the state of the run with the given id, saved when it was
+// suspended until actions are approved, to continue it with Resume. It returns
+// ErrRunNotSuspended if the run is not waiting for approvals.
+func (e *Executor) LoadSuspendedRun(ctx context.Context, runID string) (*SuspendedRun, error) {
+	if e.CheckpointStore == nil {
+		return nil, ErrNoCheckpointStore
+	}
+	cp, err := e.CheckpointStore.Load(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Finished || len(cp.Actions) == 0 {
+		return nil, ErrRunNotSuspended
+	}
+	return suspendedRun(cp), nil
+}
+
+// withRunID returns a context with a run id, generating one if needed. Every run has an
+// id, with or without a CheckpointStore, to tell the runs apart in the callbacks.
+func (e *Executor) withRunID(ctx context.Context) context.Context {
+	if _, ok := RunIDFromContext(ctx); ok {
+		return ctx
+	}
+	return ContextWithRunID(ctx, uuid.NewString())
+}
+
+// saveCheckpoint saves the state of the run after the given number of iterations.
+func (e *Executor) saveCheckpoint(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	iteration int,
+	outputs map[string]any,
+) error {
+	if e.CheckpointStore == nil {
+		return nil
+	}
+	runID, _ := RunIDFromContext(ctx)
+	usage, start := runState(ctx)
+	return e.CheckpointStore.Save(ctx, checkpoint.Checkpoint{
+		RunID:     runID,
+		Inputs:    inputs,
+		Steps:     steps,
+		Iteration: iteration,
+		Finished:  outputs != nil,
+		Outputs:   outputs,
+		Usage:     checkpointUsage(usage),
+		StartedAt: start,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// saveSuspended saves the state of a run suspended until actions are approved.
+func (e *Executor) saveSuspended(ctx context.Context, run *SuspendedRun) error {
+	if e.CheckpointStore == nil {
+		return nil
+	}
+	return e.CheckpointStore.Save(ctx, checkpoint.Checkpoint{
+		RunID:     run.RunID,
+		Inputs:    run.Inputs,
+		Steps:     run.Steps,
+		Iteration: run.Iteration,
+		Actions:   run.Actions,
+		Approvals: checkpointApprovals(run.Approvals),
+		Pending:   run.Pending,
+		Usage:     checkpointUsage(run.Usage),
+		StartedAt: run.StartedAt,
+		UpdatedAt: time.Now(),
+	})
+}
+
+func checkpointUsage(usage RunUsage) checkpoint.Usage {
+	return checkpoint.Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Cost:             usage.Cost,
+	}
+}
+
+func runUsage(usage checkpoint.Usage) RunUsage {
+	return RunUsage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Cost:             usage.Cost,
+	}
+}
+
+// suspendedRun returns the suspended run of the checkpoint.
+func suspendedRun(cp checkpoint.Checkpoint) *SuspendedRun {
+	approvals := make(map[int]Approval, len(cp.Approvals))
+	for i, approval := range cp.Approvals {
+		approvals[i] = Approval(approval)
+	}
+	return &SuspendedRun{
+		RunID:     cp.RunID,
+		Inputs:    cp.Inputs,
+		Steps:     cp.Steps,
+		Iteration: cp.Iteration,
+		Actions:   cp.Actions,
+		Approvals: approvals,
+		Pending:   cp.Pending,
+		Usage:     runUsage(cp.Usage),
+		StartedAt: cp.StartedAt,
+	}
+}
+
+func checkpointApprovals(approvals map[int]Approval) map[int]checkpoint.Approval {
+	if len(approvals) == 0 {
+		return nil
+	}
+	saved := make(map[int]checkpoint.Approval, len(approvals))
+	for i, approval := range approvals {
+		saved[i] = checkpoint.Approval(approval)
+	}
+	return saved
+}
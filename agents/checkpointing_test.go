@@ -0,0 +1,144 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errCrash = errors.New("process crashed")
+
+// countingAgent searches once per iteration and finishes after three searches.
+// It fails when planning the crashAt iteration, if set.
+type countingAgent struct {
+	tool    tools.Tool
+	crashAt int
+	plans   int
+}
+
+func (a *countingAgent) Plan(
+	_ context.Context,
+	steps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	a.plans++
+	if a.crashAt != 0 && len(steps) == a.crashAt {
+		return nil, nil, errCrash
+	}
+	if len(steps) == 3 {
+		return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": steps[2].Observation}}, nil
+	}
+	return []schema.AgentAction{{Tool: "search", ToolInput: fmt.Sprint(len(steps))}}, nil, nil
+}
+
+func (a *countingAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *countingAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *countingAgent) GetTools() []tools.Tool  { return []tools.Tool{a.tool} }
+
+func TestExecutorResumeRun(t *testing.T) {
+	t.Parallel()
+	ctx := agents.ContextWithRunID(t.Context(), "run-1")
+
+	store := checkpoint.NewInMemory()
+	search := &recordingTool{name: "search"}
+
+	crashing := agents.NewExecutor(&countingAgent{tool: search, crashAt: 2}, agents.WithCheckpointStore(store))
+	_, err := chains.Call(ctx, crashing, map[string]any{"input": "question"})
+	require.ErrorIs(t, err, errCrash)
+
+	cp, err := store.Load(ctx, "run-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, cp.Iteration)
+	require.False(t, cp.Finished)
+	require.Equal(t, map[string]string{"input": "question"}, cp.Inputs)
+	require.Len(t, cp.Steps, 2)
+
+	// a new process resumes the run without running the done steps again
+	agent := &countingAgent{tool: search}
+	executor := agents.NewExecutor(agent, agents.WithCheckpointStore(store))
+	outputs, err := executor.ResumeRun(t.Context(), "run-1")
+	require.NoError(t, err)
+	require.Equal(t, "search ran 2", outputs["output"])
+	require.Equal(t, []string{"0", "1", "2"}, search.inputs)
+	require.Equal(t, 2, agent.plans)
+
+	cp, err = store.Load(ctx, "run-1")
+	require.NoError(t, err)
+	require.True(t, cp.Finished)
+	require.Equal(t, 4, cp.Iteration)
+
+	// finished runs are replayed from their checkpoint
+	outputs, err = executor.ResumeRun(t.Context(), "run-1")
+	require.NoError(t, err)
+	require.Equal(t, "search ran 2", outputs["output"])
+	require.Equal(t, 2, agent.plans)
+
+	_, err = executor.ResumeRun(t.Context(), "missing")
+	require.ErrorIs(t, err, checkpoint.ErrNotFound)
+	_, err = agents.NewExecutor(agent).ResumeRun(t.Context(), "run-1")
+	require.ErrorIs(t, err, agents.ErrNoCheckpointStore)
+}
+
+func TestExecutorGeneratedRunID(t *testing.T) {
+	t.Parallel()
+
+	store := checkpoint.NewInMemory()
+	executor := agents.NewExecutor(&countingAgent{tool: &recordingTool{name: "search"}},
+		agents.WithCheckpointStore(store))
+	_, err := chains.Call(t.Context(), executor, map[string]any{"input": "question"})
+	require.NoError(t, err)
+
+	ids, err := store.List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	require.NotEmpty(t, ids[0])
+}
+
+// costTool reports a model response to the cost handler in the run of its context.
+type costTool struct {
+	handler *callbacks.CostHandler
+}
+
+func (c *costTool) Name() string        { return "search" }
+func (c *costTool) Description() string { return "Reports a response." }
+
+func (c *costTool) Call(ctx context.Context, _ string) (string, error) {
+	c.handler.HandleLLMGenerateContentEnd(ctx, &llms.ContentResponse{
+		Usage: &llms.Usage{PromptTokens: 1_000_000},
+	})
+	return "reported", nil
+}
+
+func TestExecutorRunIDWithoutStore(t *testing.T) {
+	t.Parallel()
+
+	handler := callbacks.NewCostHandler("model", callbacks.PriceTable{"model": {Input: 1}},
+		callbacks.WithCostRunKey(agents.RunIDFromContext))
+	executor := agents.NewExecutor(&countingAgent{tool: &costTool{handler: handler}})
+	for range 2 {
+		_, err := chains.Call(t.Context(), executor, map[string]any{"input": "question"})
+		require.NoError(t, err)
+	}
+	_, err := chains.Call(agents.ContextWithRunID(t.Context(), "run-1"), executor, map[string]any{"input": "question"})
+	require.NoError(t, err)
+
+	snapshot := handler.Snapshot()
+	require.Equal(t, 9, snapshot.Total.Requests)
+	require.Len(t, snapshot.Runs, 3)
+	for _, cost := range snapshot.Runs {
+		require.Equal(t, 3, cost.Requests)
+		require.InDelta(t, 3, cost.Amount, 1e-9)
+	}
+	require.Contains(t, snapshot.Runs, "run-1")
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/vxcontrol/langchaingo/callbacks"
 	"github.com/vxcontrol/langchaingo/chains"
 	"github.com/vxcontrol/langchaingo/llms"
-	"github.com/vxcontrol/langchaingo/llms/streaming"
 	"github.com/vxcontrol/langchaingo/prompts"
 	"github.com/vxcontrol/langchaingo/schema"
 	"github.com/vxcontrol/langchaingo/tools"
@@ -39,7 +38,10 @@ type ConversationalAgent struct {
 	CallbacksHandler callbacks.Handler
 }
 
-var _ Agent = (*ConversationalAgent)(nil)
+var (
+	_ Agent         = (*ConversationalAgent)(nil)
+	_ FinalAnswerer = (*ConversationalAgent)(nil)
+)
 
 func NewConversationalAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ConversationalAgent {
 	options := conversationalDefaultOptions()
@@ -49,7 +51,7 @@ func NewConversationalAgent(llm llms.Model, tools []tools.Tool, opts ...Option)
 
 	return &ConversationalAgent{
 		Chain: chains.NewLLMChain(
-			llm,
+			recordingUsage(llm),
 			options.getConversationalPrompt(tools),
 			chains.WithCallback(options.callbacksHandler),
 		),
@@ -72,14 +74,7 @@ func (a *ConversationalAgent) Plan(
 
 	fullInputs["agent_scratchpad"] = constructScratchPad(intermediateSteps)
 
-	var stream streaming.Callback
-
-	if a.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk streaming.Chunk) error {
-			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
+	stream := agentStreamingFunc(ctx, a.CallbacksHandler)
 
 	output, err := chains.Predict(
 		ctx,
@@ -95,6 +90,31 @@ func (a *ConversationalAgent) Plan(
 	return a.parseOutput(output)
 }
 
+// FinalAnswer asks the model for the final answer from the previous steps, without more actions.
+func (a *ConversationalAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs["agent_scratchpad"] = constructScratchPad(intermediateSteps) + " " + _finalAnswerThought + "\n"
+
+	output, err := chains.Predict(
+		ctx,
+		a.Chain,
+		fullInputs,
+		chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return completionFinish(output, a.OutputKey, a.parseOutput), nil
+}
+
 func (a *ConversationalAgent) GetInputKeys() []string {
 	chainInputs := a.Chain.GetInputKeys()
 
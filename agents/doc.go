@@ -10,7 +10,9 @@
 // Package agents provides and implementation of the agent interface called
 // OneShotZeroAgent. This agent uses the ReAct Framework (based on the
 // descriptions of tools) to decide what action to take. This agent is
-// optimized to be used with LLMs.
+// optimized to be used with LLMs. The ToolCallingAgent instead uses the native
+// tool calling of the model, and works with any llms.Model supporting
+// llms.WithTools.
 //
 // To make agents more powerful we need to make them iterative, i.e. call the
 // model multiple times until they arrive at the final answer. That's the job of
@@ -19,4 +21,25 @@
 // calling the tool that the action references with the corresponding input,
 // getting the output of the tool, and then passing all that information back
 // into the Agent to get the next action it should take.
+//
+// The Executor can ask an Approver before running the actions of selected tools
+// (WithApprover). The approver approves, rejects with feedback or edits each action,
+// either synchronously or by suspending the run, which is then continued with
+// Executor.Resume once a person has decided. With a checkpoint store
+// (WithCheckpointStore) the Executor saves the steps of its runs after every
+// iteration, and Executor.ResumeRun continues a run from its last checkpoint;
+// Executor.LoadSuspendedRun loads a run suspended for approvals from the store.
+// A Budget (WithBudget) limits the duration, tokens and cost of each run; runs
+// stopped by the budget or the max iterations can still return an answer, see
+// WithEarlyStopping. Executor.Stream runs the executor and returns the typed
+// events of the run, from the model chunks to the final answer.
+//
+// For tasks of many steps, PlanAndExecute asks a planner model for an explicit
+// plan, runs each step with an Executor and revises the remaining steps from
+// the results.
+//
+// Agents compose: NewAgentTool wraps an Executor as a tool, and a
+// SupervisorAgent hands the task off to such agent tools. The nesting of the
+// runs is available to the callbacks and the tools through AgentPath and
+// AgentDepth.
 package agents
@@ -1,6 +1,12 @@
 package agents
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/schema"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -10,11 +16,20 @@ var (
 	// ErrNotFinished is returned if the agent does not give a finish before  the number of iterations
 	// is larger than max iterations.
 	ErrNotFinished = errors.New("agent not finished before max iterations")
+	// ErrBudgetExceeded is returned if a limit of the budget of the executor is reached before
+	// the agent gives a finish.
+	ErrBudgetExceeded = errors.New("agent run budget exceeded")
 	// ErrUnknownAgentType is returned if the type given to the initializer is invalid.
 	ErrUnknownAgentType = errors.New("unknown agent type")
 	// ErrInvalidOptions is returned if the options given to the initializer is invalid.
 	ErrInvalidOptions = errors.New("invalid options")
 
+	// ErrNoCheckpointStore is returned when resuming a run with an executor without checkpoint store.
+	ErrNoCheckpointStore = errors.New("executor has no checkpoint store")
+	// ErrRunNotSuspended is returned when loading the suspended state of a run that is not
+	// waiting for approvals.
+	ErrRunNotSuspended = errors.New("run is not suspended")
+
 	// ErrUnableToParseOutput is returned if the output of the llm is unparsable.
 	ErrUnableToParseOutput = errors.New("unable to parse agent output")
 	// ErrInvalidChainReturnType is returned if the internal chain of the agent returns a value in the
@@ -38,3 +53,64 @@ func NewParserErrorHandler(formatFunc func(string) string) *ParserErrorHandler {
 		Formatter: formatFunc,
 	}
 }
+
+// ToolErrorHandler is the policy of the executor for the errors returned by tools. A failing
+// tool call is first retried up to MaxRetries times, then the error of the last attempt is
+// given to Handle, which either converts it into an observation for the agent to reason
+// about, or returns an error aborting the run. Without ToolErrorHandler, or with a nil
+// Handle, tool errors abort the run. The run is always aborted when its context is done.
+type ToolErrorHandler struct {
+	// MaxRetries is the number of times a failing tool call is retried.
+	MaxRetries int
+	// Backoff returns the delay before a retry, attempt being 1 for the first retry.
+	// Retries are immediate if nil.
+	Backoff func(attempt int) time.Duration
+	// Handle returns the observation of the failed action, or the error aborting the run.
+	Handle func(ctx context.Context, action schema.AgentAction, err error) (string, error)
+}
+
+// NewToolErrorHandler creates a tool error handler with a custom handle function.
+func NewToolErrorHandler(
+	handle func(ctx context.Context, action schema.AgentAction, err error) (string, error),
+) *ToolErrorHandler {
+	return &ToolErrorHandler{
+		Handle: handle,
+	}
+}
+
+// NewFeedbackToolErrorHandler creates a tool error handler giving the error text to the agent
+// as the observation. The formatter function can be used to format the error, if nil the error
+// is given as the observation directly.
+func NewFeedbackToolErrorHandler(formatFunc func(err string) string) *ToolErrorHandler {
+	return NewToolErrorHandler(func(_ context.Context, _ schema.AgentAction, err error) (string, error) {
+		if formatFunc == nil {
+			return err.Error(), nil
+		}
+		return formatFunc(err.Error()), nil
+	})
+}
+
+// NewRetryToolErrorHandler creates a tool error handler retrying failing tool calls up to
+// maxRetries times, waiting with an exponential backoff from initialBackoff up to maxBackoff.
+// The error of the last attempt aborts the run, unless a Handle function is set.
+func NewRetryToolErrorHandler(maxRetries int, initialBackoff, maxBackoff time.Duration) *ToolErrorHandler {
+	return &ToolErrorHandler{
+		MaxRetries: maxRetries,
+		Backoff:    ExponentialBackoff(initialBackoff, maxBackoff),
+	}
+}
+
+// ExponentialBackoff returns a backoff function doubling the delay from initial for each
+// attempt, up to maxDelay if it is positive.
+func ExponentialBackoff(initial, maxDelay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && (maxDelay <= 0 || delay < maxDelay); i++ {
+			delay *= 2
+		}
+		if maxDelay > 0 {
+			delay = min(delay, maxDelay)
+		}
+		return delay
+	}
+}
@@ -0,0 +1,118 @@
+package agents
+
+import (
+	"context"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+// _eventsBufferSize is the number of events buffered before the run waits for the reader.
+const _eventsBufferSize = 16
+
+// EventType is the type of an Event of an agent run.
+type EventType string
+
+const (
+	// EventTypeChunk is a chunk of the model stream: a text, reasoning or tool call delta.
+	EventTypeChunk EventType = "chunk"
+	// EventTypeIterationStart is the start of an iteration of the executor.
+	EventTypeIterationStart EventType = "iteration_start"
+	// EventTypeIterationEnd is the end of an iteration of the executor.
+	EventTypeIterationEnd EventType = "iteration_end"
+	// EventTypeToolStart is the start of the action of a tool.
+	EventTypeToolStart EventType = "tool_start"
+	// EventTypeToolEnd is the end of the action of a tool, with its observation.
+	EventTypeToolEnd EventType = "tool_end"
+	// EventTypeToolError is a failed call of a tool, which may be retried.
+	EventTypeToolError EventType = "tool_error"
+	// EventTypeFinish is the end of the run, with its outputs.
+	EventTypeFinish EventType = "finish"
+	// EventTypeError is the end of the run with an error, with the outputs returned along it.
+	EventTypeError EventType = "error"
+)
+
+// Event is an event of an agent run, see Executor.Stream.
+type Event struct {
+	Type EventType `json:"type"`
+	// Depth is the nesting depth of the run of the event, see AgentDepth.
+	Depth int `json:"depth,omitempty"`
+	// Agent is the name of the agent tool of the run of the event, empty for the top-level run.
+	Agent string `json:"agent,omitempty"`
+	// Iteration is the iteration of the executor for the iteration events.
+	Iteration int `json:"iteration,omitempty"`
+	// Chunk is the chunk of the model stream for EventTypeChunk.
+	Chunk *streaming.Chunk `json:"chunk,omitempty"`
+	// Action is the action of the tool events.
+	Action *schema.AgentAction `json:"action,omitempty"`
+	// Observation is the observation of the tool for EventTypeToolEnd.
+	Observation string `json:"observation,omitempty"`
+	// Outputs are the outputs of the run for EventTypeFinish and EventTypeError.
+	Outputs map[string]any `json:"outputs,omitempty"`
+	// Err is the error of EventTypeToolError and EventTypeError.
+	Err error `json:"-"`
+}
+
+type eventsKey struct{}
+
+// Stream runs the executor with the inputs, as chains.Call does, and returns the events
+// of the run. The channel is closed after the last event, of type EventTypeFinish or
+// EventTypeError. The caller must read the channel until it is closed, or cancel the
+// context to stop the run.
+//
+// The model chunks are those of the agents of this package, which stream the responses
+// of their model while a run is streamed.
+func (e *Executor) Stream(ctx context.Context, inputValues map[string]any) <-chan Event {
+	events := make(chan Event, _eventsBufferSize)
+	go func() {
+		defer close(events)
+
+		ctx := context.WithValue(ctx, eventsKey{}, (chan<- Event)(events))
+		outputs, err := chains.Call(ctx, e, inputValues)
+		if err != nil {
+			emitEvent(ctx, Event{Type: EventTypeError, Outputs: outputs, Err: err})
+			return
+		}
+		emitEvent(ctx, Event{Type: EventTypeFinish, Outputs: outputs})
+	}()
+	return events
+}
+
+// emitEvent sends the event to the stream of the run of the context, if any. The event
+// is dropped if the context is done while the buffer of the stream is full.
+func emitEvent(ctx context.Context, event Event) {
+	events, ok := ctx.Value(eventsKey{}).(chan<- Event)
+	if !ok {
+		return
+	}
+	if path, _ := ctx.Value(agentPathKey{}).([]string); len(path) > 0 {
+		event.Depth, event.Agent = len(path), path[len(path)-1]
+	}
+	select {
+	case events <- event:
+		return
+	default:
+	}
+	select {
+	case events <- event:
+	case <-ctx.Done():
+	}
+}
+
+// agentStreamingFunc returns the streaming function of the model calls of an agent, which
+// gives the chunks to the callbacks handler and to the event stream of the run. It is nil
+// when there is neither.
+func agentStreamingFunc(ctx context.Context, handler callbacks.Handler) streaming.Callback {
+	if _, ok := ctx.Value(eventsKey{}).(chan<- Event); !ok && handler == nil {
+		return nil
+	}
+	return func(ctx context.Context, chunk streaming.Chunk) error {
+		if handler != nil {
+			handler.HandleStreamingFunc(ctx, chunk)
+		}
+		emitEvent(ctx, Event{Type: EventTypeChunk, Chunk: &chunk})
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+// streamingToolsLLM streams the content of the responses of a scriptedToolsLLM.
+type streamingToolsLLM struct {
+	*scriptedToolsLLM
+}
+
+func (m streamingToolsLLM) GenerateContent(
+	ctx context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	resp, err := m.scriptedToolsLLM.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	for _, choice := range resp.Choices {
+		if err := streaming.CallWithReasoning(ctx, opts.StreamingFunc, choice.ReasoningContent); err != nil {
+			return nil, err
+		}
+		if err := streaming.CallWithText(ctx, opts.StreamingFunc, choice.Content); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func TestExecutorStream(t *testing.T) {
+	t.Parallel()
+
+	llm := streamingToolsLLM{&scriptedToolsLLM{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{
+			ReasoningContent: "I need the weather.",
+			ToolCalls:        []llms.ToolCall{toolCall("call_1", "weather", "Paris")},
+		}}},
+		{Choices: []*llms.ContentChoice{{Content: "Sunny."}}},
+	}}}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(llm, []tools.Tool{&recordingTool{name: "weather"}}))
+
+	var events []agents.Event
+	for event := range executor.Stream(t.Context(), map[string]any{"input": "Weather?"}) {
+		events = append(events, event)
+	}
+
+	types := make([]agents.EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []agents.EventType{
+		agents.EventTypeIterationStart,
+		agents.EventTypeChunk,
+		agents.EventTypeToolStart,
+		agents.EventTypeToolEnd,
+		agents.EventTypeIterationEnd,
+		agents.EventTypeIterationStart,
+		agents.EventTypeChunk,
+		agents.EventTypeIterationEnd,
+		agents.EventTypeFinish,
+	}, types)
+
+	assert.Equal(t, streaming.NewReasoningChunk("I need the weather."), *events[1].Chunk)
+	assert.Equal(t, "Paris", events[2].Action.ToolInput)
+	assert.Equal(t, "call_1", events[3].Action.ToolID)
+	assert.Equal(t, "weather ran Paris", events[3].Observation)
+	assert.Equal(t, 1, events[5].Iteration)
+	assert.Equal(t, streaming.NewTextChunk("Sunny."), *events[6].Chunk)
+	assert.Equal(t, "Sunny.", events[8].Outputs["output"])
+}
+
+func TestExecutorStreamError(t *testing.T) {
+	t.Parallel()
+
+	agent := &testAgent{actions: []schema.AgentAction{{Tool: "flaky"}}, tools: []tools.Tool{&flakyTool{failures: 1}}}
+	executor := agents.NewExecutor(agent, agents.WithMaxIterations(1))
+
+	var events []agents.Event
+	for event := range executor.Stream(t.Context(), map[string]any{"input": "?"}) {
+		events = append(events, event)
+	}
+
+	require.NotEmpty(t, events)
+	assert.Equal(t, agents.EventTypeToolError, events[2].Type)
+	last := events[len(events)-1]
+	assert.Equal(t, agents.EventTypeError, last.Type)
+	require.EqualError(t, last.Err, "service unavailable")
+}
+
+func TestExecutorStreamCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	agent := &testAgent{actions: []schema.AgentAction{{Tool: "slow"}}, tools: []tools.Tool{&slowTool{calls: 1}}}
+	executor := agents.NewExecutor(agent)
+
+	var last agents.Event
+	for event := range executor.Stream(ctx, map[string]any{"input": "?"}) {
+		if event.Type == agents.EventTypeToolStart {
+			cancel()
+		}
+		last = event
+	}
+	assert.Equal(t, agents.EventTypeError, last.Type)
+	require.ErrorIs(t, last.Err, context.Canceled)
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
 	"github.com/vxcontrol/langchaingo/callbacks"
 	"github.com/vxcontrol/langchaingo/chains"
 	"github.com/vxcontrol/langchaingo/schema"
 	"github.com/vxcontrol/langchaingo/tools"
+
+	"golang.org/x/sync/errgroup"
 )
 
 const _intermediateStepsOutputKey = "intermediateSteps"
@@ -20,9 +26,23 @@ type Executor struct {
 	Memory           schema.Memory
 	CallbacksHandler callbacks.Handler
 	ErrorHandler     *ParserErrorHandler
+	ToolErrorHandler *ToolErrorHandler
+	// Approver, if set, decides whether the actions of the agent can run.
+	Approver Approver
+	// ApprovalTools are the names of the tools whose actions need an approval, all when empty.
+	ApprovalTools []string
+	// CheckpointStore, if set, keeps the state of the runs after every iteration.
+	CheckpointStore checkpoint.Store
+	// Budget limits the time, tokens and cost of each run.
+	Budget Budget
+	// EarlyStopping is how runs stopped by MaxIterations or the Budget end.
+	EarlyStopping EarlyStoppingMethod
 
 	MaxIterations           int
 	ReturnIntermediateSteps bool
+	// MaxConcurrentActions is the number of actions of an iteration run concurrently.
+	// The actions are run one after the other when it is 1 or less.
+	MaxConcurrentActions int
 }
 
 var (
@@ -44,6 +64,13 @@ func NewExecutor(agent Agent, opts ...Option) *Executor {
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
 		ErrorHandler:            options.errorHandler,
+		ToolErrorHandler:        options.toolErrorHandler,
+		Approver:                options.approver,
+		ApprovalTools:           options.approvalTools,
+		CheckpointStore:         options.checkpointStore,
+		MaxConcurrentActions:    options.maxConcurrentActions,
+		Budget:                  options.budget,
+		EarlyStopping:           options.earlyStopping,
 	}
 }
 
@@ -52,26 +79,89 @@ func (e *Executor) Call(ctx context.Context, inputValues map[string]any, _ ...ch
 	if err != nil {
 		return nil, err
 	}
+
+	ctx, meter := e.withBudget(e.withRunID(ctx), RunUsage{}, time.Time{})
+	return e.run(ctx, meter, inputs, make([]schema.AgentStep, 0), 0)
+}
+
+// Resume continues a run suspended until actions are approved, see ApprovalPendingError
+// and LoadSuspendedRun.
+// The approvals are the decisions about the pending actions, by index in run.Actions;
+// the approver is asked again about the pending actions without decision. The run keeps
+// its id, and its checkpoints if a CheckpointStore is set. As the run is not continued
+// through chains.Call, Resume saves its inputs and outputs to the memory.
+func (e *Executor) Resume(ctx context.Context, run *SuspendedRun, approvals map[int]Approval) (map[string]any, error) {
+	decisions := make(map[int]Approval, len(run.Approvals)+len(approvals))
+	maps.Copy(decisions, run.Approvals)
+	for _, i := range run.Pending {
+		if approval, ok := approvals[i]; ok {
+			decisions[i] = approval
+		}
+	}
+	return e.resume(ctx, run, decisions)
+}
+
+// resume runs the actions of the suspended run with the decisions, then continues the run
+// from the next iteration and saves it to the memory.
+func (e *Executor) resume(ctx context.Context, run *SuspendedRun, decisions map[int]Approval) (map[string]any, error) {
+	if run.RunID != "" {
+		ctx = ContextWithRunID(ctx, run.RunID)
+	}
+	ctx, meter := e.withBudget(e.withRunID(ctx), run.Usage, run.StartedAt)
+
+	nameToTool := getNameToTool(e.Agent.GetTools())
+	steps, err := e.doActions(ctx, slices.Clone(run.Steps), nameToTool, run.Inputs, run.Iteration, run.Actions, decisions)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.saveCheckpoint(ctx, run.Inputs, steps, run.Iteration+1, nil); err != nil {
+		return nil, err
+	}
+
+	outputs, err := e.run(ctx, meter, run.Inputs, steps, run.Iteration+1)
+	if err != nil {
+		return outputs, err
+	}
+	return outputs, e.saveMemory(ctx, run.Inputs, outputs)
+}
+
+// run runs the iterations of the run from the given one. The context has the id of the
+// run and its meter, see withBudget.
+func (e *Executor) run(
+	ctx context.Context,
+	meter *runMeter,
+	inputs map[string]string,
+	steps []schema.AgentStep,
+	iteration int,
+) (map[string]any, error) {
+	runCtx, cancel := meter.withDeadline(ctx)
+	defer cancel()
 	nameToTool := getNameToTool(e.Agent.GetTools())
 
-	steps := make([]schema.AgentStep, 0)
-	for i := 0; i < e.MaxIterations; i++ {
+	var err error
+	for i := iteration; i < e.MaxIterations; i++ {
+		if err := meter.check(); err != nil {
+			return e.stopEarly(ctx, inputs, steps, i, err)
+		}
+
 		var finish map[string]any
-		steps, finish, err = e.doIteration(ctx, steps, nameToTool, inputs)
-		if finish != nil || err != nil {
+		steps, finish, err = e.doIteration(runCtx, steps, nameToTool, inputs, i)
+		if err != nil {
+			if ctx.Err() == nil && runCtx.Err() != nil {
+				// the max duration of the budget canceled the iteration
+				return e.stopEarly(ctx, inputs, steps, i, meter.check())
+			}
 			return finish, err
 		}
+		if err := e.saveCheckpoint(ctx, inputs, steps, i+1, finish); err != nil {
+			return nil, err
+		}
+		if finish != nil {
+			return finish, nil
+		}
 	}
 
-	if e.CallbacksHandler != nil {
-		e.CallbacksHandler.HandleAgentFinish(ctx, schema.AgentFinish{
-			ReturnValues: map[string]any{"output": ErrNotFinished.Error()},
-		})
-	}
-	return e.getReturn(
-		&schema.AgentFinish{ReturnValues: make(map[string]any)},
-		steps,
-	), ErrNotFinished
+	return e.stopEarly(ctx, inputs, steps, e.MaxIterations, ErrNotFinished)
 }
 
 func (e *Executor) doIteration( // nolint
@@ -79,7 +169,11 @@ func (e *Executor) doIteration( // nolint
 	steps []schema.AgentStep,
 	nameToTool map[string]tools.Tool,
 	inputs map[string]string,
+	iteration int,
 ) ([]schema.AgentStep, map[string]any, error) {
+	emitEvent(ctx, Event{Type: EventTypeIterationStart, Iteration: iteration})
+	defer emitEvent(ctx, Event{Type: EventTypeIterationEnd, Iteration: iteration})
+
 	actions, finish, err := e.Agent.Plan(ctx, steps, inputs)
 	if errors.Is(err, ErrUnableToParseOutput) && e.ErrorHandler != nil {
 		formattedObservation := err.Error()
@@ -106,43 +200,202 @@ func (e *Executor) doIteration( // nolint
 		return steps, e.getReturn(finish, steps), nil
 	}
 
-	for _, action := range actions {
-		steps, err = e.doAction(ctx, steps, nameToTool, action)
-		if err != nil {
-			return steps, nil, err
+	steps, err = e.doActions(ctx, steps, nameToTool, inputs, iteration, actions, nil)
+	return steps, nil, err
+}
+
+// doActions asks the approvals of the actions, then runs the approved actions and
+// appends the steps of all of them in the order of the actions. The iteration is
+// suspended, returning an ApprovalPendingError, if an approval is pending.
+func (e *Executor) doActions(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	nameToTool map[string]tools.Tool,
+	inputs map[string]string,
+	iteration int,
+	actions []schema.AgentAction,
+	approvals map[int]Approval,
+) ([]schema.AgentStep, error) {
+	approvals, pending, err := e.approve(ctx, actions, approvals)
+	if err != nil {
+		return steps, err
+	}
+	if len(pending) != 0 {
+		runID, _ := RunIDFromContext(ctx)
+		usage, start := runState(ctx)
+		run := &SuspendedRun{
+			RunID:     runID,
+			Inputs:    inputs,
+			Steps:     steps,
+			Iteration: iteration,
+			Actions:   actions,
+			Approvals: approvals,
+			Pending:   pending,
+			Usage:     usage,
+			StartedAt: start,
+		}
+		if err := e.saveSuspended(ctx, run); err != nil {
+			return steps, err
 		}
+		return steps, &ApprovalPendingError{Run: run}
 	}
 
-	return steps, nil, nil
+	actions = slices.Clone(actions)
+	results := make([]schema.AgentStep, len(actions))
+	toRun := make([]int, 0, len(actions))
+	for i, action := range actions {
+		approval, ok := approvals[i]
+		switch {
+		case ok && !approval.Approved:
+			results[i] = schema.AgentStep{Action: action, Observation: approval.Feedback}
+			if approval.Feedback == "" {
+				results[i].Observation = _defaultRejectionObservation
+			}
+			continue
+		case ok && approval.Input != "":
+			actions[i].ToolInput = approval.Input
+		}
+		toRun = append(toRun, i)
+	}
+
+	if e.MaxConcurrentActions > 1 && len(toRun) > 1 {
+		err = e.doActionsConcurrently(ctx, nameToTool, actions, toRun, results)
+	} else {
+		for _, i := range toRun {
+			results[i], err = e.doAction(ctx, nameToTool, actions[i])
+			if err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return steps, err
+	}
+
+	return append(steps, results...), nil
+}
+
+// doActionsConcurrently runs the actions of the indexes with at most MaxConcurrentActions
+// workers and sets their steps in results. The first error cancels the context of the
+// other actions.
+func (e *Executor) doActionsConcurrently(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+	indexes []int,
+	results []schema.AgentStep,
+) error {
+	for _, i := range indexes {
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleAgentAction(ctx, actions[i])
+		}
+		emitEvent(ctx, Event{Type: EventTypeToolStart, Action: &actions[i]})
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(e.MaxConcurrentActions)
+	for _, i := range indexes {
+		g.Go(func() error {
+			step, err := e.runAction(gctx, nameToTool, actions[i])
+			if err != nil {
+				return err
+			}
+			results[i] = step
+			return nil
+		})
+	}
+	return g.Wait()
 }
 
 func (e *Executor) doAction(
 	ctx context.Context,
-	steps []schema.AgentStep,
 	nameToTool map[string]tools.Tool,
 	action schema.AgentAction,
-) ([]schema.AgentStep, error) {
+) (schema.AgentStep, error) {
 	if e.CallbacksHandler != nil {
 		e.CallbacksHandler.HandleAgentAction(ctx, action)
 	}
+	emitEvent(ctx, Event{Type: EventTypeToolStart, Action: &action})
+
+	return e.runAction(ctx, nameToTool, action)
+}
 
+// runAction calls the tool of the action and returns the step with its observation.
+func (e *Executor) runAction(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	action schema.AgentAction,
+) (schema.AgentStep, error) {
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
 	if !ok {
-		return append(steps, schema.AgentStep{
+		observation := fmt.Sprintf("%s is not a valid tool, try another one", action.Tool)
+		emitEvent(ctx, Event{Type: EventTypeToolEnd, Action: &action, Observation: observation})
+		return schema.AgentStep{
 			Action:      action,
-			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+			Observation: observation,
+		}, nil
 	}
 
-	observation, err := tool.Call(ctx, strings.TrimSuffix(action.ToolInput, "\nObservation:"))
+	observation, err := e.callTool(ctx, tool, action)
+	if errors.Is(err, tools.ErrInvalidArguments) {
+		// the model is given the validation error to fix the arguments in the next step
+		observation, err = err.Error(), nil
+	}
+	if err != nil && ctx.Err() == nil && e.ToolErrorHandler != nil && e.ToolErrorHandler.Handle != nil {
+		observation, err = e.ToolErrorHandler.Handle(ctx, action, err)
+	}
 	if err != nil {
-		return nil, err
+		return schema.AgentStep{}, err
 	}
 
-	return append(steps, schema.AgentStep{
+	emitEvent(ctx, Event{Type: EventTypeToolEnd, Action: &action, Observation: observation})
+	return schema.AgentStep{
 		Action:      action,
 		Observation: observation,
-	}), nil
+	}, nil
+}
+
+// callTool calls the tool, retrying failed calls as set by the ToolErrorHandler.
+func (e *Executor) callTool(ctx context.Context, tool tools.Tool, action schema.AgentAction) (string, error) {
+	input := strings.TrimSuffix(action.ToolInput, "\nObservation:")
+	for attempt := 0; ; attempt++ {
+		observation, err := tool.Call(ctx, input)
+		if err == nil || errors.Is(err, tools.ErrInvalidArguments) {
+			return observation, err
+		}
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		emitEvent(ctx, Event{Type: EventTypeToolError, Action: &action, Err: err})
+		if e.ToolErrorHandler == nil || attempt >= e.ToolErrorHandler.MaxRetries || ctx.Err() != nil {
+			return observation, err
+		}
+
+		if e.ToolErrorHandler.Backoff != nil {
+			timer := time.NewTimer(e.ToolErrorHandler.Backoff(attempt + 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return observation, err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// saveMemory saves the context of a resumed run, whose inputs include the memory variables.
+func (e *Executor) saveMemory(ctx context.Context, inputs map[string]string, outputs map[string]any) error {
+	if e.Memory == nil {
+		return nil
+	}
+	inputValues := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		inputValues[key] = value
+	}
+	for _, key := range e.Memory.MemoryVariables(ctx) {
+		delete(inputValues, key)
+	}
+	return e.Memory.SaveContext(ctx, inputValues, outputs)
 }
 
 func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
@@ -2,9 +2,13 @@ package agents_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/vxcontrol/langchaingo/agents"
 	"github.com/vxcontrol/langchaingo/chains"
@@ -230,3 +234,215 @@ func TestExecutorTrimsObservationSuffix(t *testing.T) {
 	// Verify that the tool received the input with "\nObservation:" trimmed off
 	require.Equal(t, "test input", receivedInput, "Tool should receive input with \\nObservation: suffix trimmed")
 }
+
+func TestExecutorStructuredToolValidationError(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	type weatherArgs struct {
+		City string `json:"city"`
+	}
+	var calls []string
+	weather, err := tools.NewStructured("weather", "Get the weather.",
+		func(_ context.Context, args weatherArgs) (string, error) {
+			calls = append(calls, args.City)
+			return "sunny in " + args.City, nil
+		})
+	require.NoError(t, err)
+
+	a := &testAgent{
+		actions: []schema.AgentAction{
+			{Tool: "weather", ToolInput: `{"town": "Paris"}`},
+			{Tool: "weather", ToolInput: `{"city": "Paris"}`},
+		},
+		tools: []tools.Tool{weather},
+	}
+	executor := agents.NewExecutor(a, agents.WithMaxIterations(2))
+
+	_, err = chains.Call(ctx, executor, map[string]any{"input": "weather in Paris?"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	// the invalid arguments never reach the function, in both iterations
+	require.Equal(t, []string{"Paris", "Paris"}, calls)
+	require.Len(t, a.recordedIntermediateSteps, 2)
+	require.Contains(t, a.recordedIntermediateSteps[0].Observation, tools.ErrInvalidArguments.Error())
+	require.Contains(t, a.recordedIntermediateSteps[0].Observation, `missing required property "city"`)
+	require.Equal(t, "sunny in Paris", a.recordedIntermediateSteps[1].Observation)
+}
+
+// concurrentTool records the maximum number of concurrent calls. Calls with the
+// "fail" input fail, calls with the "block" input wait for the context to be done.
+type concurrentTool struct {
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+	mu          sync.Mutex
+	cancelled   []string
+}
+
+func (c *concurrentTool) Name() string        { return "concurrent" }
+func (c *concurrentTool) Description() string { return "A tool called concurrently." }
+
+func (c *concurrentTool) Call(ctx context.Context, input string) (string, error) {
+	n := c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	for {
+		m := c.maxInFlight.Load()
+		if n <= m || c.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+
+	switch input {
+	case "fail":
+		return "", errors.New("tool failed")
+	case "block":
+		<-ctx.Done()
+		c.mu.Lock()
+		c.cancelled = append(c.cancelled, input)
+		c.mu.Unlock()
+		return "", ctx.Err()
+	}
+	time.Sleep(20 * time.Millisecond)
+	return "result " + input, nil
+}
+
+func TestExecutorConcurrentActions(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	tool := &concurrentTool{}
+	a := &testAgent{tools: []tools.Tool{tool}}
+	for _, input := range []string{"1", "2", "3", "4", "5"} {
+		a.actions = append(a.actions, schema.AgentAction{Tool: "concurrent", ToolInput: input})
+	}
+	executor := agents.NewExecutor(a, agents.WithMaxIterations(2), agents.WithMaxConcurrentActions(2))
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "question"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.Equal(t, int32(2), tool.maxInFlight.Load())
+
+	observations := make([]string, 0, len(a.recordedIntermediateSteps))
+	for _, step := range a.recordedIntermediateSteps {
+		observations = append(observations, step.Observation)
+	}
+	require.Equal(t, []string{"result 1", "result 2", "result 3", "result 4", "result 5"}, observations)
+}
+
+func TestExecutorConcurrentActionsCancel(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+
+	tool := &concurrentTool{}
+	a := &testAgent{
+		actions: []schema.AgentAction{
+			{Tool: "concurrent", ToolInput: "block"},
+			{Tool: "concurrent", ToolInput: "fail"},
+		},
+		tools: []tools.Tool{tool},
+	}
+	executor := agents.NewExecutor(a, agents.WithMaxConcurrentActions(2))
+
+	_, err := chains.Call(ctx, executor, map[string]any{"input": "question"})
+	require.EqualError(t, err, "tool failed")
+	require.Equal(t, 1, a.numPlanCalls)
+	require.Equal(t, []string{"block"}, tool.cancelled)
+}
+
+// flakyTool fails the first failures calls.
+type flakyTool struct {
+	failures int
+	calls    int
+}
+
+func (f *flakyTool) Name() string        { return "flaky" }
+func (f *flakyTool) Description() string { return "A tool failing at first." }
+
+func (f *flakyTool) Call(_ context.Context, _ string) (string, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return "", errors.New("service unavailable")
+	}
+	return "ok", nil
+}
+
+func TestExecutorToolErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		handler         *agents.ToolErrorHandler
+		failures        int
+		wantErr         string
+		wantCalls       int
+		wantObservation string
+	}{
+		{
+			name:      "abort",
+			failures:  1,
+			wantErr:   "service unavailable",
+			wantCalls: 1,
+		},
+		{
+			name:            "feedback",
+			handler:         agents.NewFeedbackToolErrorHandler(func(err string) string { return "Error: " + err }),
+			failures:        1,
+			wantObservation: "Error: service unavailable",
+		},
+		{
+			name:            "retry",
+			handler:         agents.NewRetryToolErrorHandler(2, time.Millisecond, 2*time.Millisecond),
+			failures:        2,
+			wantObservation: "ok",
+		},
+		{
+			name:      "retries exhausted",
+			handler:   agents.NewRetryToolErrorHandler(2, 0, 0),
+			failures:  3,
+			wantErr:   "service unavailable",
+			wantCalls: 3,
+		},
+		{
+			name: "custom",
+			handler: agents.NewToolErrorHandler(
+				func(_ context.Context, action schema.AgentAction, err error) (string, error) {
+					return action.Tool + " is down: " + err.Error(), nil
+				}),
+			failures:        1,
+			wantObservation: "flaky is down: service unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tool := &flakyTool{failures: tt.failures}
+			a := &testAgent{
+				actions: []schema.AgentAction{{Tool: "flaky", ToolInput: "input"}},
+				tools:   []tools.Tool{tool},
+			}
+			executor := agents.NewExecutor(a, agents.WithMaxIterations(2), agents.WithToolErrorHandler(tt.handler))
+
+			_, err := chains.Call(t.Context(), executor, map[string]any{"input": "question"})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				require.Equal(t, tt.wantCalls, tool.calls)
+				return
+			}
+			require.ErrorIs(t, err, agents.ErrNotFinished)
+			require.Len(t, a.recordedIntermediateSteps, 1)
+			require.Equal(t, tt.wantObservation, a.recordedIntermediateSteps[0].Observation)
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	backoff := agents.ExponentialBackoff(100*time.Millisecond, time.Second)
+	require.Equal(t, 100*time.Millisecond, backoff(1))
+	require.Equal(t, 200*time.Millisecond, backoff(2))
+	require.Equal(t, 800*time.Millisecond, backoff(4))
+	require.Equal(t, time.Second, backoff(5))
+	require.Equal(t, time.Second, backoff(50))
+	require.Equal(t, 1600*time.Millisecond, agents.ExponentialBackoff(100*time.Millisecond, 0)(5))
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/vxcontrol/langchaingo/callbacks"
 	"github.com/vxcontrol/langchaingo/chains"
 	"github.com/vxcontrol/langchaingo/llms"
-	"github.com/vxcontrol/langchaingo/llms/streaming"
 	"github.com/vxcontrol/langchaingo/schema"
 	"github.com/vxcontrol/langchaingo/tools"
 )
@@ -36,7 +35,10 @@ type OneShotZeroAgent struct {
 	CallbacksHandler callbacks.Handler
 }
 
-var _ Agent = (*OneShotZeroAgent)(nil)
+var (
+	_ Agent         = (*OneShotZeroAgent)(nil)
+	_ FinalAnswerer = (*OneShotZeroAgent)(nil)
+)
 
 // NewOneShotAgent creates a new OneShotZeroAgent with the given LLM model, tools,
 // and options. It returns a pointer to the created agent. The opts parameter
@@ -49,7 +51,7 @@ func NewOneShotAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *OneSho
 
 	return &OneShotZeroAgent{
 		Chain: chains.NewLLMChain(
-			llm,
+			recordingUsage(llm),
 			options.getMrklPrompt(tools),
 			chains.WithCallback(options.callbacksHandler),
 		),
@@ -72,14 +74,7 @@ func (a *OneShotZeroAgent) Plan(
 
 	fullInputs["agent_scratchpad"] = constructMrklScratchPad(intermediateSteps)
 
-	var stream streaming.Callback
-
-	if a.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk streaming.Chunk) error {
-			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
+	stream := agentStreamingFunc(ctx, a.CallbacksHandler)
 
 	output, err := chains.Predict(
 		ctx,
@@ -95,6 +90,31 @@ func (a *OneShotZeroAgent) Plan(
 	return a.parseOutput(output)
 }
 
+// FinalAnswer asks the model for the final answer from the previous steps, without more actions.
+func (a *OneShotZeroAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs["agent_scratchpad"] = constructMrklScratchPad(intermediateSteps) + "\n" + _finalAnswerThought + "\n"
+
+	output, err := chains.Predict(
+		ctx,
+		a.Chain,
+		fullInputs,
+		chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return completionFinish(output, a.OutputKey, a.parseOutput), nil
+}
+
 func (a *OneShotZeroAgent) GetInputKeys() []string {
 	chainInputs := a.Chain.GetInputKeys()
 
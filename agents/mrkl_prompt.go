@@ -56,10 +56,10 @@ func toolNames(tools []tools.Tool) string {
 	return tn.String()
 }
 
-func toolDescriptions(tools []tools.Tool) string {
+func toolDescriptions(availableTools []tools.Tool) string {
 	var ts strings.Builder
-	for _, tool := range tools {
-		ts.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name(), tool.Description()))
+	for _, tool := range availableTools {
+		ts.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name(), tools.Describe(tool)))
 	}
 
 	return ts.String()
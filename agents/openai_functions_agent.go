@@ -8,7 +8,6 @@ import (
 
 	"github.com/vxcontrol/langchaingo/callbacks"
 	"github.com/vxcontrol/langchaingo/llms"
-	"github.com/vxcontrol/langchaingo/llms/streaming"
 	"github.com/vxcontrol/langchaingo/prompts"
 	"github.com/vxcontrol/langchaingo/schema"
 	"github.com/vxcontrol/langchaingo/tools"
@@ -34,7 +33,10 @@ type OpenAIFunctionsAgent struct {
 	CallbacksHandler callbacks.Handler
 }
 
-var _ Agent = (*OpenAIFunctionsAgent)(nil)
+var (
+	_ Agent         = (*OpenAIFunctionsAgent)(nil)
+	_ FinalAnswerer = (*OpenAIFunctionsAgent)(nil)
+)
 
 // NewOpenAIFunctionsAgent creates a new OpenAIFunctionsAgent.
 func NewOpenAIFunctionsAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *OpenAIFunctionsAgent {
@@ -60,13 +62,7 @@ func (o *OpenAIFunctionsAgent) tools() []llms.Tool {
 			Function: &llms.FunctionDefinition{
 				Name:        tool.Name(),
 				Description: tool.Description(),
-				Parameters: map[string]any{
-					"properties": map[string]any{
-						"__arg1": map[string]string{"title": "__arg1", "type": "string"},
-					},
-					"required": []string{"__arg1"},
-					"type":     "object",
-				},
+				Parameters:  tools.ArgumentsSchema(tool),
 			},
 		})
 	}
@@ -85,14 +81,7 @@ func (o *OpenAIFunctionsAgent) Plan(
 	}
 	fullInputs[agentScratchpad] = o.constructScratchPad(intermediateSteps)
 
-	var stream streaming.Callback
-
-	if o.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk streaming.Chunk) error {
-			o.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
+	stream := agentStreamingFunc(ctx, o.CallbacksHandler)
 
 	prompt, err := o.Prompt.FormatPrompt(fullInputs)
 	if err != nil {
@@ -158,10 +147,33 @@ func (o *OpenAIFunctionsAgent) Plan(
 	if err != nil {
 		return nil, nil, err
 	}
+	RecordUsage(ctx, result)
 
 	return o.ParseOutput(result)
 }
 
+// FinalAnswer asks the model for the final answer from the previous steps, ignoring the
+// tool calls of the response.
+func (o *OpenAIFunctionsAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs[agentScratchpad] = o.constructScratchPad(intermediateSteps)
+
+	prompt, err := o.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return generateFinalAnswer(ctx, o.LLM, chatMessagesToMessageContents(prompt.Messages()), o.OutputKey,
+		llms.WithTools(o.tools()))
+}
+
 func (o *OpenAIFunctionsAgent) GetInputKeys() []string {
 	chainInputs := o.Prompt.GetInputVariables()
 
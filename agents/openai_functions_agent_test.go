@@ -1,6 +1,7 @@
 package agents_test
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"testing"
@@ -8,6 +9,8 @@ import (
 	"github.com/vxcontrol/langchaingo/agents"
 	"github.com/vxcontrol/langchaingo/chains"
 	"github.com/vxcontrol/langchaingo/internal/httprr"
+	"github.com/vxcontrol/langchaingo/jsonschema"
+	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/openai"
 	"github.com/vxcontrol/langchaingo/prompts"
 	"github.com/vxcontrol/langchaingo/tools"
@@ -115,3 +118,71 @@ func TestOpenAIFunctionsAgentComplexCalculation(t *testing.T) {
 		t.Errorf("expected calculation result 30 in response, got: %s", result)
 	}
 }
+
+// toolsRecordingLLM records the tools it is called with and calls the first one.
+type toolsRecordingLLM struct {
+	tools []llms.Tool
+}
+
+func (m *toolsRecordingLLM) GenerateContent(
+	_ context.Context,
+	_ []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.tools = opts.Tools
+
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		ToolCalls: []llms.ToolCall{{
+			ID:   "call_1",
+			Type: "function",
+			FunctionCall: &llms.FunctionCall{
+				Name:      opts.Tools[0].Function.Name,
+				Arguments: `{"city": "Paris", "days": 2}`,
+			},
+		}},
+	}}}, nil
+}
+
+func (m *toolsRecordingLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestOpenAIFunctionsAgentStructuredTool(t *testing.T) {
+	t.Parallel()
+
+	type forecastArgs struct {
+		City string `json:"city" description:"The city name"`
+		Days int    `json:"days"`
+	}
+	forecast, err := tools.NewStructured("forecast", "Get the weather forecast.",
+		func(_ context.Context, _ forecastArgs) (string, error) {
+			return "sunny", nil
+		})
+	require.NoError(t, err)
+
+	llm := &toolsRecordingLLM{}
+	agent := agents.NewOpenAIFunctionsAgent(llm, []tools.Tool{forecast, tools.Calculator{}})
+	actions, finish, err := agent.Plan(t.Context(), nil, map[string]string{"input": "weather in Paris?"})
+	require.NoError(t, err)
+	require.Nil(t, finish)
+
+	require.Len(t, llm.tools, 2)
+	require.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city": {Type: jsonschema.String, Description: "The city name"},
+			"days": {Type: jsonschema.Integer},
+		},
+		Required: []string{"city", "days"},
+	}, llm.tools[0].Function.Parameters)
+	require.Contains(t, llm.tools[1].Function.Parameters, "properties")
+
+	require.Len(t, actions, 1)
+	require.Equal(t, "forecast", actions[0].Tool)
+	require.JSONEq(t, `{"city": "Paris", "days": 2}`, actions[0].ToolInput)
+	require.Equal(t, "call_1", actions[0].ToolID)
+}
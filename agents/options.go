@@ -1,7 +1,9 @@
 package agents
 
 import (
+	"github.com/vxcontrol/langchaingo/agents/checkpoint"
 	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/memory"
 	"github.com/vxcontrol/langchaingo/prompts"
 	"github.com/vxcontrol/langchaingo/schema"
@@ -13,16 +15,29 @@ type Options struct {
 	memory                  schema.Memory
 	callbacksHandler        callbacks.Handler
 	errorHandler            *ParserErrorHandler
+	toolErrorHandler        *ToolErrorHandler
+	approver                Approver
+	approvalTools           []string
+	checkpointStore         checkpoint.Store
+	budget                  Budget
+	earlyStopping           EarlyStoppingMethod
 	maxIterations           int
+	maxConcurrentActions    int
 	returnIntermediateSteps bool
 	outputKey               string
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
 
-	// openai
+	// openai and tool calling
 	systemMessage string
 	extraMessages []prompts.MessageFormatter
+	toolChoice    any
+	callOptions   []llms.CallOption
+
+	// plan and execute
+	replanner       llms.Model
+	replannerPrompt prompts.PromptTemplate
 }
 
 // Option is a function type that can be used to modify the creation of the agents
@@ -62,6 +77,20 @@ func openAIFunctionsDefaultOptions() Options {
 	}
 }
 
+func supervisorDefaultOptions() Options {
+	return Options{
+		outputKey: _defaultOutputKey,
+	}
+}
+
+func planAndExecuteDefaultOptions() Options {
+	return Options{
+		maxIterations: _defaultPlanAndExecuteMaxSteps,
+		outputKey:     _defaultOutputKey,
+		memory:        memory.NewSimple(),
+	}
+}
+
 func (co Options) getMrklPrompt(tools []tools.Tool) prompts.PromptTemplate {
 	if co.prompt.Template != "" {
 		return co.prompt
@@ -96,6 +125,15 @@ func WithMaxIterations(iterations int) Option {
 	}
 }
 
+// WithMaxConcurrentActions is an option for running up to workers actions concurrently
+// when the agent returns several actions in an iteration, such as parallel tool calls.
+// The steps keep the order of the actions, and the first tool error cancels the others.
+func WithMaxConcurrentActions(workers int) Option {
+	return func(co *Options) {
+		co.maxConcurrentActions = workers
+	}
+}
+
 // WithOutputKey is an option for setting the output key of the agent.
 func WithOutputKey(outputKey string) Option {
 	return func(co *Options) {
@@ -161,6 +199,91 @@ func WithParserErrorHandler(errorHandler *ParserErrorHandler) Option {
 	}
 }
 
+// WithToolErrorHandler is an option for setting the policy of an executor for tool errors.
+func WithToolErrorHandler(errorHandler *ToolErrorHandler) Option {
+	return func(co *Options) {
+		co.toolErrorHandler = errorHandler
+	}
+}
+
+// WithApprover is an option for making an executor ask the approver before running the
+// actions of the tools with the given names, or of all tools when no name is given.
+func WithApprover(approver Approver, toolNames ...string) Option {
+	return func(co *Options) {
+		co.approver = approver
+		co.approvalTools = toolNames
+	}
+}
+
+// WithCheckpointStore is an option for making an executor save the state of its runs to
+// the store after every iteration, to resume them with Executor.ResumeRun.
+func WithCheckpointStore(store checkpoint.Store) Option {
+	return func(co *Options) {
+		co.checkpointStore = store
+	}
+}
+
+// WithBudget is an option for limiting the time, tokens and cost of the runs of the executor.
+func WithBudget(budget Budget) Option {
+	return func(co *Options) {
+		co.budget = budget
+	}
+}
+
+// WithEarlyStopping is an option for setting how the executor ends the runs stopped by the
+// max iterations or the budget.
+func WithEarlyStopping(method EarlyStoppingMethod) Option {
+	return func(co *Options) {
+		co.earlyStopping = method
+	}
+}
+
+// WithSystemMessage is an option for setting the system message of the tool calling agent.
+func WithSystemMessage(msg string) Option {
+	return func(co *Options) {
+		co.systemMessage = msg
+	}
+}
+
+// WithExtraMessages is an option for adding messages between the system message and the
+// input in the prompt of the tool calling agent.
+func WithExtraMessages(extraMessages []prompts.MessageFormatter) Option {
+	return func(co *Options) {
+		co.extraMessages = extraMessages
+	}
+}
+
+// WithToolChoice is an option for setting the tool choice of the first model call of the
+// tool calling agent, see llms.WithToolChoice.
+func WithToolChoice(choice any) Option {
+	return func(co *Options) {
+		co.toolChoice = choice
+	}
+}
+
+// WithCallOptions is an option for adding options to the model calls of the tool calling agent.
+func WithCallOptions(options ...llms.CallOption) Option {
+	return func(co *Options) {
+		co.callOptions = options
+	}
+}
+
+// WithReplanner is an option for setting the model revising the plan of the plan-and-execute
+// chain, the planner by default.
+func WithReplanner(llm llms.Model) Option {
+	return func(co *Options) {
+		co.replanner = llm
+	}
+}
+
+// WithReplannerPrompt is an option for setting the prompt of the replanner of the
+// plan-and-execute chain.
+func WithReplannerPrompt(prompt prompts.PromptTemplate) Option {
+	return func(co *Options) {
+		co.replannerPrompt = prompt
+	}
+}
+
 type OpenAIOption struct{}
 
 func NewOpenAIOption() OpenAIOption {
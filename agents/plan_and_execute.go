@@ -0,0 +1,317 @@
+package agents
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+const (
+	_defaultPlanAndExecuteMaxSteps = 10
+	_planOutputKey                 = "plan"
+	_planStepsOutputKey            = "steps"
+)
+
+// _planStepRegexp matches the steps of a numbered or bulleted list.
+var _planStepRegexp = regexp.MustCompile(`(?m)^\s*(?:\d+[.)]|[-*])\s+(.+?)\s*$`)
+
+// PlanStep is an executed step of the plan of a PlanAndExecute run.
+type PlanStep struct {
+	// Step is the step of the plan given to the executor.
+	Step string
+	// Result is the output of the executor for the step.
+	Result string
+}
+
+// PlanAndExecute is a chain that first asks a planner model for the list of steps
+// of the task, then runs the steps one at a time with an executor sub-agent using the
+// tools. After each step, a replanner model either answers or revises the remaining
+// steps from the results so far.
+//
+// The output map holds the answer at the output key, the initial plan at "plan" as a
+// []string and the executed steps at "steps" as a []PlanStep. The plans and the results
+// of the steps are also given to the callbacks handler if it implements
+// callbacks.PlanHandler.
+type PlanAndExecute struct {
+	// Planner is the model writing the initial plan.
+	Planner llms.Model
+	// Replanner is the model revising the plan after each step, the planner if nil.
+	Replanner llms.Model
+	// Executor runs each step given as its "input" value, usually an Executor with the tools.
+	Executor chains.Chain
+	// Tools are the tools of the executor, described to the planner and the replanner.
+	Tools []tools.Tool
+	// PlannerPrompt is formatted with the "input" and the "tool_descriptions" to get the plan.
+	PlannerPrompt prompts.PromptTemplate
+	// ReplannerPrompt is formatted with the "input", the "tool_descriptions", the current
+	// "plan", made of the executed steps and the remaining ones, and the "past_steps".
+	ReplannerPrompt prompts.PromptTemplate
+	// MaxSteps is the maximum number of steps executed in a run.
+	MaxSteps int
+	// OutputKey is the key where the answer is placed.
+	OutputKey        string
+	Memory           schema.Memory
+	CallbacksHandler callbacks.Handler
+}
+
+var (
+	_ chains.Chain           = &PlanAndExecute{}
+	_ callbacks.HandlerHaver = &PlanAndExecute{}
+)
+
+// NewPlanAndExecute creates a new plan-and-execute chain planning with the model and
+// running the steps with the executor, whose tools are given to the planner. The
+// WithPrompt option sets the planner prompt and WithMaxIterations the maximum number
+// of steps.
+func NewPlanAndExecute(planner llms.Model, executor *Executor, opts ...Option) *PlanAndExecute {
+	options := planAndExecuteDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	plannerPrompt := options.prompt
+	if plannerPrompt.Template == "" {
+		plannerPrompt = createPlanAndExecutePrompt(_defaultPlannerPrompt, []string{"input"})
+	}
+	replannerPrompt := options.replannerPrompt
+	if replannerPrompt.Template == "" {
+		replannerPrompt = createPlanAndExecutePrompt(_defaultReplannerPrompt, []string{"input", "plan", "past_steps"})
+	}
+
+	return &PlanAndExecute{
+		Planner:          planner,
+		Replanner:        options.replanner,
+		Executor:         executor,
+		Tools:            executor.Agent.GetTools(),
+		PlannerPrompt:    plannerPrompt,
+		ReplannerPrompt:  replannerPrompt,
+		MaxSteps:         options.maxIterations,
+		OutputKey:        options.outputKey,
+		Memory:           options.memory,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// Call plans the task of the "input" value, then executes and replans the steps until
+// the replanner answers. It returns ErrNotFinished, with the plan and the executed
+// steps, if there is no answer after MaxSteps steps.
+func (p *PlanAndExecute) Call(ctx context.Context, inputValues map[string]any, _ ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+	inputs, err := inputsToString(inputValues)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := p.predict(ctx, p.Planner, p.PlannerPrompt, map[string]any{"input": inputs["input"]})
+	if err != nil {
+		return nil, err
+	}
+	plan := parsePlan(output)
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("%w: no steps in plan: %s", ErrUnableToParseOutput, output)
+	}
+	p.handlePlan(ctx, plan)
+
+	remaining := plan
+	steps := make([]PlanStep, 0, len(plan))
+	for len(steps) < p.MaxSteps {
+		result, err := p.executeStep(ctx, inputs, steps, remaining[0])
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, PlanStep{Step: remaining[0], Result: result})
+		p.handlePlanStepEnd(ctx, remaining[0], result)
+
+		answer, next, err := p.replan(ctx, inputs, currentPlan(steps, remaining[1:]), steps)
+		if err != nil {
+			return nil, err
+		}
+		if answer != nil {
+			return p.getReturn(*answer, plan, steps), nil
+		}
+		remaining = next
+		p.handlePlan(ctx, remaining)
+	}
+
+	return p.getReturn("", plan, steps), ErrNotFinished
+}
+
+// executeStep runs the step with the executor, given the inputs and the previous steps.
+func (p *PlanAndExecute) executeStep(
+	ctx context.Context,
+	inputs map[string]string,
+	steps []PlanStep,
+	step string,
+) (string, error) {
+	values := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		values[key] = value
+	}
+	values["input"] = formatStepInput(inputs["input"], steps, step)
+
+	outputs, err := chains.Call(ctx, p.Executor, values)
+	if err != nil {
+		return "", err
+	}
+
+	outputKey := _defaultOutputKey
+	if keys := p.Executor.GetOutputKeys(); len(keys) > 0 {
+		outputKey = keys[0]
+	}
+	result, ok := outputs[outputKey].(string)
+	if !ok {
+		return "", ErrInvalidChainReturnType
+	}
+	return result, nil
+}
+
+// currentPlan returns the plan being followed: the executed steps, then the remaining ones.
+func currentPlan(steps []PlanStep, remaining []string) []string {
+	plan := make([]string, 0, len(steps)+len(remaining))
+	for _, step := range steps {
+		plan = append(plan, step.Step)
+	}
+	return append(plan, remaining...)
+}
+
+// replan returns the answer, or the remaining steps, decided by the replanner given the
+// current plan. The result of the last step is the answer if there are no remaining steps.
+func (p *PlanAndExecute) replan(
+	ctx context.Context,
+	inputs map[string]string,
+	plan []string,
+	steps []PlanStep,
+) (*string, []string, error) {
+	replanner := p.Replanner
+	if replanner == nil {
+		replanner = p.Planner
+	}
+
+	output, err := p.predict(ctx, replanner, p.ReplannerPrompt, map[string]any{
+		"input":      inputs["input"],
+		"plan":       formatPlan(plan),
+		"past_steps": formatPlanSteps(steps),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, answer, ok := strings.Cut(output, _finalAnswerAction); ok {
+		answer = strings.TrimSpace(answer)
+		return &answer, nil, nil
+	}
+	remaining := parsePlan(output)
+	if len(remaining) == 0 {
+		return &steps[len(steps)-1].Result, nil, nil
+	}
+	return nil, remaining, nil
+}
+
+func (p *PlanAndExecute) predict(
+	ctx context.Context,
+	llm llms.Model,
+	prompt prompts.PromptTemplate,
+	values map[string]any,
+) (string, error) {
+	values["tool_descriptions"] = toolDescriptions(p.Tools)
+	chain := chains.NewLLMChain(recordingUsage(llm), prompt, chains.WithCallback(p.CallbacksHandler))
+	return chains.Predict(ctx, chain, values)
+}
+
+func (p *PlanAndExecute) handlePlan(ctx context.Context, plan []string) {
+	if handler, ok := p.CallbacksHandler.(callbacks.PlanHandler); ok {
+		handler.HandlePlan(ctx, plan)
+	}
+}
+
+func (p *PlanAndExecute) handlePlanStepEnd(ctx context.Context, step, result string) {
+	if handler, ok := p.CallbacksHandler.(callbacks.PlanHandler); ok {
+		handler.HandlePlanStepEnd(ctx, step, result)
+	}
+}
+
+func (p *PlanAndExecute) getReturn(answer string, plan []string, steps []PlanStep) map[string]any {
+	outputs := map[string]any{
+		_planOutputKey:      plan,
+		_planStepsOutputKey: steps,
+	}
+	if answer != "" {
+		outputs[p.OutputKey] = answer
+	}
+	return outputs
+}
+
+// GetInputKeys returns the "input" key of the objective.
+func (p *PlanAndExecute) GetInputKeys() []string {
+	return []string{"input"}
+}
+
+// GetOutputKeys returns the output key, "plan" and "steps".
+func (p *PlanAndExecute) GetOutputKeys() []string {
+	return []string{p.OutputKey, _planOutputKey, _planStepsOutputKey}
+}
+
+func (p *PlanAndExecute) GetMemory() schema.Memory { //nolint:ireturn
+	return p.Memory
+}
+
+func (p *PlanAndExecute) GetCallbackHandler() callbacks.Handler { //nolint:ireturn
+	return p.CallbacksHandler
+}
+
+func parsePlan(text string) []string {
+	matches := _planStepRegexp.FindAllStringSubmatch(text, -1)
+	plan := make([]string, 0, len(matches))
+	for _, match := range matches {
+		plan = append(plan, match[1])
+	}
+	return plan
+}
+
+func formatPlan(plan []string) string {
+	var sb strings.Builder
+	for i, step := range plan {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, step)
+	}
+	return sb.String()
+}
+
+func formatPlanSteps(steps []PlanStep) string {
+	var sb strings.Builder
+	for i, step := range steps {
+		fmt.Fprintf(&sb, "%d. %s\nResult: %s\n", i+1, step.Step, step.Result)
+	}
+	return sb.String()
+}
+
+func formatStepInput(objective string, steps []PlanStep, step string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Objective: %s\n\n", objective)
+	if len(steps) > 0 {
+		fmt.Fprintf(&sb, "Completed steps:\n%s\n", formatPlanSteps(steps))
+	}
+	fmt.Fprintf(&sb, "Current step: %s", step)
+	return sb.String()
+}
+
+//go:embed prompts/plan_and_execute_planner.txt
+var _defaultPlannerPrompt string //nolint:gochecknoglobals
+
+//go:embed prompts/plan_and_execute_replanner.txt
+var _defaultReplannerPrompt string //nolint:gochecknoglobals
+
+func createPlanAndExecutePrompt(template string, inputVariables []string) prompts.PromptTemplate {
+	return prompts.PromptTemplate{
+		Template:       template,
+		TemplateFormat: prompts.TemplateFormatGoTemplate,
+		InputVariables: inputVariables,
+	}
+}
@@ -0,0 +1,161 @@
+package agents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/fake"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+// promptRecordingLLM returns its responses in order and records the prompts.
+type promptRecordingLLM struct {
+	*fake.LLM
+	prompts []string
+}
+
+func newPromptRecordingLLM(responses ...string) *promptRecordingLLM {
+	return &promptRecordingLLM{LLM: fake.NewFakeLLM(responses)}
+}
+
+func (m *promptRecordingLLM) GenerateContent(
+	ctx context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				m.prompts = append(m.prompts, text.Text)
+			}
+		}
+	}
+	return m.LLM.GenerateContent(ctx, messages, options...)
+}
+
+// stepAgent runs its tool with the current step, then finishes with the observation.
+type stepAgent struct {
+	tool tools.Tool
+}
+
+func (a stepAgent) Plan(
+	_ context.Context,
+	steps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(steps) == 0 {
+		_, step, _ := strings.Cut(inputs["input"], "Current step: ")
+		return []schema.AgentAction{{Tool: a.tool.Name(), ToolInput: step}}, nil, nil
+	}
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": steps[0].Observation}}, nil
+}
+
+func (a stepAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a stepAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a stepAgent) GetTools() []tools.Tool  { return []tools.Tool{a.tool} }
+
+type planRecorder struct {
+	callbacks.SimpleHandler
+	plans   [][]string
+	results []string
+}
+
+func (r *planRecorder) HandlePlan(_ context.Context, plan []string) {
+	r.plans = append(r.plans, plan)
+}
+
+func (r *planRecorder) HandlePlanStepEnd(_ context.Context, _ string, result string) {
+	r.results = append(r.results, result)
+}
+
+func TestPlanAndExecute(t *testing.T) {
+	t.Parallel()
+
+	search := &recordingTool{name: "search"}
+	planner := newPromptRecordingLLM(
+		"Here is the plan:\n1. Find the capital of France\n2. Find its population",
+		"1. Find the population of Paris",
+		"Final Answer: Paris has 2 million inhabitants.",
+	)
+	recorder := &planRecorder{}
+	executor := agents.NewExecutor(stepAgent{tool: search})
+	planAndExecute := agents.NewPlanAndExecute(planner, executor, agents.WithCallbacksHandler(recorder))
+
+	outputs, err := chains.Call(t.Context(), planAndExecute, map[string]any{"input": "Population of the capital of France?"})
+	require.NoError(t, err)
+	assert.Equal(t, "Paris has 2 million inhabitants.", outputs["output"])
+	assert.Equal(t, []string{"Find the capital of France", "Find its population"}, outputs["plan"])
+	assert.Equal(t, []agents.PlanStep{
+		{Step: "Find the capital of France", Result: "search ran Find the capital of France"},
+		{Step: "Find the population of Paris", Result: "search ran Find the population of Paris"},
+	}, outputs["steps"])
+	assert.Equal(t, []string{"Find the capital of France", "Find the population of Paris"}, search.inputs)
+
+	assert.Equal(t, [][]string{
+		{"Find the capital of France", "Find its population"},
+		{"Find the population of Paris"},
+	}, recorder.plans)
+	assert.Equal(t, []string{
+		"search ran Find the capital of France",
+		"search ran Find the population of Paris",
+	}, recorder.results)
+
+	require.Len(t, planner.prompts, 3)
+	assert.Contains(t, planner.prompts[0], "- search: Records its inputs.")
+	assert.Contains(t, planner.prompts[0], "Objective: Population of the capital of France?")
+	assert.Contains(t, planner.prompts[1], "1. Find the capital of France\n2. Find its population")
+	assert.Contains(t, planner.prompts[1], "1. Find the capital of France\nResult: search ran Find the capital of France")
+	// the replanner revises the current plan, not the initial one
+	assert.Contains(t, planner.prompts[2], "1. Find the capital of France\n2. Find the population of Paris\n")
+	assert.NotContains(t, planner.prompts[2], "Find its population")
+}
+
+func TestPlanAndExecuteReplanner(t *testing.T) {
+	t.Parallel()
+
+	search := &recordingTool{name: "search"}
+	planner := newPromptRecordingLLM("1. Search the weather")
+	replanner := newPromptRecordingLLM("Nothing left to do.")
+	planAndExecute := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{tool: search}),
+		agents.WithReplanner(replanner))
+
+	outputs, err := chains.Call(t.Context(), planAndExecute, map[string]any{"input": "Weather?"})
+	require.NoError(t, err)
+	// the result of the last step is the answer when no step remains
+	assert.Equal(t, "search ran Search the weather", outputs["output"])
+	assert.Len(t, planner.prompts, 1)
+	assert.Len(t, replanner.prompts, 1)
+}
+
+func TestPlanAndExecuteMaxSteps(t *testing.T) {
+	t.Parallel()
+
+	search := &recordingTool{name: "search"}
+	planner := newPromptRecordingLLM("1. Search\n2. Search again", "- Search again", "- Search more")
+	planAndExecute := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{tool: search}),
+		agents.WithMaxIterations(2))
+
+	outputs, err := chains.Call(t.Context(), planAndExecute, map[string]any{"input": "Search"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	assert.NotContains(t, outputs, "output")
+	assert.Len(t, outputs["steps"], 2)
+	assert.Equal(t, []string{"Search", "Search again"}, search.inputs)
+}
+
+func TestPlanAndExecuteEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	planner := newPromptRecordingLLM("I cannot plan this.")
+	planAndExecute := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{tool: &recordingTool{name: "search"}}))
+
+	_, err := chains.Call(t.Context(), planAndExecute, map[string]any{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
@@ -0,0 +1,172 @@
+package agents
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+// _supervisorFinish is the choice of the supervisor ending the run.
+const _supervisorFinish = "FINISH"
+
+// SupervisorAgent is an agent delegating the task of the user to the members of a team,
+// usually AgentTools wrapping specialist agents. At each step, the model chooses the
+// member to hand off to and its task from the results so far, or gives the final answer
+// combining the results. The members are given their task as plain text.
+//
+// The choices are JSON objects in the text of the model, so any model can supervise.
+type SupervisorAgent struct {
+	// Chain is the chain used to call with the values. The chain should have an
+	// input called "agent_scratchpad" for the results of the members.
+	Chain chains.Chain
+	// Members are the tools the supervisor hands off to.
+	Members []tools.Tool
+	// OutputKey is the key where the final output is placed.
+	OutputKey string
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*SupervisorAgent)(nil)
+
+// supervisorChoice is a choice of the supervisor.
+type supervisorChoice struct {
+	Next   string `json:"next"`
+	Input  string `json:"input"`
+	Answer string `json:"answer"`
+}
+
+// NewSupervisorAgent creates a new SupervisorAgent handing off to the members. The
+// WithPrompt option replaces the prompt, formatted with the "input", the
+// "member_descriptions" and the "agent_scratchpad".
+func NewSupervisorAgent(llm llms.Model, members []tools.Tool, opts ...Option) *SupervisorAgent {
+	options := supervisorDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	prompt := options.prompt
+	if prompt.Template == "" {
+		prompt = createSupervisorPrompt(members)
+	}
+
+	return &SupervisorAgent{
+		Chain:            chains.NewLLMChain(recordingUsage(llm), prompt, chains.WithCallback(options.callbacksHandler)),
+		Members:          members,
+		OutputKey:        options.outputKey,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// Plan chooses the member to hand off to, or returns the final answer.
+func (a *SupervisorAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs)+1)
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs["agent_scratchpad"] = constructSupervisorScratchPad(intermediateSteps)
+
+	output, err := chains.Predict(ctx, a.Chain, fullInputs,
+		chains.WithStreamingFunc(agentStreamingFunc(ctx, a.CallbacksHandler)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.parseOutput(output)
+}
+
+func (a *SupervisorAgent) parseOutput(output string) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	start, end := strings.Index(output, "{"), strings.LastIndex(output, "}")
+	if start == -1 || end < start {
+		return nil, nil, fmt.Errorf("%w: no JSON object in: %s", ErrUnableToParseOutput, output)
+	}
+	var choice supervisorChoice
+	if err := json.Unmarshal([]byte(output[start:end+1]), &choice); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w: %s", ErrUnableToParseOutput, err, output)
+	}
+
+	switch {
+	case strings.EqualFold(choice.Next, _supervisorFinish):
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: choice.Answer},
+			Log:          output,
+		}, nil
+	case choice.Next == "":
+		return nil, nil, fmt.Errorf("%w: no next member in: %s", ErrUnableToParseOutput, output)
+	}
+
+	return []schema.AgentAction{{Tool: choice.Next, ToolInput: choice.Input, Log: output}}, nil, nil
+}
+
+func (a *SupervisorAgent) GetInputKeys() []string {
+	chainInputs := a.Chain.GetInputKeys()
+
+	// Remove inputs given in plan.
+	agentInput := make([]string, 0, len(chainInputs))
+	for _, v := range chainInputs {
+		if v == "agent_scratchpad" {
+			continue
+		}
+		agentInput = append(agentInput, v)
+	}
+
+	return agentInput
+}
+
+func (a *SupervisorAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+// GetTools returns the members.
+func (a *SupervisorAgent) GetTools() []tools.Tool {
+	return a.Members
+}
+
+func constructSupervisorScratchPad(steps []schema.AgentStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nResults so far:\n")
+	for _, step := range steps {
+		if step.Action.Tool == "" {
+			fmt.Fprintf(&sb, "- Invalid response: %s\n", step.Observation)
+			continue
+		}
+		fmt.Fprintf(&sb, "- %s was asked: %s\n  Result: %s\n", step.Action.Tool, step.Action.ToolInput, step.Observation)
+	}
+	return sb.String()
+}
+
+//go:embed prompts/supervisor.txt
+var _defaultSupervisorPrompt string //nolint:gochecknoglobals
+
+func createSupervisorPrompt(members []tools.Tool) prompts.PromptTemplate {
+	var descriptions strings.Builder
+	for _, member := range members {
+		fmt.Fprintf(&descriptions, "- %s: %s\n", member.Name(), member.Description())
+	}
+
+	return prompts.PromptTemplate{
+		Template:       _defaultSupervisorPrompt,
+		TemplateFormat: prompts.TemplateFormatGoTemplate,
+		InputVariables: []string{"input", "agent_scratchpad"},
+		PartialVariables: map[string]any{
+			"member_descriptions": descriptions.String(),
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package agents_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+func TestSupervisorAgent(t *testing.T) {
+	t.Parallel()
+
+	sql := &echoAgent{}
+	search := &echoAgent{}
+	members := []tools.Tool{
+		agents.NewAgentTool("sql", "Answers questions about the database.", agents.NewExecutor(sql)),
+		agents.NewAgentTool("search", "Searches the web.", agents.NewExecutor(search)),
+	}
+	llm := newPromptRecordingLLM(
+		`{"next": "sql", "input": "count the users"}`,
+		"I will search now.\n```json\n{\"next\": \"search\", \"input\": \"average user count\"}\n```",
+		`{"next": "FINISH", "answer": "We have more users than average."}`,
+	)
+	executor := agents.NewExecutor(agents.NewSupervisorAgent(llm, members))
+
+	var events []agents.Event
+	for event := range executor.Stream(t.Context(), map[string]any{"input": "Do we have many users?"}) {
+		events = append(events, event)
+	}
+
+	last := events[len(events)-1]
+	require.Equal(t, agents.EventTypeFinish, last.Type, last.Err)
+	assert.Equal(t, "We have more users than average.", last.Outputs["output"])
+	assert.Len(t, sql.depths, 1)
+	assert.Len(t, search.depths, 1)
+
+	require.Len(t, llm.prompts, 3)
+	assert.Contains(t, llm.prompts[0], "- sql: Answers questions about the database.")
+	assert.Contains(t, llm.prompts[2], "- sql was asked: count the users\n  Result: done: count the users")
+	assert.Contains(t, llm.prompts[2], "- search was asked: average user count")
+
+	var nested []agents.Event
+	for _, event := range events {
+		if event.Depth > 0 {
+			nested = append(nested, event)
+		}
+	}
+	require.NotEmpty(t, nested)
+	assert.Equal(t, agents.EventTypeIterationStart, nested[0].Type)
+	assert.Equal(t, "sql", nested[0].Agent)
+	assert.Equal(t, 1, nested[0].Depth)
+}
+
+func TestSupervisorAgentParseError(t *testing.T) {
+	t.Parallel()
+
+	agent := agents.NewSupervisorAgent(newPromptRecordingLLM("I do not know."), nil)
+	_, _, err := agent.Plan(t.Context(), nil, map[string]string{"input": "?"})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
@@ -0,0 +1,286 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+
+	"github.com/google/uuid"
+)
+
+// _toolCallsLogPrefix starts the log of the actions of a tool calling turn.
+const _toolCallsLogPrefix = "Invoking tools: "
+
+// ToolCallingAgent is an Agent using the native tool calling of any llms.Model supporting
+// llms.WithTools, such as the OpenAI, Anthropic, Google AI, Bedrock, Ollama and Mistral
+// models. The tools are advertised with their arguments schema, see tools.ArgumentsSchema.
+//
+// All the tool calls of a model turn become actions, keeping the tool call ids of the
+// provider, and are given back to the model as a single assistant message followed by
+// one tool message per result. Text and tool calls are collected from all the choices
+// of the response, as some providers return each content block as a choice.
+type ToolCallingAgent struct {
+	// LLM is the model deciding the tool calls.
+	LLM llms.Model
+	// Prompt is formatted with the inputs and the "agent_scratchpad" messages.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// OutputKey is the key where the final output is placed.
+	OutputKey string
+	// ToolChoice is given to llms.WithToolChoice for the first turn of a run, e.g. "required",
+	// "none" or an llms.ToolChoice forcing a tool. The model chooses in the following turns.
+	ToolChoice any
+	// CallOptions are added to the options of the model calls.
+	CallOptions []llms.CallOption
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var (
+	_ Agent         = (*ToolCallingAgent)(nil)
+	_ FinalAnswerer = (*ToolCallingAgent)(nil)
+)
+
+// NewToolCallingAgent creates a new ToolCallingAgent.
+func NewToolCallingAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ToolCallingAgent {
+	options := openAIFunctionsDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &ToolCallingAgent{
+		LLM:              llm,
+		Prompt:           createOpenAIFunctionPrompt(options),
+		Tools:            tools,
+		OutputKey:        options.outputKey,
+		ToolChoice:       options.toolChoice,
+		CallOptions:      options.callOptions,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+// Plan calls the model with the tools and returns an action per tool call, or the
+// finish if the model answers without calling a tool.
+func (a *ToolCallingAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs)+1)
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs[agentScratchpad] = constructToolCallingScratchPad(intermediateSteps)
+
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	options := append([]llms.CallOption{llms.WithTools(a.tools())}, a.CallOptions...)
+	if a.ToolChoice != nil && len(intermediateSteps) == 0 {
+		options = append(options, llms.WithToolChoice(a.ToolChoice))
+	}
+	if stream := agentStreamingFunc(ctx, a.CallbacksHandler); stream != nil {
+		options = append(options, llms.WithStreamingFunc(stream))
+	}
+
+	result, err := a.LLM.GenerateContent(ctx, chatMessagesToMessageContents(prompt.Messages()), options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	RecordUsage(ctx, result)
+
+	return a.ParseOutput(result)
+}
+
+// ParseOutput returns an action per tool call of the response, or the finish if the
+// response has no tool call.
+func (a *ToolCallingAgent) ParseOutput(resp *llms.ContentResponse) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if resp == nil || len(resp.Choices) == 0 {
+		return nil, nil, fmt.Errorf("%w: no choices in response", ErrUnableToParseOutput)
+	}
+
+	var texts []string
+	var toolCalls []llms.ToolCall
+	for _, choice := range resp.Choices {
+		if choice.Content != "" {
+			texts = append(texts, choice.Content)
+		}
+		for _, toolCall := range choice.ToolCalls {
+			if toolCall.FunctionCall != nil {
+				toolCalls = append(toolCalls, toolCall)
+			}
+		}
+		if len(choice.ToolCalls) == 0 && choice.FuncCall != nil {
+			toolCalls = append(toolCalls, llms.ToolCall{Type: "function", FunctionCall: choice.FuncCall})
+		}
+	}
+	content := strings.Join(texts, "\n")
+
+	if len(toolCalls) == 0 {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{a.OutputKey: content},
+			Log:          content,
+		}, nil
+	}
+
+	calls := make([]string, 0, len(toolCalls))
+	for i := range toolCalls {
+		if toolCalls[i].ID == "" {
+			toolCalls[i].ID = "call_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+		}
+		calls = append(calls, fmt.Sprintf("%s (%s)", toolCalls[i].FunctionCall.Name, toolCalls[i].ID))
+	}
+	// the actions of a turn share their log, which holds the unique call ids of the turn
+	log := _toolCallsLogPrefix + strings.Join(calls, ", ") + "\n" + content
+
+	actions := make([]schema.AgentAction, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		actions = append(actions, schema.AgentAction{
+			Tool:      toolCall.FunctionCall.Name,
+			ToolInput: toolCall.FunctionCall.Arguments,
+			Log:       log,
+			ToolID:    toolCall.ID,
+		})
+	}
+	return actions, nil, nil
+}
+
+// FinalAnswer asks the model for the final answer from the previous steps. The tools are
+// still given, as some providers require them with tool calls in the messages, but the
+// tool calls of the response are ignored.
+func (a *ToolCallingAgent) FinalAnswer(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) (*schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs)+1)
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+	fullInputs[agentScratchpad] = constructToolCallingScratchPad(intermediateSteps)
+
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	options := append([]llms.CallOption{llms.WithTools(a.tools())}, a.CallOptions...)
+	return generateFinalAnswer(ctx, a.LLM, chatMessagesToMessageContents(prompt.Messages()), a.OutputKey, options...)
+}
+
+func (a *ToolCallingAgent) GetInputKeys() []string {
+	chainInputs := a.Prompt.GetInputVariables()
+
+	// Remove inputs given in plan.
+	agentInput := make([]string, 0, len(chainInputs))
+	for _, v := range chainInputs {
+		if v == agentScratchpad {
+			continue
+		}
+		agentInput = append(agentInput, v)
+	}
+
+	return agentInput
+}
+
+func (a *ToolCallingAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+func (a *ToolCallingAgent) GetTools() []tools.Tool {
+	return a.Tools
+}
+
+func (a *ToolCallingAgent) tools() []llms.Tool {
+	res := make([]llms.Tool, 0, len(a.Tools))
+	for _, tool := range a.Tools {
+		res = append(res, llms.Tool{
+			Type: "function",
+			Function: &llms.FunctionDefinition{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  tools.ArgumentsSchema(tool),
+			},
+		})
+	}
+	return res
+}
+
+// constructToolCallingScratchPad returns the messages of the steps: for each turn, an
+// assistant message with the tool calls of the turn followed by their results.
+func constructToolCallingScratchPad(steps []schema.AgentStep) []llms.ChatMessage {
+	messages := make([]llms.ChatMessage, 0, len(steps)*2)
+	for i := 0; i < len(steps); {
+		// steps without tool call hold parsing errors
+		if steps[i].Action.ToolID == "" {
+			messages = append(messages, llms.HumanChatMessage{Content: steps[i].Observation})
+			i++
+			continue
+		}
+
+		turn := i + 1
+		for turn < len(steps) && steps[turn].Action.ToolID != "" && steps[turn].Action.Log == steps[i].Action.Log {
+			turn++
+		}
+
+		_, content, _ := strings.Cut(steps[i].Action.Log, "\n")
+		message := llms.AIChatMessage{Content: content}
+		for _, step := range steps[i:turn] {
+			message.ToolCalls = append(message.ToolCalls, llms.ToolCall{
+				ID:   step.Action.ToolID,
+				Type: "function",
+				FunctionCall: &llms.FunctionCall{
+					Name:      step.Action.Tool,
+					Arguments: step.Action.ToolInput,
+				},
+			})
+		}
+		messages = append(messages, message)
+		for _, step := range steps[i:turn] {
+			messages = append(messages, llms.ToolChatMessage{
+				ID:      step.Action.ToolID,
+				Name:    step.Action.Tool,
+				Content: step.Observation,
+			})
+		}
+		i = turn
+	}
+	return messages
+}
+
+// chatMessagesToMessageContents converts the prompt messages, keeping all the tool calls
+// of the assistant messages.
+func chatMessagesToMessageContents(messages []llms.ChatMessage) []llms.MessageContent {
+	contents := make([]llms.MessageContent, 0, len(messages))
+	for _, msg := range messages {
+		mc := llms.MessageContent{Role: msg.GetType()}
+		switch m := msg.(type) {
+		case llms.ToolChatMessage:
+			mc.Parts = []llms.ContentPart{llms.ToolCallResponse{
+				ToolCallID: m.ID,
+				Name:       m.Name,
+				Content:    m.Content,
+			}}
+		case llms.AIChatMessage:
+			if m.Content != "" {
+				mc.Parts = append(mc.Parts, llms.TextContent{Text: m.Content})
+			}
+			for _, toolCall := range m.ToolCalls {
+				mc.Parts = append(mc.Parts, toolCall)
+			}
+		default:
+			mc.Parts = []llms.ContentPart{llms.TextContent{Text: msg.GetContent()}}
+		}
+		contents = append(contents, mc)
+	}
+	return contents
+}
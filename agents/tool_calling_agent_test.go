@@ -0,0 +1,194 @@
+package agents_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/agents"
+	"github.com/vxcontrol/langchaingo/chains"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/tools"
+)
+
+// scriptedToolsLLM returns its responses in order and records the calls.
+type scriptedToolsLLM struct {
+	mu        sync.Mutex
+	responses []*llms.ContentResponse
+	messages  [][]llms.MessageContent
+	options   []llms.CallOptions
+}
+
+func (m *scriptedToolsLLM) GenerateContent(
+	_ context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.messages = append(m.messages, messages)
+	m.options = append(m.options, opts)
+
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+func (m *scriptedToolsLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func toolCall(id, name, arguments string) llms.ToolCall {
+	return llms.ToolCall{
+		ID:           id,
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments},
+	}
+}
+
+func TestToolCallingAgentParallelToolCalls(t *testing.T) {
+	t.Parallel()
+
+	weather := &recordingTool{name: "weather"}
+	news := &recordingTool{name: "news"}
+	llm := &scriptedToolsLLM{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{
+			Content: "Let me check.",
+			ToolCalls: []llms.ToolCall{
+				toolCall("call_a", "weather", "Paris"),
+				toolCall("call_b", "news", "Paris"),
+			},
+		}}},
+		{Choices: []*llms.ContentChoice{{Content: "Sunny, and nothing new."}}},
+	}}
+
+	agent := agents.NewToolCallingAgent(llm, []tools.Tool{weather, news},
+		agents.WithToolChoice("required"))
+	executor := agents.NewExecutor(agent, agents.WithReturnIntermediateSteps())
+
+	outputs, err := chains.Call(t.Context(), executor, map[string]any{"input": "Paris?"})
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny, and nothing new.", outputs["output"])
+	assert.Equal(t, []string{"Paris"}, weather.inputs)
+	assert.Equal(t, []string{"Paris"}, news.inputs)
+
+	steps, ok := outputs["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 2)
+	assert.Equal(t, "call_a", steps[0].Action.ToolID)
+	assert.Equal(t, "call_b", steps[1].Action.ToolID)
+
+	require.Len(t, llm.options, 2)
+	assert.Equal(t, "required", llm.options[0].ToolChoice)
+	assert.Nil(t, llm.options[1].ToolChoice, "tool choice only applies to the first turn")
+	require.Len(t, llm.options[0].Tools, 2)
+	assert.Equal(t, "weather", llm.options[0].Tools[0].Function.Name)
+	assert.NotNil(t, llm.options[0].Tools[0].Function.Parameters)
+
+	// system, human, then the turn as one assistant message and a message per result
+	messages := llm.messages[1]
+	require.Len(t, messages, 5)
+	assert.Equal(t, llms.ChatMessageTypeAI, messages[2].Role)
+	assert.Equal(t, []llms.ContentPart{
+		llms.TextContent{Text: "Let me check."},
+		toolCall("call_a", "weather", "Paris"),
+		toolCall("call_b", "news", "Paris"),
+	}, messages[2].Parts)
+	assert.Equal(t, llms.ChatMessageTypeTool, messages[3].Role)
+	assert.Equal(t, []llms.ContentPart{llms.ToolCallResponse{
+		ToolCallID: "call_a", Name: "weather", Content: "weather ran Paris",
+	}}, messages[3].Parts)
+	assert.Equal(t, []llms.ContentPart{llms.ToolCallResponse{
+		ToolCallID: "call_b", Name: "news", Content: "news ran Paris",
+	}}, messages[4].Parts)
+}
+
+func TestToolCallingAgentSeparateTurns(t *testing.T) {
+	t.Parallel()
+
+	weather := &recordingTool{name: "weather"}
+	llm := &scriptedToolsLLM{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCall("call_a", "weather", "Paris")}}}},
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{toolCall("call_b", "weather", "Rome")}}}},
+		{Choices: []*llms.ContentChoice{{Content: "done"}}},
+	}}
+
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(llm, []tools.Tool{weather}))
+	_, err := chains.Call(t.Context(), executor, map[string]any{"input": "weather?"})
+	require.NoError(t, err)
+
+	messages := llm.messages[2]
+	require.Len(t, messages, 6)
+	roles := make([]llms.ChatMessageType, 0, len(messages))
+	for _, message := range messages {
+		roles = append(roles, message.Role)
+	}
+	assert.Equal(t, []llms.ChatMessageType{
+		llms.ChatMessageTypeSystem, llms.ChatMessageTypeHuman,
+		llms.ChatMessageTypeAI, llms.ChatMessageTypeTool,
+		llms.ChatMessageTypeAI, llms.ChatMessageTypeTool,
+	}, roles)
+	assert.Equal(t, []llms.ContentPart{toolCall("call_b", "weather", "Rome")}, messages[4].Parts)
+}
+
+func TestToolCallingAgentParseOutput(t *testing.T) {
+	t.Parallel()
+
+	agent := agents.NewToolCallingAgent(&scriptedToolsLLM{}, nil)
+
+	t.Run("choice per content block", func(t *testing.T) {
+		t.Parallel()
+
+		actions, finish, err := agent.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{
+			{Content: "Checking both."},
+			{ToolCalls: []llms.ToolCall{toolCall("toolu_1", "weather", `{"city":"Paris"}`)}},
+			{ToolCalls: []llms.ToolCall{toolCall("toolu_2", "news", `{"city":"Paris"}`)}},
+		}})
+		require.NoError(t, err)
+		require.Nil(t, finish)
+		require.Len(t, actions, 2)
+		assert.Equal(t, "toolu_1", actions[0].ToolID)
+		assert.Equal(t, "news", actions[1].Tool)
+		assert.Equal(t, `{"city":"Paris"}`, actions[1].ToolInput)
+		assert.Equal(t, actions[0].Log, actions[1].Log)
+		assert.Contains(t, actions[0].Log, "Checking both.")
+	})
+
+	t.Run("missing tool call id", func(t *testing.T) {
+		t.Parallel()
+
+		actions, _, err := agent.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{
+			{ToolCalls: []llms.ToolCall{toolCall("", "weather", "{}")}},
+		}})
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.NotEmpty(t, actions[0].ToolID)
+	})
+
+	t.Run("finish", func(t *testing.T) {
+		t.Parallel()
+
+		actions, finish, err := agent.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{
+			{Content: "It is sunny."},
+		}})
+		require.NoError(t, err)
+		require.Empty(t, actions)
+		require.NotNil(t, finish)
+		assert.Equal(t, "It is sunny.", finish.ReturnValues["output"])
+	})
+
+	t.Run("no choices", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := agent.ParseOutput(&llms.ContentResponse{})
+		require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+	})
+}
@@ -2,6 +2,7 @@ package callbacks
 
 import (
 	"context"
+	"time"
 
 	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/streaming"
@@ -31,6 +32,21 @@ type Handler interface {
 	HandleStreamingFunc(ctx context.Context, chunk streaming.Chunk)
 }
 
+// PlanHandler is the interface of the handlers hooking into the plans of the
+// plan-and-execute agents. It is optional: the agents check whether their
+// handler implements it.
+type PlanHandler interface {
+	HandlePlan(ctx context.Context, plan []string)
+	HandlePlanStepEnd(ctx context.Context, step string, result string)
+}
+
+// RetryHandler is the interface of the handlers hooking into the retries of the
+// model calls. It is optional: the retrying models check whether their handler
+// implements it.
+type RetryHandler interface {
+	HandleLLMRetry(ctx context.Context, attempt int, delay time.Duration, err error)
+}
+
 // HandlerHaver is an interface used to get callbacks handler.
 type HandlerHaver interface {
 	GetCallbackHandler() Handler
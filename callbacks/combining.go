@@ -2,6 +2,7 @@ package callbacks
 
 import (
 	"context"
+	"time"
 
 	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/streaming"
@@ -13,7 +14,11 @@ type CombiningHandler struct {
 	Callbacks []Handler
 }
 
-var _ Handler = CombiningHandler{}
+var (
+	_ Handler      = CombiningHandler{}
+	_ PlanHandler  = CombiningHandler{}
+	_ RetryHandler = CombiningHandler{}
+)
 
 func (l CombiningHandler) HandleText(ctx context.Context, text string) {
 	for _, handle := range l.Callbacks {
@@ -110,3 +115,31 @@ func (l CombiningHandler) HandleToolError(ctx context.Context, err error) {
 		handle.HandleToolError(ctx, err)
 	}
 }
+
+func (l CombiningHandler) HandlePlan(ctx context.Context, plan []string) {
+	for _, handle := range l.Callbacks {
+		if planHandler, ok := handle.(PlanHandler); ok {
+			planHandler.HandlePlan(ctx, plan)
+		}
+	}
+}
+
+func (l CombiningHandler) HandlePlanStepEnd(ctx context.Context, step string, result string) {
+	for _, handle := range l.Callbacks {
+		if planHandler, ok := handle.(PlanHandler); ok {
+			planHandler.HandlePlanStepEnd(ctx, step, result)
+		}
+	}
+}
+
+// HandleLLMRetry reports the retry to the handlers implementing RetryHandler, and the
+// failed attempt to HandleLLMError of the others.
+func (l CombiningHandler) HandleLLMRetry(ctx context.Context, attempt int, delay time.Duration, err error) {
+	for _, handle := range l.Callbacks {
+		if retryHandler, ok := handle.(RetryHandler); ok {
+			retryHandler.HandleLLMRetry(ctx, attempt, delay, err)
+		} else {
+			handle.HandleLLMError(ctx, err)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package callbacks
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/vxcontrol/langchaingo/llms"
+)
+
+// ModelPrice is the price of the tokens of a model, in dollars per million tokens.
+// The prices of the cached and reasoning tokens default to the input and output
+// prices when zero.
+type ModelPrice struct {
+	Input      float64 `json:"input"`
+	Output     float64 `json:"output"`
+	CacheRead  float64 `json:"cache_read,omitempty"`
+	CacheWrite float64 `json:"cache_write,omitempty"`
+	Reasoning  float64 `json:"reasoning,omitempty"`
+}
+
+// Cost returns the cost of the usage in dollars.
+func (p ModelPrice) Cost(usage llms.Usage) float64 {
+	cacheRead := orDefault(p.CacheRead, p.Input)
+	cacheWrite := orDefault(p.CacheWrite, p.Input)
+	reasoning := orDefault(p.Reasoning, p.Output)
+
+	uncached := usage.PromptTokens - usage.CacheReadTokens - usage.CacheWriteTokens
+	answer := usage.CompletionTokens - usage.ReasoningTokens
+	cost := float64(uncached)*p.Input +
+		float64(usage.CacheReadTokens)*cacheRead +
+		float64(usage.CacheWriteTokens)*cacheWrite +
+		float64(answer)*p.Output +
+		float64(usage.ReasoningTokens)*reasoning
+	return cost / 1e6
+}
+
+func orDefault(price, defaultPrice float64) float64 {
+	if price == 0 {
+		return defaultPrice
+	}
+	return price
+}
+
+// _versionSuffix matches the suffixes of the dated or versioned names of a model, as
+// "-2024-08-06", "-20250514", "@20250514", "-0125", "-001", "-v2" or "-latest".
+var _versionSuffix = regexp.MustCompile(`^[-@](latest|\d{4}-\d{2}-\d{2}|\d{3,8}|v\d+(\.\d+)*)$`)
+
+// PriceTable holds the prices of the models by name. A model missing from the table
+// is priced as the longest name in the table it starts with, followed by a date or a
+// version suffix, so that "gpt-4o" prices "gpt-4o-2024-08-06" too but not "gpt-4o-mini".
+type PriceTable map[string]ModelPrice
+
+// Price returns the price of the model. The second value reports whether the model is
+// priced by the table.
+func (t PriceTable) Price(model string) (ModelPrice, bool) {
+	if price, ok := t[model]; ok {
+		return price, true
+	}
+	var match string
+	for name := range t {
+		if len(name) > len(match) && strings.HasPrefix(model, name) &&
+			_versionSuffix.MatchString(model[len(name):]) {
+			match = name
+		}
+	}
+	if match == "" {
+		return ModelPrice{}, false
+	}
+	return t[match], true
+}
+
+// DefaultPrices returns a price table of common models, with the list prices of their
+// providers at the time of writing. Prices change: check them, and add or override
+// the models you use.
+func DefaultPrices() PriceTable {
+	return PriceTable{
+		"gpt-4o":            {Input: 2.5, Output: 10, CacheRead: 1.25},
+		"gpt-4o-mini":       {Input: 0.15, Output: 0.6, CacheRead: 0.075},
+		"gpt-4.1":           {Input: 2, Output: 8, CacheRead: 0.5},
+		"gpt-4.1-mini":      {Input: 0.4, Output: 1.6, CacheRead: 0.1},
+		"gpt-4.1-nano":      {Input: 0.1, Output: 0.4, CacheRead: 0.025},
+		"o3":                {Input: 2, Output: 8, CacheRead: 0.5},
+		"o3-mini":           {Input: 1.1, Output: 4.4, CacheRead: 0.55},
+		"o4-mini":           {Input: 1.1, Output: 4.4, CacheRead: 0.275},
+		"claude-3-5-haiku":  {Input: 0.8, Output: 4, CacheRead: 0.08, CacheWrite: 1},
+		"claude-3-7-sonnet": {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+		"claude-sonnet-4":   {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+		"claude-opus-4":     {Input: 15, Output: 75, CacheRead: 1.5, CacheWrite: 18.75},
+		"gemini-2.0-flash":  {Input: 0.1, Output: 0.4, CacheRead: 0.025},
+		"gemini-2.5-flash":  {Input: 0.3, Output: 2.5, CacheRead: 0.075},
+		"gemini-2.5-pro":    {Input: 1.25, Output: 10, CacheRead: 0.31},
+	}
+}
+
+// Cost is the cost of the model responses of a scope: a run, a model, or all of them.
+type Cost struct {
+	// Requests is the number of model responses.
+	Requests int `json:"requests"`
+	// Unpriced is the number of responses without usage or without a price for their model.
+	Unpriced int `json:"unpriced"`
+	// Usage is the token usage of the responses.
+	Usage llms.Usage `json:"usage"`
+	// Amount is the cost of the priced responses in dollars.
+	Amount float64 `json:"amount"`
+}
+
+func (c *Cost) add(req RequestCost) {
+	c.Requests++
+	if !req.Priced {
+		c.Unpriced++
+	}
+	c.Usage = c.Usage.Add(req.Usage)
+	c.Amount += req.Amount
+}
+
+// RequestCost is the cost of a model response.
+type RequestCost struct {
+	Model  string     `json:"model"`
+	Run    string     `json:"run,omitempty"`
+	Usage  llms.Usage `json:"usage"`
+	Amount float64    `json:"amount"`
+	Priced bool       `json:"priced"`
+}
+
+// CostSnapshot is a copy of the costs aggregated by a CostHandler.
+type CostSnapshot struct {
+	Total  Cost            `json:"total"`
+	Runs   map[string]Cost `json:"runs"`
+	Models map[string]Cost `json:"models"`
+}
+
+type costRunKey struct{}
+
+// ContextWithRun returns a context whose model responses the CostHandler accounts to
+// the run.
+func ContextWithRun(ctx context.Context, run string) context.Context {
+	return context.WithValue(ctx, costRunKey{}, run)
+}
+
+// RunFromContext returns the run set by ContextWithRun.
+func RunFromContext(ctx context.Context) (string, bool) {
+	run, ok := ctx.Value(costRunKey{}).(string)
+	return run, ok && run != ""
+}
+
+// CostOption is a function that configures a CostHandler.
+type CostOption func(*costLedger)
+
+// WithCostRunKey sets the function returning the run of the context of a response,
+// RunFromContext by default. With agents.RunIDFromContext, the costs are accounted to
+// the runs of the agent executors, which all have an id.
+func WithCostRunKey(runKey func(ctx context.Context) (string, bool)) CostOption {
+	return func(l *costLedger) {
+		l.runKey = runKey
+	}
+}
+
+// WithRequestCostFunc sets a function called with the cost of each response, e.g.
+// to emit metrics or enforce a budget.
+func WithRequestCostFunc(fn func(ctx context.Context, cost RequestCost)) CostOption {
+	return func(l *costLedger) {
+		l.onRequest = fn
+	}
+}
+
+// costLedger aggregates the costs of the handlers created from the same handler.
+type costLedger struct {
+	prices    PriceTable
+	runKey    func(ctx context.Context) (string, bool)
+	onRequest func(ctx context.Context, cost RequestCost)
+
+	mu     sync.Mutex
+	total  Cost
+	runs   map[string]Cost
+	models map[string]Cost
+}
+
+// CostHandler is a callback handler pricing the usage of the model responses against
+// a price table, and aggregating the costs in total, per run and per model. A response
+// is priced as the model reported by the provider, or as the default model of the
+// handler if the provider reports none. It is safe for concurrent use.
+type CostHandler struct {
+	SimpleHandler
+	model  string
+	ledger *costLedger
+}
+
+var _ Handler = &CostHandler{}
+
+// NewCostHandler creates a handler pricing the responses at the prices of the table,
+// as the given model when the provider does not report the model of a response. The
+// table is used as is: add models to it before creating the handler.
+func NewCostHandler(model string, prices PriceTable, opts ...CostOption) *CostHandler {
+	ledger := &costLedger{
+		prices: prices,
+		runKey: RunFromContext,
+		runs:   make(map[string]Cost),
+		models: make(map[string]Cost),
+	}
+	for _, opt := range opts {
+		opt(ledger)
+	}
+	return &CostHandler{
+		model:  model,
+		ledger: ledger,
+	}
+}
+
+// ForModel returns a handler with another default model, whose costs are aggregated
+// with the costs of the handler.
+func (h *CostHandler) ForModel(model string) *CostHandler {
+	return &CostHandler{
+		model:  model,
+		ledger: h.ledger,
+	}
+}
+
+// HandleLLMGenerateContentEnd prices the usage of the response.
+func (h *CostHandler) HandleLLMGenerateContentEnd(ctx context.Context, res *llms.ContentResponse) {
+	if res == nil {
+		return
+	}
+	req := RequestCost{Model: ResponseModel(res, h.model)}
+	if h.ledger.runKey != nil {
+		req.Run, _ = h.ledger.runKey(ctx)
+	}
+	if res.Usage != nil {
+		req.Usage = *res.Usage
+		if price, ok := h.ledger.prices.Price(req.Model); ok {
+			req.Amount = price.Cost(req.Usage)
+			req.Priced = true
+		}
+	}
+
+	h.ledger.mu.Lock()
+	h.ledger.total.add(req)
+	addTo(h.ledger.models, req.Model, req)
+	if req.Run != "" {
+		addTo(h.ledger.runs, req.Run, req)
+	}
+	h.ledger.mu.Unlock()
+
+	if h.ledger.onRequest != nil {
+		h.ledger.onRequest(ctx, req)
+	}
+}
+
+// ResponseModel returns the model of the response reported by the provider, in its
+// Model field or in the "model" generation info of its first choice, or defaultModel
+// if the provider reports none.
+func ResponseModel(res *llms.ContentResponse, defaultModel string) string {
+	if res.Model != "" {
+		return res.Model
+	}
+	if len(res.Choices) > 0 && res.Choices[0] != nil {
+		if model, ok := res.Choices[0].GenerationInfo["model"].(string); ok && model != "" {
+			return model
+		}
+	}
+	return defaultModel
+}
+
+func addTo(costs map[string]Cost, key string, req RequestCost) {
+	cost := costs[key]
+	cost.add(req)
+	costs[key] = cost
+}
+
+// Snapshot returns a copy of the costs aggregated so far.
+func (h *CostHandler) Snapshot() CostSnapshot {
+	h.ledger.mu.Lock()
+	defer h.ledger.mu.Unlock()
+	return CostSnapshot{
+		Total:  h.ledger.total,
+		Runs:   maps.Clone(h.ledger.runs),
+		Models: maps.Clone(h.ledger.models),
+	}
+}
+
+// RunCost returns the cost of the run so far.
+func (h *CostHandler) RunCost(run string) (Cost, bool) {
+	h.ledger.mu.Lock()
+	defer h.ledger.mu.Unlock()
+	cost, ok := h.ledger.runs[run]
+	return cost, ok
+}
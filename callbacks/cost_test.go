@@ -0,0 +1,207 @@
+package callbacks
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/llms"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usageResponse(usage *llms.Usage) *llms.ContentResponse {
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{Content: "ok"}},
+		Usage:   usage,
+	}
+}
+
+func TestModelPriceCost(t *testing.T) {
+	t.Parallel()
+
+	price := ModelPrice{Input: 2, Output: 10, CacheRead: 0.5, CacheWrite: 3, Reasoning: 20}
+	usage := llms.Usage{
+		PromptTokens:     1_000_000,
+		CompletionTokens: 500_000,
+		ReasoningTokens:  100_000,
+		CacheReadTokens:  200_000,
+		CacheWriteTokens: 100_000,
+	}
+	// 0.7M input, 0.2M cache read, 0.1M cache write, 0.4M output, 0.1M reasoning
+	assert.InDelta(t, 1.4+0.1+0.3+4+2, price.Cost(usage), 1e-9)
+
+	// the cached and reasoning tokens default to the input and output prices
+	price = ModelPrice{Input: 2, Output: 10}
+	assert.InDelta(t, 2+5, price.Cost(usage), 1e-9)
+}
+
+func TestPriceTablePrice(t *testing.T) {
+	t.Parallel()
+
+	prices := PriceTable{
+		"gpt-4o":      {Input: 2.5, Output: 10},
+		"gpt-4o-mini": {Input: 0.15, Output: 0.6},
+	}
+
+	price, ok := prices.Price("gpt-4o-mini")
+	require.True(t, ok)
+	assert.InDelta(t, 0.15, price.Input, 1e-9)
+
+	price, ok = prices.Price("gpt-4o-mini-2024-07-18")
+	require.True(t, ok)
+	assert.InDelta(t, 0.15, price.Input, 1e-9)
+
+	price, ok = prices.Price("gpt-4o-2024-08-06")
+	require.True(t, ok)
+	assert.InDelta(t, 2.5, price.Input, 1e-9)
+
+	_, ok = prices.Price("claude-sonnet-4")
+	assert.False(t, ok)
+
+	_, ok = DefaultPrices().Price("claude-sonnet-4-20250514")
+	assert.True(t, ok)
+	_, ok = DefaultPrices().Price("claude-3-5-haiku-latest")
+	assert.True(t, ok)
+	_, ok = DefaultPrices().Price("claude-opus-4@20250514")
+	assert.True(t, ok)
+
+	// other models sharing a prefix with a priced model are not priced as it
+	_, ok = DefaultPrices().Price("o3-pro")
+	assert.False(t, ok)
+	_, ok = DefaultPrices().Price("o3-deep-research-2025-06-26")
+	assert.False(t, ok)
+	_, ok = PriceTable{"gpt-4o": {Input: 2.5, Output: 10}}.Price("gpt-4o-mini-2024-07-18")
+	assert.False(t, ok)
+}
+
+func TestCostHandler(t *testing.T) {
+	t.Parallel()
+
+	var requests []RequestCost
+	prices := PriceTable{
+		"small": {Input: 1, Output: 2},
+		"large": {Input: 10, Output: 20},
+	}
+	small := NewCostHandler("small", prices, WithRequestCostFunc(func(_ context.Context, cost RequestCost) {
+		requests = append(requests, cost)
+	}))
+	large := small.ForModel("large")
+	unknown := small.ForModel("unknown")
+
+	usage := &llms.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000_000}
+	runA := ContextWithRun(t.Context(), "a")
+	runB := ContextWithRun(t.Context(), "b")
+	small.HandleLLMGenerateContentEnd(runA, usageResponse(usage))
+	large.HandleLLMGenerateContentEnd(runA, usageResponse(usage))
+	small.HandleLLMGenerateContentEnd(runB, usageResponse(usage))
+	unknown.HandleLLMGenerateContentEnd(runB, usageResponse(usage))
+	small.HandleLLMGenerateContentEnd(t.Context(), usageResponse(nil))
+
+	snapshot := large.Snapshot()
+	assert.Equal(t, 5, snapshot.Total.Requests)
+	assert.Equal(t, 2, snapshot.Total.Unpriced)
+	assert.Equal(t, 8_000_000, snapshot.Total.Usage.TotalTokens())
+	assert.InDelta(t, 3+30+3, snapshot.Total.Amount, 1e-9)
+
+	require.Len(t, snapshot.Runs, 2)
+	assert.Equal(t, 2, snapshot.Runs["a"].Requests)
+	assert.InDelta(t, 33, snapshot.Runs["a"].Amount, 1e-9)
+	assert.Equal(t, 1, snapshot.Runs["b"].Unpriced)
+	assert.InDelta(t, 3, snapshot.Runs["b"].Amount, 1e-9)
+
+	require.Len(t, snapshot.Models, 3)
+	assert.Equal(t, 3, snapshot.Models["small"].Requests)
+	assert.Equal(t, 1, snapshot.Models["unknown"].Unpriced)
+
+	cost, ok := small.RunCost("a")
+	require.True(t, ok)
+	assert.Equal(t, snapshot.Runs["a"], cost)
+	_, ok = small.RunCost("c")
+	assert.False(t, ok)
+
+	require.Len(t, requests, 5)
+	assert.Equal(t, RequestCost{
+		Model:  "large",
+		Run:    "a",
+		Usage:  *usage,
+		Amount: 30,
+		Priced: true,
+	}, requests[1])
+
+	// the snapshot is a copy
+	snapshot.Runs["a"] = Cost{}
+	cost, _ = small.RunCost("a")
+	assert.Equal(t, 2, cost.Requests)
+}
+
+func TestCostHandlerResponseModel(t *testing.T) {
+	t.Parallel()
+
+	handler := NewCostHandler("small", PriceTable{
+		"small": {Input: 1, Output: 1},
+		"large": {Input: 10, Output: 10},
+	})
+	usage := &llms.Usage{PromptTokens: 1_000_000}
+
+	// the model reported by the provider is priced, not the default model
+	handler.HandleLLMGenerateContentEnd(t.Context(), &llms.ContentResponse{Model: "large-2025-01-01", Usage: usage})
+	handler.HandleLLMGenerateContentEnd(t.Context(), &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{GenerationInfo: map[string]any{"model": "large"}}},
+		Usage:   usage,
+	})
+	handler.HandleLLMGenerateContentEnd(t.Context(), usageResponse(usage))
+	handler.HandleLLMGenerateContentEnd(t.Context(), &llms.ContentResponse{Model: "unknown", Usage: usage})
+
+	snapshot := handler.Snapshot()
+	assert.InDelta(t, 10+10+1, snapshot.Total.Amount, 1e-9)
+	assert.Equal(t, 1, snapshot.Total.Unpriced)
+	assert.Equal(t, 1, snapshot.Models["large-2025-01-01"].Requests)
+	assert.Equal(t, 1, snapshot.Models["large"].Requests)
+	assert.Equal(t, 1, snapshot.Models["small"].Requests)
+	assert.Equal(t, 1, snapshot.Models["unknown"].Unpriced)
+}
+
+func TestCostHandlerRunKey(t *testing.T) {
+	t.Parallel()
+
+	type runKey struct{}
+	handler := NewCostHandler("small", PriceTable{"small": {Input: 1, Output: 1}},
+		WithCostRunKey(func(ctx context.Context) (string, bool) {
+			run, ok := ctx.Value(runKey{}).(string)
+			return run, ok
+		}))
+
+	ctx := context.WithValue(t.Context(), runKey{}, "custom")
+	handler.HandleLLMGenerateContentEnd(ctx, usageResponse(&llms.Usage{PromptTokens: 10}))
+	handler.HandleLLMGenerateContentEnd(ContextWithRun(t.Context(), "default"), usageResponse(&llms.Usage{PromptTokens: 10}))
+
+	snapshot := handler.Snapshot()
+	assert.Equal(t, 2, snapshot.Total.Requests)
+	require.Len(t, snapshot.Runs, 1)
+	assert.Equal(t, 10, snapshot.Runs["custom"].Usage.PromptTokens)
+}
+
+func TestCostHandlerConcurrent(t *testing.T) {
+	t.Parallel()
+
+	handler := NewCostHandler("model", PriceTable{"model": {Input: 1, Output: 1}})
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := ContextWithRun(t.Context(), []string{"a", "b"}[i%2])
+			handler.HandleLLMGenerateContentEnd(ctx, usageResponse(&llms.Usage{PromptTokens: 1, CompletionTokens: 1}))
+			_ = handler.Snapshot()
+		}()
+	}
+	wg.Wait()
+
+	snapshot := handler.Snapshot()
+	assert.Equal(t, 50, snapshot.Total.Requests)
+	assert.Equal(t, 100, snapshot.Total.Usage.TotalTokens())
+	assert.Equal(t, 25, snapshot.Runs["a"].Requests)
+	assert.Equal(t, 25, snapshot.Runs["b"].Requests)
+}
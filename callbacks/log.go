@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/streaming"
@@ -14,7 +15,11 @@ import (
 // LogHandler is a callback handler that prints to the standard output.
 type LogHandler struct{}
 
-var _ Handler = LogHandler{}
+var (
+	_ Handler      = LogHandler{}
+	_ PlanHandler  = LogHandler{}
+	_ RetryHandler = LogHandler{}
+)
 
 func (l LogHandler) HandleLLMGenerateContentStart(_ context.Context, ms []llms.MessageContent) {
 	fmt.Println("Entering LLM with messages:")
@@ -108,6 +113,18 @@ func (l LogHandler) HandleRetrieverEnd(_ context.Context, query string, document
 	fmt.Println("Exiting retriever with documents for query:", documents, query)
 }
 
+func (l LogHandler) HandlePlan(_ context.Context, plan []string) {
+	fmt.Println("Plan:", strings.Join(plan, "; "))
+}
+
+func (l LogHandler) HandlePlanStepEnd(_ context.Context, step string, result string) {
+	fmt.Println("Finished plan step:", removeNewLines(step), "with result:", removeNewLines(result))
+}
+
+func (l LogHandler) HandleLLMRetry(_ context.Context, attempt int, delay time.Duration, err error) {
+	fmt.Println("Retrying LLM call, attempt", attempt, "in", delay, "after error:", err)
+}
+
 func formatChainValues(values map[string]any) string {
 	output := ""
 	for key, value := range values {
@@ -11,7 +11,10 @@ import (
 
 type SimpleHandler struct{}
 
-var _ Handler = SimpleHandler{}
+var (
+	_ Handler     = SimpleHandler{}
+	_ PlanHandler = SimpleHandler{}
+)
 
 func (SimpleHandler) HandleText(context.Context, string)                                   {}
 func (SimpleHandler) HandleLLMStart(context.Context, []string)                             {}
@@ -29,3 +32,5 @@ func (SimpleHandler) HandleAgentFinish(context.Context, schema.AgentFinish)
 func (SimpleHandler) HandleRetrieverStart(context.Context, string)                         {}
 func (SimpleHandler) HandleRetrieverEnd(context.Context, string, []schema.Document)        {}
 func (SimpleHandler) HandleStreamingFunc(context.Context, streaming.Chunk)                 {}
+func (SimpleHandler) HandlePlan(context.Context, []string)                                 {}
+func (SimpleHandler) HandlePlanStepEnd(context.Context, string, string)                    {}
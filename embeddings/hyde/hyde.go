@@ -0,0 +1,92 @@
+// Package hyde implements Hypothetical Document Embeddings (HyDE, https://arxiv.org/abs/2212.10496).
+//
+// The Embedder wraps another embeddings.Embedder: queries are embedded by asking a
+// language model to write passages answering them, and combining the embeddings of
+// these passages. As answers are closer to the indexed documents than questions, this
+// improves the recall of similarity searches. The Embedder plugs into any vector store,
+// and thus vectorstores.ToRetriever, in place of the embedder used to query it.
+package hyde
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/embeddings"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+)
+
+// ErrMissingPassages is returned when the language model writes no passage for a query.
+var ErrMissingPassages = errors.New("no hypothetical passage written")
+
+var _ embeddings.Embedder = &Embedder{}
+
+// Embedder is an embeddings.Embedder embedding queries as hypothetical passages
+// answering them. Documents are embedded by the base embedder as is.
+type Embedder struct {
+	LLM  llms.Model
+	Base embeddings.Embedder
+	// Prompt is formatted with the "question" input variable.
+	Prompt prompts.FormatPrompter
+	// NumPassages is the number of passages written for each query, 1 by default.
+	NumPassages int
+	// IncludeQuery also combines the embedding of the query with the ones of the passages.
+	IncludeQuery bool
+	CallOptions  []llms.CallOption
+}
+
+// New creates a new HyDE Embedder writing the passages with llm and embedding them with embedder.
+func New(llm llms.Model, embedder embeddings.Embedder, opts ...Option) (*Embedder, error) {
+	return applyOptions(llm, embedder, opts...), nil
+}
+
+// EmbedDocuments embeds the documents with the base embedder.
+func (e *Embedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	return e.Base.EmbedDocuments(ctx, texts)
+}
+
+// EmbedQuery writes hypothetical passages answering the query, and returns the
+// normalized average of their embeddings, see embeddings.CombineVectors.
+func (e *Embedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	passages, err := e.WritePassages(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	if e.IncludeQuery {
+		passages = append(passages, text)
+	}
+
+	vectors, err := e.Base.EmbedDocuments(ctx, passages)
+	if err != nil {
+		return nil, err
+	}
+	weights := make([]int, len(vectors))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return embeddings.CombineVectors(vectors, weights)
+}
+
+// WritePassages asks the language model to write NumPassages passages answering the query.
+func (e *Embedder) WritePassages(ctx context.Context, query string) ([]string, error) {
+	prompt, err := e.Prompt.FormatPrompt(map[string]any{"question": query})
+	if err != nil {
+		return nil, err
+	}
+
+	passages := make([]string, 0, e.NumPassages)
+	for range max(e.NumPassages, 1) {
+		completion, err := llms.GenerateFromSinglePrompt(ctx, e.LLM, prompt.String(), e.CallOptions...)
+		if err != nil {
+			return nil, err
+		}
+		if passage := strings.TrimSpace(completion); passage != "" {
+			passages = append(passages, passage)
+		}
+	}
+	if len(passages) == 0 {
+		return nil, ErrMissingPassages
+	}
+	return passages, nil
+}
@@ -0,0 +1,60 @@
+package hyde
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/llms/fake"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapEmbedder returns the vectors of its map, keyed by text.
+type mapEmbedder map[string][]float32
+
+func (e mapEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, e[text])
+	}
+	return vectors, nil
+}
+
+func (e mapEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return e[text], nil
+}
+
+func TestEmbedder(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	base := mapEmbedder{
+		"question":  {0, 0, 1},
+		"passage a": {2, 0, 0},
+		"passage b": {0, 2, 0},
+	}
+
+	embedder, err := New(fake.NewFakeLLM([]string{" passage a\n"}), base)
+	require.NoError(t, err)
+	vector, err := embedder.EmbedQuery(ctx, "question")
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{1, 0, 0}, vector, 1e-6)
+
+	docs, err := embedder.EmbedDocuments(ctx, []string{"question"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0, 0, 1}}, docs)
+
+	embedder, err = New(fake.NewFakeLLM([]string{"passage a", "passage b"}), base,
+		WithNumPassages(2), WithIncludeQuery(true))
+	require.NoError(t, err)
+	vector, err = embedder.EmbedQuery(ctx, "question")
+	require.NoError(t, err)
+	// average of (2, 0, 0), (0, 2, 0) and (0, 0, 1), normalized
+	assert.InDeltaSlice(t, []float32{2.0 / 3, 2.0 / 3, 1.0 / 3}, vector, 1e-6)
+
+	embedder, err = New(fake.NewFakeLLM([]string{"  "}), base)
+	require.NoError(t, err)
+	_, err = embedder.EmbedQuery(ctx, "question")
+	require.ErrorIs(t, err, ErrMissingPassages)
+}
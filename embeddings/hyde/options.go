@@ -0,0 +1,61 @@
+package hyde
+
+import (
+	"github.com/vxcontrol/langchaingo/embeddings"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+)
+
+const (
+	_defaultNumPassages = 1
+	_defaultTemplate    = `Please write a passage to answer the question.
+Question: {{.question}}
+Passage:`
+)
+
+// Option is a function type that can be used to modify the Embedder.
+type Option func(e *Embedder)
+
+// WithPrompt is an option for providing the prompt writing a passage, formatted
+// with the "question" input variable.
+func WithPrompt(prompt prompts.FormatPrompter) Option {
+	return func(e *Embedder) {
+		e.Prompt = prompt
+	}
+}
+
+// WithNumPassages is an option for specifying the number of hypothetical passages
+// written for each query.
+func WithNumPassages(numPassages int) Option {
+	return func(e *Embedder) {
+		e.NumPassages = numPassages
+	}
+}
+
+// WithIncludeQuery is an option for also combining the embedding of the query itself
+// with the embeddings of the passages.
+func WithIncludeQuery(includeQuery bool) Option {
+	return func(e *Embedder) {
+		e.IncludeQuery = includeQuery
+	}
+}
+
+// WithCallOptions is an option for specifying the options of the LLM calls.
+func WithCallOptions(options ...llms.CallOption) Option {
+	return func(e *Embedder) {
+		e.CallOptions = options
+	}
+}
+
+func applyOptions(llm llms.Model, embedder embeddings.Embedder, opts ...Option) *Embedder {
+	e := &Embedder{
+		LLM:         llm,
+		Base:        embedder,
+		Prompt:      prompts.NewPromptTemplate(_defaultTemplate, []string{"question"}),
+		NumPassages: _defaultNumPassages,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
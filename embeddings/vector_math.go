@@ -28,6 +28,26 @@ func CombineVectors(vectors [][]float32, weights []int) ([]float32, error) {
 	return average, nil
 }
 
+// CosineSimilarity returns the cosine similarity of two vectors of the same size.
+// It returns 0 if the vectors have different sizes or one of them is a zero vector.
+func CosineSimilarity(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot float32
+	for i := 0; i < len(a); i++ {
+		dot += a[i] * b[i]
+	}
+
+	norm := getNorm(a) * getNorm(b)
+	if norm == 0 {
+		return 0
+	}
+
+	return dot / norm
+}
+
 // getAverage does the following calculation:
 //
 //	avg = sum(vectors * weights) / sum(weights).
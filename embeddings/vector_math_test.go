@@ -108,3 +108,29 @@ func TestGetNorm(t *testing.T) {
 		})
 	}
 }
+
+func TestCosineSimilarity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		a        []float32
+		b        []float32
+		expected float32
+	}{
+		{name: "same direction", a: []float32{1, 2, 3}, b: []float32{2, 4, 6}, expected: 1},
+		{name: "orthogonal", a: []float32{1, 0}, b: []float32{0, 1}, expected: 0},
+		{name: "opposite", a: []float32{1, 1}, b: []float32{-1, -1}, expected: -1},
+		{name: "zero vector", a: []float32{0, 0}, b: []float32{1, 1}, expected: 0},
+		{name: "different sizes", a: []float32{1, 2}, b: []float32{1, 2, 3}, expected: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CosineSimilarity(tc.a, tc.b)
+			if math.Abs(float64(got-tc.expected)) > 1e-6 {
+				t.Errorf("CosineSimilarity() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -110,6 +110,7 @@ require (
 	github.com/nikolalohinski/gonja v1.5.3
 	github.com/zeebo/blake3 v0.2.4
 	golang.org/x/sync v0.15.0
+	golang.org/x/time v0.12.0
 	golang.org/x/tools v0.34.0
 	sigs.k8s.io/yaml v1.3.0
 )
@@ -320,7 +321,6 @@ require (
 	golang.org/x/net v0.41.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.26.0 // indirect
-	golang.org/x/time v0.12.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,134 @@
+package jsonschema
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedType is returned when a Go type cannot be described by a Definition.
+var ErrUnsupportedType = errors.New("unsupported type for JSON schema")
+
+var (
+	_timeType          = reflect.TypeOf(time.Time{})
+	_textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// GenerateSchemaForType returns the Definition of the JSON encoding of v, a Go value
+// or a pointer to one, typically a struct describing the arguments of a tool.
+//
+// Struct fields are named after their json tag, and are required unless tagged
+// omitempty or `required:"false"`. The `description` tag sets the description of a
+// field and the `enum` tag its comma separated allowed values.
+func GenerateSchemaForType(v any) (*Definition, error) {
+	def, err := reflectType(reflect.TypeOf(v), map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+func reflectType(t reflect.Type, parents map[reflect.Type]bool) (Definition, error) {
+	if t == nil {
+		return Definition{}, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == _timeType:
+		return Definition{Type: String}, nil
+	case t.Kind() != reflect.String && t.Implements(_textMarshalerType):
+		return Definition{Type: String}, nil
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return Definition{Type: String}, nil
+	case reflect.Bool:
+		return Definition{Type: Boolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Definition{Type: Integer}, nil
+	case reflect.Float32, reflect.Float64:
+		return Definition{Type: Number}, nil
+	case reflect.Interface:
+		return Definition{}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return Definition{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+		}
+		return Definition{Type: Object}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := reflectType(t.Elem(), parents)
+		if err != nil {
+			return Definition{}, err
+		}
+		return Definition{Type: Array, Items: &items}, nil
+	case reflect.Struct:
+		if parents[t] {
+			return Definition{}, fmt.Errorf("%w: recursive type %s", ErrUnsupportedType, t)
+		}
+		parents[t] = true
+		defer delete(parents, t)
+
+		def := Definition{Type: Object, Properties: map[string]Definition{}}
+		if err := reflectFields(t, &def, parents); err != nil {
+			return Definition{}, err
+		}
+		return def, nil
+	default:
+		return Definition{}, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
+func reflectFields(t reflect.Type, def *Definition, parents map[reflect.Type]bool) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// the fields of embedded structs are promoted, as by encoding/json
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := reflectFields(embedded, def, parents); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := reflectType(field.Type, parents)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		property.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			property.Enum = strings.Split(enum, ",")
+		}
+		def.Properties[name] = property
+
+		optional := strings.Contains(","+opts+",", ",omitempty,") || field.Tag.Get("required") == "false"
+		if !optional {
+			def.Required = append(def.Required, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package jsonschema_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/jsonschema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City    string `json:"city" description:"The city name"`
+	Country string `json:"country,omitempty" enum:"FR,US"`
+}
+
+type weatherArgs struct {
+	address
+	Days     int               `json:"days" description:"Number of days to forecast"`
+	Units    *string           `json:"units" required:"false" enum:"metric,imperial"`
+	Hourly   bool              `json:"hourly"`
+	Ratio    float64           `json:"ratio,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Extra    map[string]any    `json:"extra,omitempty"`
+	Since    time.Time         `json:"since,omitempty"`
+	Ignored  string            `json:"-"`
+	Previous []address         `json:"previous,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	internal string
+}
+
+func TestGenerateSchemaForType(t *testing.T) {
+	t.Parallel()
+
+	def, err := jsonschema.GenerateSchemaForType(weatherArgs{})
+	require.NoError(t, err)
+
+	addressDef := jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city":    {Type: jsonschema.String, Description: "The city name"},
+			"country": {Type: jsonschema.String, Enum: []string{"FR", "US"}},
+		},
+		Required: []string{"city"},
+	}
+	assert.Equal(t, &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city":     addressDef.Properties["city"],
+			"country":  addressDef.Properties["country"],
+			"days":     {Type: jsonschema.Integer, Description: "Number of days to forecast"},
+			"units":    {Type: jsonschema.String, Enum: []string{"metric", "imperial"}},
+			"hourly":   {Type: jsonschema.Boolean},
+			"ratio":    {Type: jsonschema.Number},
+			"tags":     {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+			"extra":    {Type: jsonschema.Object},
+			"since":    {Type: jsonschema.String},
+			"previous": {Type: jsonschema.Array, Items: &addressDef},
+			"labels":   {Type: jsonschema.Object},
+		},
+		Required: []string{"city", "days", "hourly"},
+	}, def)
+
+	pointerDef, err := jsonschema.GenerateSchemaForType(&weatherArgs{})
+	require.NoError(t, err)
+	assert.Equal(t, def, pointerDef)
+}
+
+type node struct {
+	Children []node `json:"children"`
+}
+
+func TestGenerateSchemaForTypeUnsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, v := range []any{node{}, map[int]string{}, struct{ C chan int }{}} {
+		_, err := jsonschema.GenerateSchemaForType(v)
+		require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+	}
+}
@@ -0,0 +1,131 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// ErrValidation is returned when a value does not match a Definition.
+var ErrValidation = errors.New("value does not match schema")
+
+// Validate checks that data, a value decoded from JSON into an any, matches the schema.
+// Only the keywords of Definition are checked, additional properties are allowed.
+// The returned error wraps ErrValidation and describes the first mismatch found.
+func Validate(schema Definition, data any) error {
+	return validate(schema, data, "$")
+}
+
+// VerifySchemaAndUnmarshal checks that the JSON content matches the schema,
+// and unmarshals it into v.
+func VerifySchemaAndUnmarshal(schema Definition, content []byte, v any) error {
+	var data any
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("%w: invalid JSON: %w", ErrValidation, err)
+	}
+	if err := Validate(schema, data); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidation, err)
+	}
+	return nil
+}
+
+func validate(schema Definition, data any, path string) error { //nolint:cyclop
+	switch schema.Type {
+	case Object:
+		object, ok := data.(map[string]any)
+		if !ok {
+			return mismatch(path, schema.Type, data)
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%w: %s: missing required property %q", ErrValidation, path, name)
+			}
+		}
+		names := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value, ok := object[name]
+			// optional properties may be null, as nil pointers are encoded
+			if !ok || (value == nil && !slices.Contains(schema.Required, name)) {
+				continue
+			}
+			if err := validate(schema.Properties[name], value, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case Array:
+		array, ok := data.([]any)
+		if !ok {
+			return mismatch(path, schema.Type, data)
+		}
+		if schema.Items == nil {
+			return nil
+		}
+		for i, item := range array {
+			if err := validate(*schema.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case String:
+		s, ok := data.(string)
+		if !ok {
+			return mismatch(path, schema.Type, data)
+		}
+		if len(schema.Enum) != 0 && !slices.Contains(schema.Enum, s) {
+			return fmt.Errorf("%w: %s: %q is not one of %q", ErrValidation, path, s, schema.Enum)
+		}
+	case Number:
+		if _, ok := data.(float64); !ok {
+			return mismatch(path, schema.Type, data)
+		}
+	case Integer:
+		n, ok := data.(float64)
+		if !ok || n != math.Trunc(n) {
+			return mismatch(path, schema.Type, data)
+		}
+	case Boolean:
+		if _, ok := data.(bool); !ok {
+			return mismatch(path, schema.Type, data)
+		}
+	case Null:
+		if data != nil {
+			return mismatch(path, schema.Type, data)
+		}
+	}
+	return nil
+}
+
+func mismatch(path string, expected DataType, data any) error {
+	return fmt.Errorf("%w: %s: expected %s, got %s", ErrValidation, path, expected, typeOf(data))
+}
+
+func typeOf(data any) string {
+	switch v := data.(type) {
+	case nil:
+		return string(Null)
+	case map[string]any:
+		return string(Object)
+	case []any:
+		return string(Array)
+	case string:
+		return string(String)
+	case bool:
+		return string(Boolean)
+	case float64:
+		if v == math.Trunc(v) {
+			return string(Integer)
+		}
+		return string(Number)
+	default:
+		return fmt.Sprintf("%T", data)
+	}
+}
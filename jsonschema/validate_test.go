@@ -0,0 +1,55 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/jsonschema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySchemaAndUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	schema, err := jsonschema.GenerateSchemaForType(weatherArgs{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "valid", content: `{"city": "Paris", "country": "FR", "days": 3, "hourly": false, "units": null}`},
+		{name: "extra property", content: `{"city": "Paris", "days": 3, "hourly": true, "unknown": 1}`},
+		{name: "invalid json", content: `{"city": `, wantErr: "invalid JSON"},
+		{name: "not an object", content: `"Paris"`, wantErr: "$: expected object, got string"},
+		{name: "missing property", content: `{"city": "Paris", "hourly": true}`, wantErr: `missing required property "days"`},
+		{name: "wrong type", content: `{"city": 1, "days": 3, "hourly": true}`, wantErr: "$.city: expected string, got integer"},
+		{name: "not an integer", content: `{"city": "Paris", "days": 1.5, "hourly": true}`, wantErr: "$.days: expected integer, got number"},
+		{name: "enum", content: `{"city": "Paris", "country": "DE", "days": 3, "hourly": true}`, wantErr: `"DE" is not one of`},
+		{name: "required null", content: `{"city": null, "days": 3, "hourly": true}`, wantErr: "$.city: expected string, got null"},
+		{
+			name:    "array item",
+			content: `{"city": "Paris", "days": 3, "hourly": true, "previous": [{"city": "Lyon"}, {"city": true}]}`,
+			wantErr: "$.previous[1].city: expected string, got boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var args weatherArgs
+			err := jsonschema.VerifySchemaAndUnmarshal(*schema, []byte(tt.content), &args)
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, jsonschema.ErrValidation)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Paris", args.City)
+			assert.Equal(t, 3, args.Days)
+		})
+	}
+}
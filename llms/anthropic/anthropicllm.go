@@ -252,6 +252,15 @@ func generateMessagesContent(ctx context.Context, o *LLM, messages []llms.Messag
 
 	resp := &llms.ContentResponse{
 		Choices: choices,
+		Model:   result.Model,
+		// the input tokens of anthropic exclude the tokens read from and written to the cache
+		Usage: &llms.Usage{
+			PromptTokens: result.Usage.InputTokens + result.Usage.CacheReadInputTokens +
+				result.Usage.CacheCreationInputTokens,
+			CompletionTokens: result.Usage.OutputTokens,
+			CacheReadTokens:  result.Usage.CacheReadInputTokens,
+			CacheWriteTokens: result.Usage.CacheCreationInputTokens,
+		},
 	}
 	return resp, nil
 }
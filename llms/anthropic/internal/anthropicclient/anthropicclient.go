@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/vxcontrol/langchaingo/httputil"
+	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/streaming"
 )
 
@@ -210,7 +211,7 @@ func (c *Client) decodeError(resp *http.Response) error {
 
 	var errResp errorMessage
 	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-		return errors.New(msg)
+		return llms.WithRetryAfter(errors.New(msg), resp.Header)
 	}
-	return fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+	return llms.WithRetryAfter(fmt.Errorf("%s: %s", msg, errResp.Error.Message), resp.Header)
 }
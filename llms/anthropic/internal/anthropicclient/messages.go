@@ -201,8 +201,10 @@ type MessageResponsePayload struct {
 	StopSequence string    `json:"stop_sequence"`
 	Type         string    `json:"type"`
 	Usage        struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
 	} `json:"usage"`
 }
 
@@ -496,6 +498,12 @@ func handleMessageStartEvent(event map[string]interface{}, response MessageRespo
 	response.Role = getString(message, "role")
 	response.Type = getString(message, "type")
 	response.Usage.InputTokens = int(inputTokens)
+	if cacheCreationTokens, ok := usage["cache_creation_input_tokens"].(float64); ok {
+		response.Usage.CacheCreationInputTokens = int(cacheCreationTokens)
+	}
+	if cacheReadTokens, ok := usage["cache_read_input_tokens"].(float64); ok {
+		response.Usage.CacheReadInputTokens = int(cacheReadTokens)
+	}
 
 	return response, nil
 }
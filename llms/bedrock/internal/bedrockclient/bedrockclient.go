@@ -3,11 +3,15 @@ package bedrockclient
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 
 	"github.com/vxcontrol/langchaingo/llms"
 
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 // Client is a Bedrock client.
@@ -121,3 +125,21 @@ func getMaxTokens(maxTokens, defaultValue int) int {
 	}
 	return maxTokens
 }
+
+// invocationUsage returns the token usage reported in the headers of an
+// InvokeModel response, nil if the headers are missing.
+func invocationUsage(metadata middleware.Metadata) *llms.Usage {
+	raw, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response)
+	if !ok {
+		return nil
+	}
+	input, err := strconv.Atoi(raw.Header.Get("X-Amzn-Bedrock-Input-Token-Count"))
+	if err != nil {
+		return nil
+	}
+	output, err := strconv.Atoi(raw.Header.Get("X-Amzn-Bedrock-Output-Token-Count"))
+	if err != nil {
+		return nil
+	}
+	return &llms.Usage{PromptTokens: input, CompletionTokens: output}
+}
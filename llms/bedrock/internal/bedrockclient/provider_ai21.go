@@ -120,7 +120,9 @@ func createAi21Completion(ctx context.Context, client *bedrockruntime.Client, mo
 	}
 
 	choices := make([]*llms.ContentChoice, len(output.Completions))
+	usage := &llms.Usage{PromptTokens: len(output.Prompt.Tokens)}
 	for i, completion := range output.Completions {
+		usage.CompletionTokens += len(completion.Data.Tokens)
 		choices[i] = &llms.ContentChoice{
 			Content:    completion.Data.Text,
 			StopReason: completion.FinishReason.Reason,
@@ -132,5 +134,5 @@ func createAi21Completion(ctx context.Context, client *bedrockruntime.Client, mo
 		}
 	}
 
-	return &llms.ContentResponse{Choices: choices}, nil
+	return &llms.ContentResponse{Choices: choices, Usage: usage}, nil
 }
@@ -102,8 +102,10 @@ func createAmazonCompletion(ctx context.Context,
 	}
 
 	contentChoices := make([]*llms.ContentChoice, len(output.Results))
+	usage := &llms.Usage{PromptTokens: output.InputTextTokenCount}
 
 	for i, result := range output.Results {
+		usage.CompletionTokens += result.TokenCount
 		contentChoices[i] = &llms.ContentChoice{
 			Content:    result.OutputText,
 			StopReason: result.CompletionReason,
@@ -116,5 +118,6 @@ func createAmazonCompletion(ctx context.Context,
 
 	return &llms.ContentResponse{
 		Choices: contentChoices,
+		Usage:   usage,
 	}, nil
 }
@@ -118,5 +118,6 @@ func createCohereCompletion(ctx context.Context,
 
 	return &llms.ContentResponse{
 		Choices: choices,
+		Usage:   invocationUsage(resp.ResultMetadata),
 	}, nil
 }
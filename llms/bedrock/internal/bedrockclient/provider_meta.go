@@ -95,5 +95,9 @@ func createMetaCompletion(ctx context.Context,
 				},
 			},
 		},
+		Usage: &llms.Usage{
+			PromptTokens:     output.PromptTokenCount,
+			CompletionTokens: output.GenerationTokenCount,
+		},
 	}, nil
 }
@@ -198,8 +198,22 @@ func createNovaCompletion(ctx context.Context,
 			},
 		}
 	}
+	usage := &llms.Usage{
+		PromptTokens:     output.Usage.InputTokens,
+		CompletionTokens: output.Usage.OutputTokens,
+	}
+	// Nova reports the cached tokens apart from the input tokens.
+	if output.Usage.CacheReadInputTokenCount != nil {
+		usage.CacheReadTokens = *output.Usage.CacheReadInputTokenCount
+		usage.PromptTokens += usage.CacheReadTokens
+	}
+	if output.Usage.CacheWriteInputTokenCount != nil {
+		usage.CacheWriteTokens = *output.Usage.CacheWriteInputTokenCount
+		usage.PromptTokens += usage.CacheWriteTokens
+	}
 	return &llms.ContentResponse{
 		Choices: Contentchoices,
+		Usage:   usage,
 	}, nil
 }
 
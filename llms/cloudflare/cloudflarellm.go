@@ -135,6 +135,12 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	}
 
 	response := &llms.ContentResponse{Choices: choices}
+	if usage := res.Result.Usage; usage != nil {
+		response.Usage = &llms.Usage{
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+		}
+	}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
@@ -1,6 +1,8 @@
 package cloudflare
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/cloudflare/internal/cloudflareclient"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -419,3 +422,53 @@ func TestGenerateContentWithErrors(t *testing.T) {
 		t.Errorf("GenerateContent() error = %v, want error containing 'Invalid API key'", err)
 	}
 }
+
+func TestGenerateContentUsage(t *testing.T) {
+	ctx := t.Context()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request cloudflareclient.GenerateContentRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		if request.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, err := w.Write([]byte("data: {\"response\":\"Hello\",\"p\":\"abc\"}\n\n" +
+				"data: {\"response\":\"\",\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":3,\"total_tokens\":15}}\n\n" +
+				"data: [DONE]\n\n"))
+			assert.NoError(t, err)
+			return
+		}
+		response := `{
+			"result": {
+				"response": "Hello",
+				"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15}
+			},
+			"success": true,
+			"errors": [],
+			"messages": []
+		}`
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	llm, _ := New(
+		WithToken("test-token"),
+		WithAccountID("test-account-id"),
+		WithModel("test-model"),
+		WithCloudflareServerURL(serverURL),
+	)
+	messages := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hello")}
+	want := &llms.Usage{PromptTokens: 12, CompletionTokens: 3}
+
+	resp, err := llm.GenerateContent(ctx, messages)
+	assert.NoError(t, err)
+	assert.Equal(t, want, resp.Usage)
+
+	resp, err = llm.GenerateContent(ctx, messages, llms.WithStreamingFunc(func(context.Context, streaming.Chunk) error {
+		return nil
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, want, resp.Usage)
+}
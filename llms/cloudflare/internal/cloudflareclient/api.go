@@ -97,6 +97,8 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateContentRe
 	}
 	defer streaming.CallWithDone(ctx, request.StreamingFunc) //nolint:errcheck
 
+	// the usage is sent with the last events of the stream
+	var usage *Usage
 	scanner := bufio.NewScanner(response.Body)
 	// increase the buffer size to avoid running out of space
 	scanBuf := make([]byte, 0, maxBufferSize)
@@ -134,12 +136,18 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateContentRe
 			}, nil
 		}
 
+		if streamingResponse.Usage != nil {
+			usage = streamingResponse.Usage
+		}
+
 		if err = streaming.CallWithText(ctx, request.StreamingFunc, string(bts)); err != nil {
 			return nil, err
 		}
 	}
 
-	return &GenerateContentResponse{}, nil
+	generateResponse := &GenerateContentResponse{}
+	generateResponse.Result.Usage = usage
+	return generateResponse, nil
 }
 
 // Summarize summarizes the given input text.
@@ -21,13 +21,22 @@ type GenerateContentResponse struct {
 	Messages []string   `json:"messages"`
 	Result   struct {
 		Response string `json:"response"`
+		Usage    *Usage `json:"usage,omitempty"`
 	} `json:"result"`
 	Success bool `json:"success"`
 }
 
+// Usage is the token usage of a text generation, given by the models reporting it.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
 type StreamingResponse struct {
 	Response string `json:"response"`
 	P        string `json:"p"`
+	Usage    *Usage `json:"usage,omitempty"`
 }
 
 type APIError struct {
@@ -59,6 +59,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				Content: result.Text,
 			},
 		},
+		Usage: &llms.Usage{
+			PromptTokens:     result.InputTokens,
+			CompletionTokens: result.OutputTokens,
+		},
 	}
 
 	if o.CallbacksHandler != nil {
@@ -43,6 +43,8 @@ func TestClient_CreateGeneration(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.NotEmpty(t, resp.Text)
+	assert.Equal(t, 11, resp.InputTokens)
+	assert.Equal(t, 696, resp.OutputTokens)
 }
 
 func TestClient_CreateGenerationWithCustomModel(t *testing.T) {
@@ -58,3 +58,14 @@ func TestOpenAIComplianceExample(t *testing.T) {
 	// suite := compliance.NewSuite("openai", llm)
 	// suite.Run(t)
 }
+
+func TestNewSuiteSkipsUsage(t *testing.T) {
+	t.Parallel()
+
+	if !compliance.NewSuite("local", fake.NewFakeLLM(nil)).SkipTests["Usage"] {
+		t.Error("Expected the Usage test to be skipped for the local provider")
+	}
+	if compliance.NewSuite("cloudflare", fake.NewFakeLLM(nil)).SkipTests["Usage"] {
+		t.Error("Expected the Usage test to run for the cloudflare provider")
+	}
+}
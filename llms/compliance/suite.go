@@ -24,12 +24,23 @@ type Suite struct {
 	Timeout time.Duration
 }
 
-// NewSuite creates a new compliance test suite.
+// noUsageProviders are the providers whose responses have no token usage, as their
+// backend does not report it. Their suites skip the Usage test.
+var noUsageProviders = map[string]bool{ //nolint:gochecknoglobals
+	"local": true,
+}
+
+// NewSuite creates a new compliance test suite. The Usage test is skipped for the
+// providers reporting no token usage.
 func NewSuite(provider string, model llms.Model) *Suite {
+	skipTests := make(map[string]bool)
+	if noUsageProviders[provider] {
+		skipTests["Usage"] = true
+	}
 	return &Suite{
 		Provider:  provider,
 		Model:     model,
-		SkipTests: make(map[string]bool),
+		SkipTests: skipTests,
 		Timeout:   30 * time.Second,
 	}
 }
@@ -50,6 +61,7 @@ func (s *Suite) Run(t *testing.T) {
 		{"Temperature", s.testTemperature},
 		{"MaxTokens", s.testMaxTokens},
 		{"StopSequences", s.testStopSequences},
+		{"Usage", s.testUsage},
 	}
 
 	for _, test := range tests {
@@ -199,3 +211,35 @@ func (s *Suite) testStopSequences(t *testing.T) {
 		t.Errorf("Expected output to stop before Thursday but got: %s", output)
 	}
 }
+
+func (s *Suite) testUsage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.Timeout)
+	defer cancel()
+
+	content := []llms.MessageContent{
+		{Role: "user", Parts: []llms.ContentPart{llms.TextPart("Say 'Hello, World!' and nothing else.")}},
+	}
+
+	resp, err := s.Model.GenerateContent(ctx, content)
+	if err != nil {
+		t.Fatalf("GenerateContent failed: %v", err)
+	}
+
+	if resp.Usage == nil {
+		t.Fatal("No usage returned")
+	}
+	if resp.Usage.PromptTokens <= 0 {
+		t.Errorf("Expected positive prompt tokens but got: %d", resp.Usage.PromptTokens)
+	}
+	if resp.Usage.CompletionTokens <= 0 {
+		t.Errorf("Expected positive completion tokens but got: %d", resp.Usage.CompletionTokens)
+	}
+	if resp.Usage.ReasoningTokens > resp.Usage.CompletionTokens {
+		t.Errorf("Expected reasoning tokens within completion tokens but got: %d > %d",
+			resp.Usage.ReasoningTokens, resp.Usage.CompletionTokens)
+	}
+	if resp.Usage.CacheReadTokens+resp.Usage.CacheWriteTokens > resp.Usage.PromptTokens {
+		t.Errorf("Expected cached tokens within prompt tokens but got: %d > %d",
+			resp.Usage.CacheReadTokens+resp.Usage.CacheWriteTokens, resp.Usage.PromptTokens)
+	}
+}
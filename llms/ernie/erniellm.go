@@ -114,6 +114,10 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				Content: result.Result,
 			},
 		},
+		Usage: &llms.Usage{
+			PromptTokens:     result.Usage.PromptTokens,
+			CompletionTokens: result.Usage.CompletionTokens,
+		},
 	}
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, resp)
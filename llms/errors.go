@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"time"
 )
 
 // ErrorCode represents a standardized error code for LLM operations.
@@ -185,6 +188,59 @@ func IsNotImplementedError(err error) bool {
 	return errors.As(err, &e) && e.Code == ErrCodeNotImplemented
 }
 
+// RetryAfter returns the delay the provider asked to wait before retrying the
+// request. The delay is carried by the "retry_after" detail of an Error, or by an
+// error of the chain implementing RetryAfter() time.Duration.
+func RetryAfter(err error) (time.Duration, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		if delay, ok := e.Details["retry_after"].(time.Duration); ok {
+			return delay, true
+		}
+	}
+	var hinted interface{ RetryAfter() time.Duration }
+	if errors.As(err, &hinted) {
+		return hinted.RetryAfter(), true
+	}
+	return 0, false
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, given either in
+// seconds or as an HTTP date.
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// retryAfterError is the error of a response asking to wait before retrying.
+type retryAfterError struct {
+	error
+	delay time.Duration
+}
+
+// RetryAfter returns the delay of the Retry-After header of the response.
+func (e *retryAfterError) RetryAfter() time.Duration { return e.delay }
+
+func (e *retryAfterError) Unwrap() error { return e.error }
+
+// WithRetryAfter attaches the Retry-After header of a response to the error, to be
+// returned by RetryAfter. The error is returned as is when the header is missing or
+// invalid.
+func WithRetryAfter(err error, header http.Header) error {
+	if delay, ok := ParseRetryAfter(header.Get("Retry-After")); ok {
+		return &retryAfterError{error: err, delay: delay}
+	}
+	return err
+}
+
 // Common error variables for easy comparison.
 var (
 	// ErrAuthentication is returned when authentication fails.
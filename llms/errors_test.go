@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/vxcontrol/langchaingo/llms"
 )
@@ -292,6 +294,50 @@ func TestCustomMatcher(t *testing.T) {
 	}
 }
 
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	detailed := llms.NewError(llms.ErrCodeRateLimit, "test", "slow down").WithDetail("retry_after", 2*time.Second)
+	if delay, ok := llms.RetryAfter(fmt.Errorf("call: %w", detailed)); !ok || delay != 2*time.Second {
+		t.Errorf("RetryAfter(detail) = %v, %v, want 2s, true", delay, ok)
+	}
+	if delay, ok := llms.RetryAfter(fmt.Errorf("call: %w", hintedError{3 * time.Second})); !ok || delay != 3*time.Second {
+		t.Errorf("RetryAfter(hinted) = %v, %v, want 3s, true", delay, ok)
+	}
+	if _, ok := llms.RetryAfter(errors.New("plain")); ok {
+		t.Error("RetryAfter(plain) should not report a delay")
+	}
+
+	if delay, ok := llms.ParseRetryAfter("7"); !ok || delay != 7*time.Second {
+		t.Errorf("ParseRetryAfter(seconds) = %v, %v, want 7s, true", delay, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if delay, ok := llms.ParseRetryAfter(date); !ok || delay <= 0 || delay > time.Minute {
+		t.Errorf("ParseRetryAfter(date) = %v, %v, want within a minute", delay, ok)
+	}
+	if _, ok := llms.ParseRetryAfter("soon"); ok {
+		t.Error("ParseRetryAfter(soon) should fail")
+	}
+
+	cause := errors.New("rate limited")
+	wrapped := llms.WithRetryAfter(cause, http.Header{"Retry-After": []string{"4"}})
+	if delay, ok := llms.RetryAfter(wrapped); !ok || delay != 4*time.Second {
+		t.Errorf("RetryAfter(WithRetryAfter) = %v, %v, want 4s, true", delay, ok)
+	}
+	if !errors.Is(wrapped, cause) || wrapped.Error() != cause.Error() {
+		t.Errorf("WithRetryAfter(cause) = %v, want the cause wrapped", wrapped)
+	}
+	if llms.WithRetryAfter(cause, http.Header{}) != cause {
+		t.Error("WithRetryAfter without header should return the error as is")
+	}
+}
+
+// hintedError is a mock error carrying a retry delay.
+type hintedError struct{ delay time.Duration }
+
+func (e hintedError) Error() string             { return "hinted" }
+func (e hintedError) RetryAfter() time.Duration { return e.delay }
+
 // timeoutError is a mock network timeout error.
 type timeoutError struct{}
 
@@ -3,6 +3,7 @@ package fake
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/vxcontrol/langchaingo/llms"
 )
@@ -20,7 +21,7 @@ func NewFakeLLM(responses []string) *LLM {
 }
 
 // GenerateContent generate fake content.
-func (f *LLM) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
+func (f *LLM) GenerateContent(_ context.Context, messages []llms.MessageContent, _ ...llms.CallOption) (*llms.ContentResponse, error) {
 	if len(f.responses) == 0 {
 		return nil, errors.New("no responses configured")
 	}
@@ -31,9 +32,34 @@ func (f *LLM) GenerateContent(_ context.Context, _ []llms.MessageContent, _ ...l
 	f.index++
 	return &llms.ContentResponse{
 		Choices: []*llms.ContentChoice{{Content: response}},
+		Usage: &llms.Usage{
+			PromptTokens:     countTokens(messagesText(messages)),
+			CompletionTokens: countTokens(response),
+		},
 	}, nil
 }
 
+// countTokens estimates the number of tokens of the text, at least one for
+// a non-empty text.
+func countTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return max(llms.CountTokens("", text), 1)
+}
+
+func messagesText(messages []llms.MessageContent) string {
+	var sb strings.Builder
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				sb.WriteString(text.Text)
+			}
+		}
+	}
+	return sb.String()
+}
+
 // Call  the model with a prompt.
 func (f *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
 	resp, err := f.GenerateContent(ctx, []llms.MessageContent{{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{llms.TextContent{Text: prompt}}}}, options...)
@@ -52,6 +52,9 @@ func TestFakeLLM_GenerateContentMethod(t *testing.T) {
 	if len(resp.Choices) < 1 || resp.Choices[0].Content != responses[0] {
 		t.Errorf("Expected 'Resposta 1', got '%s'", resp.Choices[0].Content)
 	}
+	if resp.Usage == nil || resp.Usage.PromptTokens < 1 || resp.Usage.CompletionTokens < 1 {
+		t.Errorf("Expected estimated usage, got %v", resp.Usage)
+	}
 
 	resp, err = fakeLLM.GenerateContent(ctx, []llms.MessageContent{msg})
 	if err != nil {
@@ -122,6 +122,45 @@ func (ToolCallResponse) isPart() {}
 // It can potentially return multiple content choices.
 type ContentResponse struct {
 	Choices []*ContentChoice
+
+	// Model is the model that generated the response as reported by the provider,
+	// empty if the provider does not report it.
+	Model string
+
+	// Usage is the token usage of the call, nil if the provider does not report it.
+	Usage *Usage
+}
+
+// Usage is the token usage of a GenerateContent call, normalized across providers.
+// The prompt tokens include the cached tokens and the completion tokens include the
+// reasoning tokens.
+type Usage struct {
+	// PromptTokens is the number of tokens of the input.
+	PromptTokens int `json:"prompt_tokens"`
+	// CompletionTokens is the number of generated tokens.
+	CompletionTokens int `json:"completion_tokens"`
+	// ReasoningTokens is the number of generated tokens used for reasoning.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+	// CacheReadTokens is the number of prompt tokens read from the cache of the provider.
+	CacheReadTokens int `json:"cache_read_tokens,omitempty"`
+	// CacheWriteTokens is the number of prompt tokens written to the cache of the provider.
+	CacheWriteTokens int `json:"cache_write_tokens,omitempty"`
+}
+
+// TotalTokens returns the number of prompt and completion tokens.
+func (u Usage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add returns the sum of the usages.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		ReasoningTokens:  u.ReasoningTokens + other.ReasoningTokens,
+		CacheReadTokens:  u.CacheReadTokens + other.CacheReadTokens,
+		CacheWriteTokens: u.CacheWriteTokens + other.CacheWriteTokens,
+	}
 }
 
 // ContentChoice is one of the response choices returned by GenerateContent
@@ -34,3 +34,16 @@ func TestTextParts(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageAdd(t *testing.T) {
+	t.Parallel()
+	a := Usage{PromptTokens: 10, CompletionTokens: 5, ReasoningTokens: 2, CacheReadTokens: 4}
+	b := Usage{PromptTokens: 3, CompletionTokens: 7, CacheWriteTokens: 1}
+	want := Usage{PromptTokens: 13, CompletionTokens: 12, ReasoningTokens: 2, CacheReadTokens: 4, CacheWriteTokens: 1}
+	if got := a.Add(b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Add() = %v, want %v", got, want)
+	}
+	if got := want.TotalTokens(); got != 25 {
+		t.Errorf("TotalTokens() = %v, want %v", got, 25)
+	}
+}
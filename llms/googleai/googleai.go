@@ -255,6 +255,8 @@ func (g *GoogleAI) generateStreamingContent(
 
 	var accumulatedContent strings.Builder
 	var accumulatedToolCalls []llms.ToolCall
+	var usage *llms.Usage
+	var modelVersion string
 
 	// Trying to keep the same ID for the same tool call name
 	toolCallIDs := make(map[string]string)
@@ -267,6 +269,12 @@ func (g *GoogleAI) generateStreamingContent(
 	}
 
 	for chunk := range iter {
+		if chunk.UsageMetadata != nil {
+			usage = convertUsage(chunk.UsageMetadata)
+		}
+		if chunk.ModelVersion != "" {
+			modelVersion = chunk.ModelVersion
+		}
 		if len(chunk.Candidates) == 0 {
 			continue
 		}
@@ -314,6 +322,8 @@ StreamEnd:
 			Content:   accumulatedContent.String(),
 			ToolCalls: accumulatedToolCalls,
 		}},
+		Model: modelVersion,
+		Usage: usage,
 	}, nil
 }
 
@@ -379,7 +389,22 @@ func convertResponse(resp *genai.GenerateContentResponse) (*llms.ContentResponse
 		})
 	}
 
-	return &llms.ContentResponse{Choices: choices}, nil
+	response := &llms.ContentResponse{Choices: choices, Model: resp.ModelVersion}
+	if resp.UsageMetadata != nil {
+		response.Usage = convertUsage(resp.UsageMetadata)
+	}
+	return response, nil
+}
+
+// convertUsage converts the usage metadata of a response. Gemini counts the
+// thoughts apart from the candidates.
+func convertUsage(metadata *genai.GenerateContentResponseUsageMetadata) *llms.Usage {
+	return &llms.Usage{
+		PromptTokens:     int(metadata.PromptTokenCount),
+		CompletionTokens: int(metadata.CandidatesTokenCount + metadata.ThoughtsTokenCount),
+		ReasoningTokens:  int(metadata.ThoughtsTokenCount),
+		CacheReadTokens:  int(metadata.CachedContentTokenCount),
+	}
 }
 
 func convertParts(parts []llms.ContentPart) ([]*genai.Part, error) {
@@ -240,6 +240,13 @@ func TestConvertResponse(t *testing.T) { //nolint:funlen
 						FinishReason: genai.FinishReasonStop,
 					},
 				},
+				UsageMetadata: &genai.GenerateContentResponseUsageMetadata{
+					PromptTokenCount:        20,
+					CachedContentTokenCount: 8,
+					CandidatesTokenCount:    5,
+					ThoughtsTokenCount:      12,
+					TotalTokenCount:         37,
+				},
 			},
 			wantErr: false,
 		},
@@ -291,6 +298,16 @@ func TestConvertResponse(t *testing.T) { //nolint:funlen
 				assert.Contains(t, choice.GenerationInfo, "input_tokens")
 				assert.Contains(t, choice.GenerationInfo, "output_tokens")
 				assert.Contains(t, choice.GenerationInfo, "total_tokens")
+
+				usage := tt.response.UsageMetadata
+				assert.Equal(t, &llms.Usage{
+					PromptTokens:     int(usage.PromptTokenCount),
+					CompletionTokens: int(usage.CandidatesTokenCount + usage.ThoughtsTokenCount),
+					ReasoningTokens:  int(usage.ThoughtsTokenCount),
+					CacheReadTokens:  int(usage.CachedContentTokenCount),
+				}, result.Usage)
+			} else {
+				assert.Nil(t, result.Usage)
 			}
 
 			// Check for thinking content in metadata
@@ -178,6 +178,12 @@ func convertCandidates(candidates []*genai.Candidate, usage *genai.UsageMetadata
 				ToolCalls:      toolCalls,
 			})
 	}
+	if usage != nil {
+		contentResponse.Usage = &llms.Usage{
+			PromptTokens:     int(usage.PromptTokenCount),
+			CompletionTokens: int(usage.CandidatesTokenCount),
+		}
+	}
 	return &contentResponse, nil
 }
 
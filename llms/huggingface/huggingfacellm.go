@@ -71,6 +71,12 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 			},
 		},
 	}
+	if result.PromptTokens > 0 || result.CompletionTokens > 0 {
+		resp.Usage = &llms.Usage{
+			PromptTokens:     result.PromptTokens,
+			CompletionTokens: result.CompletionTokens,
+		}
+	}
 	return resp, nil
 }
 
@@ -73,6 +73,9 @@ type InferenceRequest struct {
 
 type InferenceResponse struct {
 	Text string `json:"generated_text"`
+	// PromptTokens and CompletionTokens are only reported by the router providers.
+	PromptTokens     int `json:"prompt_tokens,omitempty"`
+	CompletionTokens int `json:"completion_tokens,omitempty"`
 }
 
 func (c *Client) RunInference(ctx context.Context, request *InferenceRequest) (*InferenceResponse, error) {
@@ -100,7 +103,9 @@ func (c *Client) RunInference(ctx context.Context, request *InferenceRequest) (*
 	// TODO: Add response cleaning based on Model.
 	// e.g., for gpt2, text = text[len(request.Prompt)+1:]
 	return &InferenceResponse{
-		Text: text,
+		Text:             text,
+		PromptTokens:     resp[0].PromptTokens,
+		CompletionTokens: resp[0].CompletionTokens,
 	}, nil
 }
 
@@ -58,12 +58,19 @@ type chatCompletionsResponse struct {
 		} `json:"message"`
 		Index int `json:"index"`
 	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
 }
 
 type (
 	inferenceResponsePayload []inferenceResponse
 	inferenceResponse        struct {
 		Text string `json:"generated_text"`
+		// Only the chat completions API reports the usage.
+		PromptTokens     int `json:"-"`
+		CompletionTokens int `json:"-"`
 	}
 )
 
@@ -206,7 +213,9 @@ func (c *Client) runChatCompletions(ctx context.Context, payload *inferencePaylo
 	// Convert to the expected response format
 	response := make(inferenceResponsePayload, 1)
 	response[0] = inferenceResponse{
-		Text: chatResponse.Choices[0].Message.Content,
+		Text:             chatResponse.Choices[0].Message.Content,
+		PromptTokens:     chatResponse.Usage.PromptTokens,
+		CompletionTokens: chatResponse.Usage.CompletionTokens,
 	}
 
 	return response, nil
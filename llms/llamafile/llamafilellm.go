@@ -98,6 +98,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 	req = makeLlamaOptionsFromOptions(req, opts)
 
 	streamedResponse := ""
+	var usage *llms.Usage
 	fn := func(response llamafileclient.ChatResponse) error {
 		if opts.StreamingFunc != nil && response.Content != "" {
 			if err := streaming.CallWithText(ctx, opts.StreamingFunc, response.Content); err != nil {
@@ -107,6 +108,12 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 		if response.Content != "" {
 			streamedResponse += response.Content
 		}
+		if response.Stop {
+			usage = &llms.Usage{
+				PromptTokens:     response.TokensEvaluated,
+				CompletionTokens: response.TokensPredicted,
+			}
+		}
 
 		return nil
 	}
@@ -126,6 +133,7 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 				Content: streamedResponse,
 			},
 		},
+		Usage: usage,
 	}, nil
 }
 
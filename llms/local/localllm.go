@@ -20,7 +20,7 @@ var (
 	ErrMissingBin = errors.New("missing the local LLM binary path, set the LOCAL_LLM_BIN environment variable")
 )
 
-// LLM is a local LLM implementation.
+// LLM is a local LLM implementation. Its responses have no token usage.
 type LLM struct {
 	CallbacksHandler callbacks.Handler
 	client           *localclient.Client
@@ -57,7 +57,8 @@ func (o *LLM) appendGlobalsToArgs(opts llms.CallOptions) {
 	}
 }
 
-// GenerateContent implements the Model interface.
+// GenerateContent implements the Model interface. The local binary reports no token
+// usage, so the Usage of the response is nil.
 func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) { //nolint: lll, cyclop, whitespace
 
 	if o.CallbacksHandler != nil {
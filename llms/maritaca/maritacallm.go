@@ -148,7 +148,13 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 	choices := createChoice(resp)
 
-	response := &llms.ContentResponse{Choices: choices}
+	response := &llms.ContentResponse{
+		Choices: choices,
+		Usage: &llms.Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+		},
+	}
 
 	if o.CallbacksHandler != nil {
 		o.CallbacksHandler.HandleLLMGenerateContentEnd(ctx, response)
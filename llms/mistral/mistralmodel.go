@@ -160,6 +160,8 @@ func generateNonStreamingContent(ctx context.Context, m *Model, callOptions *llm
 
 	langchainContentResponse := &llms.ContentResponse{
 		Choices: make([]*llms.ContentChoice, 0),
+		Model:   res.Model,
+		Usage:   convertUsage(res.Usage),
 	}
 	for idx, choice := range res.Choices {
 		langchainContentResponse.Choices = append(langchainContentResponse.Choices, &llms.ContentChoice{
@@ -210,7 +212,11 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 		chunkStr := ""
 		langchainContentResponse.Choices[0].GenerationInfo["created"] = chatResChunk.Created
 		langchainContentResponse.Choices[0].GenerationInfo["model"] = chatResChunk.Model
+		langchainContentResponse.Model = chatResChunk.Model
 		langchainContentResponse.Choices[0].GenerationInfo["usage"] = chatResChunk.Usage
+		if chatResChunk.Usage.TotalTokens > 0 {
+			langchainContentResponse.Usage = convertUsage(chatResChunk.Usage)
+		}
 		if chatResChunk.Error == nil {
 			for _, choice := range chatResChunk.Choices {
 				chunkStr += choice.Delta.Content
@@ -241,6 +247,14 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 	return langchainContentResponse, nil
 }
 
+// convertUsage converts the usage reported by Mistral.
+func convertUsage(usage sdk.UsageInfo) *llms.Usage {
+	return &llms.Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+	}
+}
+
 func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sdk.ChatMessage, error) {
 	messages := make([]sdk.ChatMessage, 0)
 	for _, msg := range langchainMessages {
@@ -328,7 +328,14 @@ func (o *LLM) createContentResponse(resp api.ChatResponse) *llms.ContentResponse
 		})
 	}
 
-	return &llms.ContentResponse{Choices: choices}
+	return &llms.ContentResponse{
+		Choices: choices,
+		Model:   resp.Model,
+		Usage: &llms.Usage{
+			PromptTokens:     resp.PromptEvalCount,
+			CompletionTokens: resp.EvalCount,
+		},
+	}
 }
 
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float32, error) {
@@ -334,6 +334,9 @@ type ChatUsage struct {
 	CompletionTokensDetails struct {
 		ReasoningTokens int `json:"reasoning_tokens"`
 	} `json:"completion_tokens_details"`
+	PromptTokensDetails struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"prompt_tokens_details"`
 }
 
 // ChatCompletionResponse is a response to a chat request.
@@ -354,6 +357,9 @@ type Usage struct {
 	CompletionTokensDetails struct {
 		ReasoningTokens int `json:"reasoning_tokens"`
 	} `json:"completion_tokens_details"`
+	PromptTokensDetails struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"prompt_tokens_details"`
 }
 
 // StreamedToolCall is a call to a tool.
@@ -467,10 +473,10 @@ func (c *Client) createChat(ctx context.Context, payload *ChatRequest) (*ChatCom
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg)
+			return nil, llms.WithRetryAfter(errors.New(msg), r.Header)
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+		return nil, llms.WithRetryAfter(fmt.Errorf("%s: %s", msg, errResp.Error.Message), r.Header)
 	}
 	if payload.Stream {
 		return parseStreamingChatResponse(ctx, r, payload)
@@ -572,6 +578,9 @@ func combineStreamingChatResponse(
 		}
 
 		updateChatUsage(&response.Usage, streamResponse.Usage)
+		if streamResponse.Model != "" {
+			response.Model = streamResponse.Model
+		}
 
 		if len(streamResponse.Choices) == 0 {
 			continue
@@ -660,6 +669,7 @@ func updateChatUsage(chatUsage *ChatUsage, streamUsage *Usage) {
 	chatUsage.PromptTokens = streamUsage.PromptTokens
 	chatUsage.TotalTokens = streamUsage.TotalTokens
 	chatUsage.CompletionTokensDetails.ReasoningTokens = streamUsage.CompletionTokensDetails.ReasoningTokens
+	chatUsage.PromptTokensDetails.CachedTokens = streamUsage.PromptTokensDetails.CachedTokens
 }
 
 func updateFunctionCall(message *ChatMessage, functionCall *FunctionCall) {
@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vxcontrol/langchaingo/llms"
 	"github.com/vxcontrol/langchaingo/llms/streaming"
 )
 
@@ -140,3 +143,31 @@ func TestChatMessage_MarshalUnmarshal_WithReasoning(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, msg, msg2)
 }
+
+type responseDoer struct {
+	resp *http.Response
+}
+
+func (d responseDoer) Do(*http.Request) (*http.Response, error) {
+	return d.resp, nil
+}
+
+func TestCreateChat_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	doer := responseDoer{resp: &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"3"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Rate limit reached"}}`)),
+	}}
+	client, err := New("token", "gpt-4.1-mini", "", "", APITypeOpenAI, "", doer, "", nil, false, false)
+	require.NoError(t, err)
+
+	_, err = client.CreateChat(t.Context(), &ChatRequest{
+		Messages: []*ChatMessage{{Role: "user", Content: "hi"}},
+	})
+	require.ErrorContains(t, err, "Rate limit reached")
+	delay, ok := llms.RetryAfter(err)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/vxcontrol/langchaingo/llms"
 )
 
 const (
@@ -68,10 +70,10 @@ func (c *Client) createEmbedding(ctx context.Context, payload *embeddingPayload)
 		// status code.
 		var errResp errorMessage
 		if err := json.NewDecoder(r.Body).Decode(&errResp); err != nil {
-			return nil, errors.New(msg)
+			return nil, llms.WithRetryAfter(errors.New(msg), r.Header)
 		}
 
-		return nil, fmt.Errorf("%s: %s", msg, errResp.Error.Message)
+		return nil, llms.WithRetryAfter(fmt.Errorf("%s: %s", msg, errResp.Error.Message), r.Header)
 	}
 
 	var response embeddingResponsePayload
@@ -323,7 +323,16 @@ func (o *LLM) processResponse(result *openaiclient.ChatCompletionResponse) *llms
 		o.processToolCalls(choices[i], c)
 	}
 
-	return &llms.ContentResponse{Choices: choices}
+	return &llms.ContentResponse{
+		Choices: choices,
+		Model:   result.Model,
+		Usage: &llms.Usage{
+			PromptTokens:     result.Usage.PromptTokens,
+			CompletionTokens: result.Usage.CompletionTokens,
+			ReasoningTokens:  result.Usage.CompletionTokensDetails.ReasoningTokens,
+			CacheReadTokens:  result.Usage.PromptTokensDetails.CachedTokens,
+		},
+	}
 }
 
 // processToolCalls processes tool calls in the response.
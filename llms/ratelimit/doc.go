@@ -0,0 +1,19 @@
+// Package ratelimit provides client-side rate limiting for `llms.Model` and
+// `embeddings.Embedder`, so that batch jobs stay within the quotas of a provider
+// instead of failing with rate limit errors.
+//
+// A Limiter enforces requests per minute, tokens per minute and a maximum number of
+// requests in flight. It is safe for concurrent use and is meant to be shared by all
+// the models and embedders drawing on the same quota:
+//
+//	limiter := ratelimit.NewLimiter(
+//	    ratelimit.WithRequestsPerMinute(500),
+//	    ratelimit.WithTokensPerMinute(200_000),
+//	    ratelimit.WithMaxInFlight(8),
+//	)
+//	llm := ratelimit.NewModel(openaiLLM, limiter)
+//	embedder, err := embeddings.NewEmbedder(ratelimit.NewEmbedderClient(openaiLLM, limiter))
+//
+// The tokens of a request are estimated with llms.CountTokens before it is sent.
+// The usage reported in the response of a model then corrects the estimate.
+package ratelimit
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/vxcontrol/langchaingo/embeddings"
+	"github.com/vxcontrol/langchaingo/llms"
+)
+
+// Embedder is an embedder wrapper that waits for the limits of its limiter before
+// each call. A call of EmbedDocuments counts as a single request: to limit each
+// batch of an embeddings.EmbedderImpl, wrap its client with NewEmbedderClient instead.
+type Embedder struct {
+	embedder embeddings.Embedder
+	limiter  *Limiter
+}
+
+// assert that `Embedder` implements the `embeddings.Embedder` interface.
+var _ embeddings.Embedder = (*Embedder)(nil)
+
+// NewEmbedder wraps an Embedder and rate limits its calls.
+func NewEmbedder(embedder embeddings.Embedder, limiter *Limiter) *Embedder {
+	return &Embedder{
+		embedder: embedder,
+		limiter:  limiter,
+	}
+}
+
+// EmbedDocuments waits until the call is allowed by the limits, then returns a
+// vector for each text.
+func (e *Embedder) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
+	release, err := e.limiter.Wait(ctx, countTokens(texts...))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return e.embedder.EmbedDocuments(ctx, texts)
+}
+
+// EmbedQuery waits until the call is allowed by the limits, then embeds a single text.
+func (e *Embedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	release, err := e.limiter.Wait(ctx, countTokens(text))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return e.embedder.EmbedQuery(ctx, text)
+}
+
+// EmbedderClient is an embedder client wrapper that waits for the limits of its
+// limiter before each call.
+type EmbedderClient struct {
+	client  embeddings.EmbedderClient
+	limiter *Limiter
+}
+
+// assert that `EmbedderClient` implements the `embeddings.EmbedderClient` interface.
+var _ embeddings.EmbedderClient = (*EmbedderClient)(nil)
+
+// NewEmbedderClient wraps an EmbedderClient and rate limits its calls.
+func NewEmbedderClient(client embeddings.EmbedderClient, limiter *Limiter) *EmbedderClient {
+	return &EmbedderClient{
+		client:  client,
+		limiter: limiter,
+	}
+}
+
+// CreateEmbedding waits until the call is allowed by the limits, then creates
+// the embeddings of the texts.
+func (c *EmbedderClient) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	release, err := c.limiter.Wait(ctx, countTokens(texts...))
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.client.CreateEmbedding(ctx, texts)
+}
+
+func countTokens(texts ...string) int {
+	var tokens int
+	for _, text := range texts {
+		tokens += max(llms.CountTokens("", text), 1)
+	}
+	return tokens
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// Limiter enforces the limits of a provider quota on the requests sharing it.
+type Limiter struct {
+	requests *rate.Limiter
+	tokens   *rate.Limiter
+	inFlight *semaphore.Weighted
+}
+
+// Option is a function that configures a Limiter.
+type Option func(*Limiter)
+
+// WithRequestsPerMinute limits the number of requests started per minute.
+func WithRequestsPerMinute(requests int) Option {
+	return func(l *Limiter) {
+		l.requests = perMinute(requests)
+	}
+}
+
+// WithTokensPerMinute limits the number of tokens of the requests per minute.
+func WithTokensPerMinute(tokens int) Option {
+	return func(l *Limiter) {
+		l.tokens = perMinute(tokens)
+	}
+}
+
+// WithMaxInFlight limits the number of requests running at the same time.
+func WithMaxInFlight(requests int) Option {
+	return func(l *Limiter) {
+		if requests > 0 {
+			l.inFlight = semaphore.NewWeighted(int64(requests))
+		}
+	}
+}
+
+// NewLimiter creates a limiter. A limit that is not set is not enforced.
+func NewLimiter(opts ...Option) *Limiter {
+	l := &Limiter{}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// perMinute returns a token bucket holding a minute of events, refilled at the
+// rate of the limit.
+func perMinute(limit int) *rate.Limiter {
+	if limit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(float64(limit)/time.Minute.Seconds()), limit)
+}
+
+// Wait blocks until a request of the estimated number of tokens is allowed by the
+// limits, or the context is done. On success, the returned function must be called
+// once the request is over to free its in-flight slot.
+func (l *Limiter) Wait(ctx context.Context, tokens int) (func(), error) {
+	if l.inFlight != nil {
+		if err := l.inFlight.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			l.inFlight.Release(1)
+		}
+	}
+
+	if l.requests != nil {
+		if err := l.requests.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	if l.tokens != nil && tokens > 0 {
+		// a request larger than the quota waits for the whole quota.
+		if err := l.tokens.WaitN(ctx, min(tokens, l.tokens.Burst())); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// Record charges the tokens a request used beyond its estimate, delaying the
+// following requests.
+func (l *Limiter) Record(extraTokens int) {
+	if l.tokens == nil || extraTokens <= 0 {
+		return
+	}
+	l.tokens.ReserveN(time.Now(), min(extraTokens, l.tokens.Burst()))
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/llms"
+)
+
+// Model is an LLM wrapper that waits for the limits of its limiter before each call.
+type Model struct {
+	llm     llms.Model
+	limiter *Limiter
+}
+
+// assert that `Model` implements the `llms.Model` interface.
+var _ llms.Model = (*Model)(nil)
+
+// NewModel wraps a Model and rate limits its calls.
+func NewModel(llm llms.Model, limiter *Limiter) *Model {
+	return &Model{
+		llm:     llm,
+		limiter: limiter,
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (m *Model) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// GenerateContent waits until the call is allowed by the limits, then asks the
+// model to generate content from a sequence of messages. The tokens of the call
+// are estimated as the tokens of the text of the messages plus the maximum number
+// of tokens to generate, and corrected with the usage of the response.
+func (m *Model) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	estimate := max(llms.CountTokens(opts.Model, messagesText(messages)), 1) + opts.MaxTokens
+	release, err := m.limiter.Wait(ctx, estimate)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := m.llm.GenerateContent(ctx, messages, options...)
+	if resp != nil && resp.Usage != nil {
+		m.limiter.Record(resp.Usage.TotalTokens() - estimate)
+	}
+	return resp, err
+}
+
+func messagesText(messages []llms.MessageContent) string {
+	var sb strings.Builder
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				sb.WriteString(text.Text)
+			}
+		}
+	}
+	return sb.String()
+}
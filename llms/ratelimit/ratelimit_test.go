@@ -0,0 +1,167 @@
+package ratelimit_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/embeddings"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type usageLLM struct {
+	usage   *llms.Usage
+	delay   time.Duration
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func (m *usageLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *usageLLM) GenerateContent(context.Context, []llms.MessageContent, ...llms.CallOption) (*llms.ContentResponse, error) {
+	running := m.running.Add(1)
+	defer m.running.Add(-1)
+	for {
+		highest := m.peak.Load()
+		if running <= highest || m.peak.CompareAndSwap(highest, running) {
+			break
+		}
+	}
+	time.Sleep(m.delay)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}, Usage: m.usage}, nil
+}
+
+// shortContext returns a context expiring before a refill of the limits used in the tests.
+func shortContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestLimiterRequestsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.NewLimiter(ratelimit.WithRequestsPerMinute(2))
+	for range 2 {
+		release, err := limiter.Wait(t.Context(), 0)
+		require.NoError(t, err)
+		release()
+	}
+	_, err := limiter.Wait(shortContext(t), 0)
+	require.Error(t, err)
+}
+
+func TestLimiterTokensPerMinute(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.NewLimiter(ratelimit.WithTokensPerMinute(100))
+	release, err := limiter.Wait(t.Context(), 100)
+	require.NoError(t, err)
+	release()
+	_, err = limiter.Wait(shortContext(t), 10)
+	require.Error(t, err)
+
+	// a request larger than the quota waits for the whole quota
+	limiter = ratelimit.NewLimiter(ratelimit.WithTokensPerMinute(100))
+	release, err = limiter.Wait(shortContext(t), 1000)
+	require.NoError(t, err)
+	release()
+}
+
+func TestLimiterUnlimited(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.NewLimiter()
+	for range 100 {
+		release, err := limiter.Wait(t.Context(), 1_000_000)
+		require.NoError(t, err)
+		release()
+	}
+}
+
+func TestModelMaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	llm := &usageLLM{delay: 20 * time.Millisecond}
+	model := ratelimit.NewModel(llm, ratelimit.NewLimiter(ratelimit.WithMaxInFlight(2)))
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := llms.GenerateFromSinglePrompt(t.Context(), model, "hi")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), llm.peak.Load())
+
+	// the slots are freed
+	limiter := ratelimit.NewLimiter(ratelimit.WithMaxInFlight(1))
+	release, err := limiter.Wait(t.Context(), 0)
+	require.NoError(t, err)
+	_, err = limiter.Wait(shortContext(t), 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+	release, err = limiter.Wait(shortContext(t), 0)
+	require.NoError(t, err)
+	release()
+}
+
+func TestModelRecordsUsage(t *testing.T) {
+	t.Parallel()
+
+	// the response used the whole quota, far beyond the estimate of the prompt
+	llm := &usageLLM{usage: &llms.Usage{PromptTokens: 900, CompletionTokens: 100}}
+	model := ratelimit.NewModel(llm, ratelimit.NewLimiter(ratelimit.WithTokensPerMinute(1000)))
+
+	_, err := llms.GenerateFromSinglePrompt(t.Context(), model, "hi")
+	require.NoError(t, err)
+	_, err = llms.GenerateFromSinglePrompt(shortContext(t), model, "hi")
+	require.Error(t, err)
+}
+
+func TestEmbedderClientLimitsBatches(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := embeddings.EmbedderClientFunc(func(_ context.Context, texts []string) ([][]float32, error) {
+		calls.Add(1)
+		return make([][]float32, len(texts)), nil
+	})
+	limiter := ratelimit.NewLimiter(ratelimit.WithRequestsPerMinute(2))
+	embedder, err := embeddings.NewEmbedder(ratelimit.NewEmbedderClient(client, limiter), embeddings.WithBatchSize(1))
+	require.NoError(t, err)
+
+	_, err = embedder.EmbedDocuments(t.Context(), []string{"a", "b"})
+	require.NoError(t, err)
+	_, err = embedder.EmbedDocuments(shortContext(t), []string{"c"})
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestEmbedder(t *testing.T) {
+	t.Parallel()
+
+	inner, err := embeddings.NewEmbedder(embeddings.EmbedderClientFunc(func(_ context.Context, texts []string) ([][]float32, error) {
+		return make([][]float32, len(texts)), nil
+	}))
+	require.NoError(t, err)
+	embedder := ratelimit.NewEmbedder(inner, ratelimit.NewLimiter(ratelimit.WithRequestsPerMinute(1)))
+
+	vectors, err := embedder.EmbedDocuments(t.Context(), []string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.Len(t, vectors, 3)
+	_, err = embedder.EmbedQuery(shortContext(t), "d")
+	require.Error(t, err)
+}
@@ -0,0 +1,5 @@
+// Package retry provides a generic wrapper that retries the calls of a `llms.Model`
+// failing with retryable error codes, such as rate limits and unavailable providers.
+// Retries are spaced by an exponential backoff with jitter, or by the delay hinted by
+// the provider, and a call is never retried once its output started streaming.
+package retry
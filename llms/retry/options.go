@@ -0,0 +1,99 @@
+package retry
+
+import (
+	"time"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+)
+
+const (
+	_defaultMaxAttempts  = 3
+	_defaultInitialDelay = time.Second
+	_defaultMaxDelay     = 30 * time.Second
+	_defaultMultiplier   = 2.0
+	_defaultJitter       = 0.2
+)
+
+// Option is a function that configures a Retrier.
+type Option func(*options)
+
+type options struct {
+	maxAttempts      int
+	initialDelay     time.Duration
+	maxDelay         time.Duration
+	multiplier       float64
+	jitter           float64
+	retryableCodes   []llms.ErrorCode
+	errorMapper      func(error) error
+	callbacksHandler callbacks.Handler
+}
+
+func defaultOptions() options {
+	return options{
+		maxAttempts:  _defaultMaxAttempts,
+		initialDelay: _defaultInitialDelay,
+		maxDelay:     _defaultMaxDelay,
+		multiplier:   _defaultMultiplier,
+		jitter:       _defaultJitter,
+		retryableCodes: []llms.ErrorCode{
+			llms.ErrCodeRateLimit,
+			llms.ErrCodeProviderUnavailable,
+			llms.ErrCodeTimeout,
+		},
+		errorMapper: llms.NewErrorMapper("").WrapError,
+	}
+}
+
+// WithMaxAttempts sets the maximum number of attempts of a call, the first one
+// included. Default is 3.
+func WithMaxAttempts(maxAttempts int) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+	}
+}
+
+// WithBackoff sets the delay before the first retry, the maximum delay between two
+// attempts, and the factor the delay is multiplied by after each retry. Default is
+// 1s, 30s and 2. The maximum delay caps the delays hinted by the providers too.
+func WithBackoff(initialDelay, maxDelay time.Duration, multiplier float64) Option {
+	return func(o *options) {
+		o.initialDelay = initialDelay
+		o.maxDelay = maxDelay
+		o.multiplier = multiplier
+	}
+}
+
+// WithJitter sets the fraction of the delays that is randomized, between 0 and 1.
+// Default is 0.2.
+func WithJitter(jitter float64) Option {
+	return func(o *options) {
+		o.jitter = min(max(jitter, 0), 1)
+	}
+}
+
+// WithRetryableCodes sets the error codes of the calls to retry. Default is rate
+// limit, provider unavailable and timeout.
+func WithRetryableCodes(codes ...llms.ErrorCode) Option {
+	return func(o *options) {
+		o.retryableCodes = codes
+	}
+}
+
+// WithErrorMapper sets the function mapping the errors of the model that are not an
+// *llms.Error, typically the MapError function of the provider package. Default is
+// the generic llms.ErrorMapper.
+func WithErrorMapper(mapper func(error) error) Option {
+	return func(o *options) {
+		o.errorMapper = mapper
+	}
+}
+
+// WithCallbacksHandler sets the handler reporting the retries. The failed attempts
+// are reported to HandleLLMRetry if the handler implements callbacks.RetryHandler,
+// to HandleLLMError otherwise.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(o *options) {
+		o.callbacksHandler = handler
+	}
+}
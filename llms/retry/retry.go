@@ -0,0 +1,140 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
+)
+
+// Retrier is an LLM wrapper that retries the calls failing with retryable error codes.
+type Retrier struct {
+	llm  llms.Model
+	opts options
+}
+
+// assert that `Retrier` implements the `llms.Model` interface.
+var _ llms.Model = (*Retrier)(nil)
+
+// New wraps a Model and retries its failed calls.
+func New(llm llms.Model, opts ...Option) *Retrier {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Retrier{
+		llm:  llm,
+		opts: o,
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Retrier) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent asks the model to generate content from a sequence of
+// messages, retrying the attempts failing with a retryable error code until the
+// maximum number of attempts. An attempt that started streaming its output is
+// not retried.
+func (r *Retrier) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var s *streaming.Relay
+	if opts.StreamingFunc != nil {
+		s = streaming.NewRelay(opts.StreamingFunc)
+		options = append(slices.Clip(options), llms.WithStreamingFunc(s.Forward))
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := r.llm.GenerateContent(ctx, messages, options...)
+		if err == nil || !r.shouldRetry(ctx, attempt, s, err) {
+			return resp, endStream(ctx, s, err)
+		}
+
+		delay := r.delay(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, endStream(ctx, s, err)
+		}
+		r.handleRetry(ctx, attempt, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, endStream(ctx, s, errors.Join(err, ctx.Err()))
+		case <-timer.C:
+		}
+		if s != nil {
+			s.Reset()
+		}
+	}
+}
+
+func (r *Retrier) shouldRetry(ctx context.Context, attempt int, s *streaming.Relay, err error) bool {
+	if attempt >= r.opts.maxAttempts || ctx.Err() != nil || (s != nil && s.Started()) {
+		return false
+	}
+	var e *llms.Error
+	if !errors.As(err, &e) && r.opts.errorMapper != nil {
+		_ = errors.As(r.opts.errorMapper(err), &e)
+	}
+	return e != nil && slices.Contains(r.opts.retryableCodes, e.Code)
+}
+
+// delay returns the delay before the retry following the attempt: the delay hinted
+// by the provider, or the exponential backoff with jitter, both capped by the
+// maximum delay.
+func (r *Retrier) delay(attempt int, err error) time.Duration {
+	if delay, ok := llms.RetryAfter(err); ok {
+		if r.opts.maxDelay > 0 {
+			delay = min(delay, r.opts.maxDelay)
+		}
+		return delay
+	}
+	backoff := float64(r.opts.initialDelay) * math.Pow(r.opts.multiplier, float64(attempt-1))
+	if r.opts.maxDelay > 0 {
+		backoff = min(backoff, float64(r.opts.maxDelay))
+	}
+	backoff -= backoff * r.opts.jitter * rand.Float64() //nolint:gosec
+	return time.Duration(backoff)
+}
+
+func (r *Retrier) handleRetry(ctx context.Context, attempt int, delay time.Duration, err error) {
+	if r.opts.callbacksHandler == nil {
+		return
+	}
+	if handler, ok := r.opts.callbacksHandler.(callbacks.RetryHandler); ok {
+		handler.HandleLLMRetry(ctx, attempt, delay, err)
+		return
+	}
+	r.opts.callbacksHandler.HandleLLMError(ctx, err)
+}
+
+// endStream ends the stream of the call returning err, forwarding the done chunk
+// held back by the relay. The error of the done chunk is returned if the call
+// succeeded.
+func endStream(ctx context.Context, s *streaming.Relay, err error) error {
+	if s == nil {
+		return err
+	}
+	if doneErr := s.Flush(ctx); err == nil {
+		err = doneErr
+	}
+	return err
+}
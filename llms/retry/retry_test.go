@@ -0,0 +1,288 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/retry"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attempt is the outcome of a call to the scripted model.
+type attempt struct {
+	chunks []string
+	err    error
+}
+
+type scriptedLLM struct {
+	mu       sync.Mutex
+	attempts []attempt
+	calls    int
+}
+
+func (m *scriptedLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *scriptedLLM) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	m.mu.Lock()
+	a := m.attempts[min(m.calls, len(m.attempts)-1)]
+	m.calls++
+	m.mu.Unlock()
+
+	defer streaming.CallWithDone(ctx, opts.StreamingFunc) //nolint:errcheck
+	var content string
+	for _, chunk := range a.chunks {
+		if err := streaming.CallWithText(ctx, opts.StreamingFunc, chunk); err != nil {
+			return nil, err
+		}
+		content += chunk
+	}
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: content}}}, nil
+}
+
+type retryRecorder struct {
+	callbacks.SimpleHandler
+	attempts []int
+	delays   []time.Duration
+}
+
+func (h *retryRecorder) HandleLLMRetry(_ context.Context, attempt int, delay time.Duration, _ error) {
+	h.attempts = append(h.attempts, attempt)
+	h.delays = append(h.delays, delay)
+}
+
+func fastBackoff() retry.Option {
+	return retry.WithBackoff(time.Millisecond, 5*time.Millisecond, 2)
+}
+
+func TestRetrierRetriesRetryableCodes(t *testing.T) {
+	t.Parallel()
+
+	llm := &scriptedLLM{attempts: []attempt{
+		{err: llms.NewError(llms.ErrCodeRateLimit, "test", "slow down")},
+		{err: errors.New("API returned unexpected status code: 503")},
+		{chunks: []string{"done"}},
+	}}
+	recorder := &retryRecorder{}
+	r := retry.New(llm, fastBackoff(), retry.WithCallbacksHandler(recorder))
+
+	resp, err := r.GenerateContent(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "done", resp.Choices[0].Content)
+	assert.Equal(t, 3, llm.calls)
+	assert.Equal(t, []int{1, 2}, recorder.attempts)
+	for _, delay := range recorder.delays {
+		assert.LessOrEqual(t, delay, 5*time.Millisecond)
+	}
+}
+
+func TestRetrierStopsOnNonRetryableCode(t *testing.T) {
+	t.Parallel()
+
+	authErr := llms.NewError(llms.ErrCodeAuthentication, "test", "bad key")
+	llm := &scriptedLLM{attempts: []attempt{{err: authErr}}}
+	r := retry.New(llm, fastBackoff())
+
+	_, err := r.GenerateContent(t.Context(), nil)
+	require.ErrorIs(t, err, authErr)
+	assert.Equal(t, 1, llm.calls)
+}
+
+func TestRetrierMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	llm := &scriptedLLM{attempts: []attempt{{err: llms.NewError(llms.ErrCodeProviderUnavailable, "test", "down")}}}
+	r := retry.New(llm, fastBackoff(), retry.WithMaxAttempts(4))
+
+	_, err := r.GenerateContent(t.Context(), nil)
+	require.True(t, llms.IsProviderUnavailableError(err))
+	assert.Equal(t, 4, llm.calls)
+}
+
+func TestRetrierHonoursRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	limited := llms.NewError(llms.ErrCodeRateLimit, "test", "slow down").WithDetail("retry_after", 20*time.Millisecond)
+	llm := &scriptedLLM{attempts: []attempt{{err: limited}, {chunks: []string{"ok"}}}}
+	recorder := &retryRecorder{}
+	r := retry.New(llm, retry.WithBackoff(time.Millisecond, 50*time.Millisecond, 2), retry.WithCallbacksHandler(recorder))
+
+	start := time.Now()
+	_, err := r.GenerateContent(t.Context(), nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	assert.Equal(t, []time.Duration{20 * time.Millisecond}, recorder.delays)
+
+	// the hinted delay is capped by the maximum delay
+	llm = &scriptedLLM{attempts: []attempt{{err: limited}, {chunks: []string{"ok"}}}}
+	recorder = &retryRecorder{}
+	r = retry.New(llm, fastBackoff(), retry.WithCallbacksHandler(recorder))
+	_, err = r.GenerateContent(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{5 * time.Millisecond}, recorder.delays)
+}
+
+func TestRetrierDoesNotRetryStartedStream(t *testing.T) {
+	t.Parallel()
+
+	llm := &scriptedLLM{attempts: []attempt{
+		{chunks: []string{"partial"}, err: llms.NewError(llms.ErrCodeProviderUnavailable, "test", "connection reset")},
+		{chunks: []string{"full"}},
+	}}
+	r := retry.New(llm, fastBackoff())
+
+	var chunks []streaming.Chunk
+	_, err := r.GenerateContent(t.Context(), nil, llms.WithStreamingFunc(func(_ context.Context, chunk streaming.Chunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}))
+	require.Error(t, err)
+	assert.Equal(t, 1, llm.calls)
+	assert.Equal(t, []streaming.Chunk{streaming.NewTextChunk("partial"), streaming.NewDoneChunk()}, chunks)
+}
+
+func TestRetrierRetriesStreamBeforeOutput(t *testing.T) {
+	t.Parallel()
+
+	llm := &scriptedLLM{attempts: []attempt{
+		{err: llms.NewError(llms.ErrCodeRateLimit, "test", "slow down")},
+		{chunks: []string{"full"}},
+	}}
+	r := retry.New(llm, fastBackoff())
+
+	var chunks []streaming.Chunk
+	_, err := r.GenerateContent(t.Context(), nil, llms.WithStreamingFunc(func(_ context.Context, chunk streaming.Chunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 2, llm.calls)
+	// the done chunk of the failed attempt is not forwarded
+	assert.Equal(t, []streaming.Chunk{streaming.NewTextChunk("full"), streaming.NewDoneChunk()}, chunks)
+}
+
+func TestRetrierContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	llm := &scriptedLLM{attempts: []attempt{{err: llms.NewError(llms.ErrCodeRateLimit, "test", "slow down")}}}
+	r := retry.New(llm, retry.WithBackoff(time.Hour, time.Hour, 2))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := r.GenerateContent(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, llm.calls)
+}
+
+func TestRetrierEndsStreamOnEveryExit(t *testing.T) {
+	t.Parallel()
+
+	limited := llms.NewError(llms.ErrCodeRateLimit, "test", "slow down")
+	tests := []struct {
+		name string
+		ctx  func(t *testing.T) context.Context
+	}{
+		{
+			name: "canceled while waiting",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancel(t.Context())
+				time.AfterFunc(10*time.Millisecond, cancel)
+				return ctx
+			},
+		},
+		{
+			name: "deadline before the retry",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+				t.Cleanup(cancel)
+				return ctx
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			llm := &scriptedLLM{attempts: []attempt{{err: limited}}}
+			r := retry.New(llm, retry.WithBackoff(time.Hour, time.Hour, 2))
+
+			var chunks []streaming.Chunk
+			_, err := r.GenerateContent(tt.ctx(t), nil, llms.WithStreamingFunc(func(_ context.Context, chunk streaming.Chunk) error {
+				chunks = append(chunks, chunk)
+				return nil
+			}))
+			require.ErrorIs(t, err, limited)
+			assert.Equal(t, 1, llm.calls)
+			assert.Equal(t, []streaming.Chunk{streaming.NewDoneChunk()}, chunks)
+		})
+	}
+}
+
+func TestRetrierErrorMapper(t *testing.T) {
+	t.Parallel()
+
+	llm := &scriptedLLM{attempts: []attempt{{err: errors.New("overloaded")}, {chunks: []string{"ok"}}}}
+	mapper := func(err error) error {
+		return llms.NewError(llms.ErrCodeProviderUnavailable, "test", err.Error()).WithCause(err)
+	}
+	handled := 0
+	r := retry.New(llm, fastBackoff(), retry.WithErrorMapper(mapper),
+		retry.WithCallbacksHandler(errorCounter{count: &handled}))
+
+	_, err := r.GenerateContent(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, llm.calls)
+	// handlers without HandleLLMRetry get the failed attempts as errors
+	assert.Equal(t, 1, handled)
+
+	// including the handlers combined with a retry handler
+	llm = &scriptedLLM{attempts: []attempt{{err: errors.New("overloaded")}, {chunks: []string{"ok"}}}}
+	recorder := &retryRecorder{}
+	r = retry.New(llm, fastBackoff(), retry.WithErrorMapper(mapper),
+		retry.WithCallbacksHandler(callbacks.CombiningHandler{
+			Callbacks: []callbacks.Handler{recorder, errorCounter{count: &handled}},
+		}))
+	_, err = r.GenerateContent(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, recorder.attempts)
+	assert.Equal(t, 2, handled)
+}
+
+type errorCounter struct {
+	callbacks.SimpleHandler
+	count *int
+}
+
+func (h errorCounter) HandleLLMError(context.Context, error) {
+	*h.count++
+}
+
+func ExampleNew() {
+	llm := &scriptedLLM{attempts: []attempt{
+		{err: llms.NewError(llms.ErrCodeRateLimit, "example", "slow down")},
+		{chunks: []string{"Hello!"}},
+	}}
+	r := retry.New(llm, retry.WithMaxAttempts(5), retry.WithBackoff(time.Millisecond, time.Second, 2))
+
+	out, err := llms.GenerateFromSinglePrompt(context.Background(), r, "Hi")
+	fmt.Println(out, err)
+	// Output: Hello! <nil>
+}
@@ -0,0 +1,10 @@
+// Package routing provides `llms.Model` implementations spreading the calls over
+// several models, possibly of different providers. A Fallback tries its models in
+// order, moving on to the next one when a call fails with one of the configured
+// error codes. A Router sends each call to the model of the first route whose rule
+// matches the request, e.g. on the prompt length or the presence of images or tools.
+// Both are models themselves, so a route can lead to a Fallback.
+//
+// The call options are passed unchanged to every model: provider specific options,
+// such as the model name, should be set when creating the models instead.
+package routing
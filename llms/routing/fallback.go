@@ -0,0 +1,153 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
+)
+
+// ErrNoModels is returned when a Fallback or a Router has no model to call.
+var ErrNoModels = errors.New("no models to call")
+
+// Fallback is a model calling an ordered list of models, falling back to the next
+// model when a call fails with one of the fallback error codes.
+type Fallback struct {
+	models []llms.Model
+	opts   options
+}
+
+// assert that `Fallback` implements the `llms.Model` interface.
+var _ llms.Model = (*Fallback)(nil)
+
+// NewFallback creates a model falling back through the models, in order.
+func NewFallback(models []llms.Model, opts ...Option) *Fallback {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Fallback{
+		models: models,
+		opts:   o,
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (f *Fallback) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, f, prompt, options...)
+}
+
+// GenerateContent asks the models to generate content from a sequence of
+// messages, one after the other until a call succeeds or fails with an error code
+// that is not a fallback code. A call that started streaming its output is not
+// passed on. When all the models fail, the errors of all the calls are returned.
+func (f *Fallback) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	if len(f.models) == 0 {
+		return nil, ErrNoModels
+	}
+
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var s *streaming.Relay
+	if opts.StreamingFunc != nil {
+		s = streaming.NewRelay(opts.StreamingFunc)
+		options = append(slices.Clip(options), llms.WithStreamingFunc(s.Forward))
+	}
+
+	errs := make([]error, 0, len(f.models))
+	for i, model := range f.models {
+		resp, err := model.GenerateContent(ctx, messages, options...)
+		if err == nil {
+			if s != nil {
+				err = s.Flush(ctx)
+			}
+			return resp, err
+		}
+		errs = append(errs, err)
+
+		if i == len(f.models)-1 || !f.shouldFallback(ctx, s, err) {
+			break
+		}
+		if f.opts.callbacksHandler != nil {
+			f.opts.callbacksHandler.HandleLLMError(ctx, err)
+		}
+		if s != nil {
+			s.Reset()
+		}
+	}
+	if s != nil {
+		_ = s.Flush(ctx)
+	}
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (f *Fallback) shouldFallback(ctx context.Context, s *streaming.Relay, err error) bool {
+	if ctx.Err() != nil || (s != nil && s.Started()) {
+		return false
+	}
+	var e *llms.Error
+	if !errors.As(err, &e) && f.opts.errorMapper != nil {
+		_ = errors.As(f.opts.errorMapper(err), &e)
+	}
+	return e != nil && slices.Contains(f.opts.fallbackCodes, e.Code)
+}
+
+// Option is a function that configures a Fallback.
+type Option func(*options)
+
+type options struct {
+	fallbackCodes    []llms.ErrorCode
+	errorMapper      func(error) error
+	callbacksHandler callbacks.Handler
+}
+
+func defaultOptions() options {
+	return options{
+		fallbackCodes: []llms.ErrorCode{
+			llms.ErrCodeRateLimit,
+			llms.ErrCodeQuotaExceeded,
+			llms.ErrCodeProviderUnavailable,
+			llms.ErrCodeTimeout,
+		},
+		errorMapper: llms.NewErrorMapper("").WrapError,
+	}
+}
+
+// WithFallbackCodes sets the error codes of the calls to pass on to the next model.
+// Default is rate limit, quota exceeded, provider unavailable and timeout.
+func WithFallbackCodes(codes ...llms.ErrorCode) Option {
+	return func(o *options) {
+		o.fallbackCodes = codes
+	}
+}
+
+// WithErrorMapper sets the function mapping the errors of the models that are not
+// an *llms.Error. Default is the generic llms.ErrorMapper.
+func WithErrorMapper(mapper func(error) error) Option {
+	return func(o *options) {
+		o.errorMapper = mapper
+	}
+}
+
+// WithCallbacksHandler sets the handler the errors of the calls passed on to the
+// next model are reported to.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(o *options) {
+		o.callbacksHandler = handler
+	}
+}
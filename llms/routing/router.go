@@ -0,0 +1,130 @@
+package routing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/llms"
+)
+
+// Request is a call to route.
+type Request struct {
+	Messages []llms.MessageContent
+	Options  llms.CallOptions
+}
+
+// PromptTokens returns an estimate of the number of tokens of the text of the messages.
+func (r Request) PromptTokens() int {
+	var sb strings.Builder
+	for _, message := range r.Messages {
+		for _, part := range message.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				sb.WriteString(text.Text)
+			}
+		}
+	}
+	return llms.CountTokens(r.Options.Model, sb.String())
+}
+
+// HasImages returns whether the messages hold images.
+func (r Request) HasImages() bool {
+	for _, message := range r.Messages {
+		for _, part := range message.Parts {
+			switch p := part.(type) {
+			case llms.ImageURLContent:
+				return true
+			case llms.BinaryContent:
+				if strings.HasPrefix(p.MIMEType, "image/") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// HasTools returns whether the call offers tools or functions to the model.
+func (r Request) HasTools() bool {
+	return len(r.Options.Tools) > 0 || len(r.Options.Functions) > 0
+}
+
+// Rule reports whether a request matches a route.
+type Rule func(Request) bool
+
+// PromptLongerThan matches the requests whose prompt is estimated longer than the
+// number of tokens.
+func PromptLongerThan(tokens int) Rule {
+	return func(r Request) bool {
+		return r.PromptTokens() > tokens
+	}
+}
+
+// HasImages matches the requests holding images.
+func HasImages(r Request) bool {
+	return r.HasImages()
+}
+
+// HasTools matches the requests offering tools.
+func HasTools(r Request) bool {
+	return r.HasTools()
+}
+
+// Route sends the requests matching its rule to its model.
+type Route struct {
+	Rule  Rule
+	Model llms.Model
+}
+
+// Router is a model sending each call to the model of the first route matching
+// the request, or to its default model.
+type Router struct {
+	routes       []Route
+	defaultModel llms.Model
+}
+
+// assert that `Router` implements the `llms.Model` interface.
+var _ llms.Model = (*Router)(nil)
+
+// NewRouter creates a model routing the calls through the routes, in order, to the
+// default model when no route matches.
+func NewRouter(defaultModel llms.Model, routes ...Route) *Router {
+	return &Router{
+		routes:       routes,
+		defaultModel: defaultModel,
+	}
+}
+
+// Call is a simplified interface for a text-only Model, generating a single
+// string response from a single string prompt.
+//
+// Deprecated: this method is retained for backwards compatibility. Use the
+// more general [GenerateContent] instead. You can also use
+// the [GenerateFromSinglePrompt] function which provides a similar capability
+// to Call and is built on top of the new interface.
+func (r *Router) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, options...)
+}
+
+// GenerateContent asks the model the request is routed to to generate content
+// from a sequence of messages.
+func (r *Router) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	model := r.Route(messages, options...)
+	if model == nil {
+		return nil, ErrNoModels
+	}
+	return model.GenerateContent(ctx, messages, options...)
+}
+
+// Route returns the model the call is routed to.
+func (r *Router) Route(messages []llms.MessageContent, options ...llms.CallOption) llms.Model {
+	req := Request{Messages: messages}
+	for _, opt := range options {
+		opt(&req.Options)
+	}
+	for _, route := range r.routes {
+		if route.Rule(req) {
+			return route.Model
+		}
+	}
+	return r.defaultModel
+}
@@ -0,0 +1,193 @@
+package routing_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/llms/routing"
+	"github.com/vxcontrol/langchaingo/llms/streaming"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedLLM answers with its name, after streaming its chunks, or fails.
+type namedLLM struct {
+	name   string
+	chunks []string
+	err    error
+	calls  int
+}
+
+func (m *namedLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *namedLLM) GenerateContent(ctx context.Context, _ []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	m.calls++
+
+	defer streaming.CallWithDone(ctx, opts.StreamingFunc) //nolint:errcheck
+	for _, chunk := range m.chunks {
+		if err := streaming.CallWithText(ctx, opts.StreamingFunc, chunk); err != nil {
+			return nil, err
+		}
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.name}}}, nil
+}
+
+type errorCounter struct {
+	callbacks.SimpleHandler
+	count int
+}
+
+func (h *errorCounter) HandleLLMError(context.Context, error) {
+	h.count++
+}
+
+func TestFallbackOnCodes(t *testing.T) {
+	t.Parallel()
+
+	primary := &namedLLM{name: "openai", err: llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")}
+	secondary := &namedLLM{name: "anthropic", err: errors.New("API returned unexpected status code: 503")}
+	backup := &namedLLM{name: "ollama"}
+	handler := &errorCounter{}
+	model := routing.NewFallback([]llms.Model{primary, secondary, backup}, routing.WithCallbacksHandler(handler))
+
+	out, err := llms.GenerateFromSinglePrompt(t.Context(), model, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "ollama", out)
+	assert.Equal(t, []int{1, 1, 1}, []int{primary.calls, secondary.calls, backup.calls})
+	assert.Equal(t, 2, handler.count)
+}
+
+func TestFallbackStopsOnOtherCodes(t *testing.T) {
+	t.Parallel()
+
+	invalid := llms.NewError(llms.ErrCodeInvalidRequest, "openai", "bad request")
+	primary := &namedLLM{name: "openai", err: invalid}
+	backup := &namedLLM{name: "anthropic"}
+	model := routing.NewFallback([]llms.Model{primary, backup})
+
+	_, err := llms.GenerateFromSinglePrompt(t.Context(), model, "hi")
+	require.ErrorIs(t, err, invalid)
+	assert.Equal(t, 0, backup.calls)
+
+	// content filtering is passed on when configured
+	filtered := &namedLLM{name: "openai", err: llms.NewError(llms.ErrCodeContentFilter, "openai", "blocked")}
+	model = routing.NewFallback([]llms.Model{filtered, backup}, routing.WithFallbackCodes(llms.ErrCodeContentFilter))
+	out, err := llms.GenerateFromSinglePrompt(t.Context(), model, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic", out)
+}
+
+func TestFallbackAllFail(t *testing.T) {
+	t.Parallel()
+
+	limited := llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")
+	down := llms.NewError(llms.ErrCodeProviderUnavailable, "anthropic", "overloaded")
+	model := routing.NewFallback([]llms.Model{&namedLLM{err: limited}, &namedLLM{err: down}})
+
+	_, err := llms.GenerateFromSinglePrompt(t.Context(), model, "hi")
+	require.ErrorIs(t, err, limited)
+	require.ErrorIs(t, err, down)
+
+	_, err = routing.NewFallback(nil).GenerateContent(t.Context(), nil)
+	require.ErrorIs(t, err, routing.ErrNoModels)
+}
+
+func TestFallbackStreaming(t *testing.T) {
+	t.Parallel()
+
+	unavailable := llms.NewError(llms.ErrCodeProviderUnavailable, "openai", "overloaded")
+
+	var chunks []streaming.Chunk
+	record := llms.WithStreamingFunc(func(_ context.Context, chunk streaming.Chunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	// no output yet: the call is passed on and only the done chunk of the backup is forwarded
+	backup := &namedLLM{name: "anthropic", chunks: []string{"Hello"}}
+	model := routing.NewFallback([]llms.Model{&namedLLM{err: unavailable}, backup})
+	_, err := model.GenerateContent(t.Context(), nil, record)
+	require.NoError(t, err)
+	assert.Equal(t, []streaming.Chunk{streaming.NewTextChunk("Hello"), streaming.NewDoneChunk()}, chunks)
+
+	// output started: the call is not passed on
+	chunks = nil
+	backup.calls = 0
+	model = routing.NewFallback([]llms.Model{&namedLLM{chunks: []string{"Hel"}, err: unavailable}, backup})
+	_, err = model.GenerateContent(t.Context(), nil, record)
+	require.ErrorIs(t, err, unavailable)
+	assert.Equal(t, 0, backup.calls)
+	assert.Equal(t, []streaming.Chunk{streaming.NewTextChunk("Hel"), streaming.NewDoneChunk()}, chunks)
+}
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	vision := &namedLLM{name: "vision"}
+	tools := &namedLLM{name: "tools"}
+	long := &namedLLM{name: "long"}
+	small := &namedLLM{name: "small"}
+	router := routing.NewRouter(small,
+		routing.Route{Rule: routing.HasImages, Model: vision},
+		routing.Route{Rule: routing.HasTools, Model: tools},
+		routing.Route{Rule: routing.PromptLongerThan(100), Model: long},
+	)
+
+	text := func(s string) []llms.MessageContent {
+		return []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, s)}
+	}
+	image := []llms.MessageContent{{
+		Role:  llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{llms.TextPart("What is it?"), llms.ImageURLPart("https://example.com/cat.png")},
+	}}
+	tool := llms.WithTools([]llms.Tool{{Type: "function", Function: &llms.FunctionDefinition{Name: "search"}}})
+
+	tests := []struct {
+		name     string
+		messages []llms.MessageContent
+		options  []llms.CallOption
+		want     string
+	}{
+		{"default", text("Hi"), nil, "small"},
+		{"images", image, []llms.CallOption{tool}, "vision"},
+		{"tools", text("Hi"), []llms.CallOption{tool}, "tools"},
+		{"long prompt", text(strings.Repeat("a long prompt ", 200)), nil, "long"},
+	}
+	for _, tt := range tests {
+		resp, err := router.GenerateContent(t.Context(), tt.messages, tt.options...)
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, resp.Choices[0].Content, tt.name)
+	}
+}
+
+func TestRouterToFallback(t *testing.T) {
+	t.Parallel()
+
+	primary := &namedLLM{name: "openai", err: llms.NewError(llms.ErrCodeRateLimit, "openai", "slow down")}
+	backup := &namedLLM{name: "anthropic"}
+	router := routing.NewRouter(nil, routing.Route{
+		Rule:  func(routing.Request) bool { return true },
+		Model: routing.NewFallback([]llms.Model{primary, backup}),
+	})
+
+	out, err := llms.GenerateFromSinglePrompt(t.Context(), router, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic", out)
+
+	_, err = routing.NewRouter(nil).GenerateContent(t.Context(), nil)
+	require.ErrorIs(t, err, routing.ErrNoModels)
+}
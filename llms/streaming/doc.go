@@ -40,4 +40,8 @@
 // When the LLM finishes generating all content, it should call the streaming.CallWithDone
 // function to signal the end of the streaming session, allowing consumers to perform
 // any necessary cleanup or finalization.
+//
+// Wrappers making several attempts of a call, such as retries or fallbacks, stream the
+// attempts through a Relay, which holds back the done chunk of an attempt until the
+// call ends.
 package streaming
@@ -0,0 +1,62 @@
+package streaming
+
+import (
+	"context"
+	"sync"
+)
+
+// Relay forwards the chunks of the successive attempts of a call, such as retries or
+// fallbacks to other models, to the streaming function of the call, and records
+// whether output started streaming. The done chunk of an attempt is held back until
+// Flush, so that an attempt followed by another one does not end the stream.
+type Relay struct {
+	fn Callback
+
+	mu      sync.Mutex
+	output  bool
+	pending bool
+}
+
+// NewRelay creates a relay forwarding the chunks to the streaming function.
+func NewRelay(fn Callback) *Relay {
+	return &Relay{fn: fn}
+}
+
+// Forward is the streaming function of the attempts.
+func (r *Relay) Forward(ctx context.Context, chunk Chunk) error {
+	r.mu.Lock()
+	if chunk.Type == ChunkTypeDone {
+		r.pending = true
+		r.mu.Unlock()
+		return nil
+	}
+	r.output = true
+	r.mu.Unlock()
+	return r.fn(ctx, chunk)
+}
+
+// Started reports whether a chunk other than done was forwarded.
+func (r *Relay) Started() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.output
+}
+
+// Reset drops the done chunk held back from the previous attempt.
+func (r *Relay) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = false
+}
+
+// Flush forwards the done chunk held back, if any, ending the stream of the call.
+func (r *Relay) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = false
+	r.mu.Unlock()
+	if !pending {
+		return nil
+	}
+	return CallWithDone(ctx, r.fn)
+}
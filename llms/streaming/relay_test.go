@@ -0,0 +1,35 @@
+package streaming
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay(t *testing.T) {
+	t.Parallel()
+
+	var chunks []Chunk
+	relay := NewRelay(func(_ context.Context, chunk Chunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	ctx := t.Context()
+
+	// a failed attempt without output ends its stream, which is held back
+	require.NoError(t, relay.Forward(ctx, NewDoneChunk()))
+	assert.False(t, relay.Started())
+	assert.Empty(t, chunks)
+	relay.Reset()
+
+	require.NoError(t, relay.Forward(ctx, NewTextChunk("hello")))
+	require.NoError(t, relay.Forward(ctx, NewDoneChunk()))
+	assert.True(t, relay.Started())
+	assert.Equal(t, []Chunk{NewTextChunk("hello")}, chunks)
+
+	require.NoError(t, relay.Flush(ctx))
+	require.NoError(t, relay.Flush(ctx))
+	assert.Equal(t, []Chunk{NewTextChunk("hello"), NewDoneChunk()}, chunks)
+}
@@ -63,6 +63,10 @@ func (wx *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConte
 				Content: result.Text,
 			},
 		},
+		Usage: &llms.Usage{
+			PromptTokens:     result.InputTokenCount,
+			CompletionTokens: result.GeneratedTokenCount,
+		},
 	}
 	return resp, nil
 }
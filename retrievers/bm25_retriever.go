@@ -0,0 +1,208 @@
+package retrievers
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	// DefaultBM25K1 is the default term frequency saturation parameter of BM25.
+	DefaultBM25K1 = 1.5
+	// DefaultBM25B is the default document length normalization parameter of BM25.
+	DefaultBM25B = 0.75
+	// DefaultBM25NumDocuments is the default number of documents returned by BM25Retriever.
+	DefaultBM25NumDocuments = 4
+)
+
+var _ schema.Retriever = &BM25Retriever{}
+
+// BM25Retriever is a keyword retriever ranking an in-memory set of documents with
+// the Okapi BM25 function. It finds exact terms such as product codes or
+// identifiers that embeddings tend to miss, and is usually combined with a vector
+// store retriever by EnsembleRetriever.
+type BM25Retriever struct {
+	CallbacksHandler callbacks.Handler
+
+	docs         []schema.Document
+	termFreqs    []map[string]int
+	docLens      []int
+	docFreqs     map[string]int
+	avgDocLen    float64
+	k1           float64
+	b            float64
+	numDocuments int
+	tokenizer    func(string) []string
+	stopWords    map[string]struct{}
+}
+
+// BM25Option is a function that configures a BM25Retriever.
+type BM25Option func(*BM25Retriever)
+
+// WithBM25Parameters sets the k1 (term frequency saturation, 1.5 by default)
+// and b (document length normalization, 0.75 by default) parameters.
+func WithBM25Parameters(k1, b float64) BM25Option {
+	return func(r *BM25Retriever) {
+		r.k1 = k1
+		r.b = b
+	}
+}
+
+// WithBM25NumDocuments sets the maximum number of documents returned (4 by default).
+func WithBM25NumDocuments(numDocuments int) BM25Option {
+	return func(r *BM25Retriever) {
+		r.numDocuments = numDocuments
+	}
+}
+
+// WithBM25Tokenizer sets the function splitting documents and queries into terms.
+// DefaultBM25Tokenizer is used by default.
+func WithBM25Tokenizer(tokenizer func(string) []string) BM25Option {
+	return func(r *BM25Retriever) {
+		r.tokenizer = tokenizer
+	}
+}
+
+// WithBM25StopWords sets terms that are ignored in documents and queries.
+// Stop words are compared with the terms produced by the tokenizer.
+func WithBM25StopWords(stopWords ...string) BM25Option {
+	return func(r *BM25Retriever) {
+		for _, word := range stopWords {
+			r.stopWords[word] = struct{}{}
+		}
+	}
+}
+
+// DefaultBM25Tokenizer lowercases the text and splits it on any character that
+// is not a letter or a digit.
+func DefaultBM25Tokenizer(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// NewBM25Retriever creates a new BM25Retriever indexing the documents.
+func NewBM25Retriever(docs []schema.Document, opts ...BM25Option) *BM25Retriever {
+	r := &BM25Retriever{
+		k1:           DefaultBM25K1,
+		b:            DefaultBM25B,
+		numDocuments: DefaultBM25NumDocuments,
+		tokenizer:    DefaultBM25Tokenizer,
+		stopWords:    make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.docs = make([]schema.Document, len(docs))
+	copy(r.docs, docs)
+	r.termFreqs = make([]map[string]int, len(docs))
+	r.docLens = make([]int, len(docs))
+	r.docFreqs = make(map[string]int)
+
+	totalLen := 0
+	for i, doc := range docs {
+		terms := r.tokenize(doc.PageContent)
+		freqs := make(map[string]int, len(terms))
+		for _, term := range terms {
+			freqs[term]++
+		}
+		for term := range freqs {
+			r.docFreqs[term]++
+		}
+		r.termFreqs[i] = freqs
+		r.docLens[i] = len(terms)
+		totalLen += len(terms)
+	}
+	if len(docs) > 0 {
+		r.avgDocLen = float64(totalLen) / float64(len(docs))
+	}
+
+	return r
+}
+
+// GetRelevantDocuments returns the documents with the highest BM25 score for the
+// query, with the score set in schema.Document.Score. Documents sharing no term
+// with the query are not returned.
+func (r *BM25Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	scores := r.Scores(query)
+	indices := make([]int, 0, len(scores))
+	for i, score := range scores {
+		if score > 0 {
+			indices = append(indices, i)
+		}
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return scores[indices[a]] > scores[indices[b]]
+	})
+	if r.numDocuments > 0 && len(indices) > r.numDocuments {
+		indices = indices[:r.numDocuments]
+	}
+
+	docs := make([]schema.Document, 0, len(indices))
+	for _, i := range indices {
+		doc := r.docs[i]
+		doc.Score = float32(scores[i])
+		docs = append(docs, doc)
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
+
+// Scores returns the BM25 score of every indexed document for the query,
+// in the order of the documents given to NewBM25Retriever.
+func (r *BM25Retriever) Scores(query string) []float64 {
+	scores := make([]float64, len(r.docs))
+	numDocs := float64(len(r.docs))
+
+	for _, term := range r.tokenize(query) {
+		docFreq := float64(r.docFreqs[term])
+		if docFreq == 0 {
+			continue
+		}
+		// the +1 keeps the inverse document frequency positive for frequent terms
+		idf := math.Log((numDocs-docFreq+0.5)/(docFreq+0.5) + 1)
+
+		for i, freqs := range r.termFreqs {
+			freq := float64(freqs[term])
+			if freq == 0 {
+				continue
+			}
+			norm := 1 - r.b
+			if r.avgDocLen > 0 {
+				norm += r.b * float64(r.docLens[i]) / r.avgDocLen
+			}
+			scores[i] += idf * freq * (r.k1 + 1) / (freq + r.k1*norm)
+		}
+	}
+
+	return scores
+}
+
+// tokenize splits the text into terms, without the stop words.
+func (r *BM25Retriever) tokenize(text string) []string {
+	terms := r.tokenizer(text)
+	if len(r.stopWords) == 0 {
+		return terms
+	}
+
+	filtered := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if _, ok := r.stopWords[term]; !ok {
+			filtered = append(filtered, term)
+		}
+	}
+	return filtered
+}
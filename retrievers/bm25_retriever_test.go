@@ -0,0 +1,71 @@
+package retrievers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBM25Retriever(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	docs := []schema.Document{
+		{PageContent: "The SKU-4411 charger supports fast charging", Metadata: map[string]any{"id": 1}},
+		{PageContent: "Chargers and cables for every phone"},
+		{PageContent: "A guide to the best phone cases"},
+		{PageContent: "Fast shipping on phone cases and phone chargers"},
+	}
+
+	retriever := NewBM25Retriever(docs)
+	results, err := retriever.GetRelevantDocuments(ctx, "sku-4411")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, docs[0].PageContent, results[0].PageContent)
+	assert.Equal(t, docs[0].Metadata, results[0].Metadata)
+	assert.Positive(t, results[0].Score)
+
+	results, err = retriever.GetRelevantDocuments(ctx, "phone cases")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	// documents matching both terms rank before the one matching only "phone"
+	assert.ElementsMatch(t,
+		[]string{docs[2].PageContent, docs[3].PageContent},
+		[]string{results[0].PageContent, results[1].PageContent})
+	assert.Equal(t, docs[1].PageContent, results[2].PageContent)
+	assert.GreaterOrEqual(t, results[0].Score, results[1].Score)
+	assert.Greater(t, results[1].Score, results[2].Score)
+
+	results, err = retriever.GetRelevantDocuments(ctx, "laptop")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestBM25RetrieverOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	docs := []schema.Document{
+		{PageContent: "the a1 b2"},
+		{PageContent: "the c3"},
+		{PageContent: "the d4 a1"},
+	}
+
+	retriever := NewBM25Retriever(docs,
+		WithBM25NumDocuments(1),
+		WithBM25Tokenizer(strings.Fields),
+		WithBM25StopWords("the"),
+		WithBM25Parameters(1.2, 0),
+	)
+	results, err := retriever.GetRelevantDocuments(ctx, "the a1")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "the a1 b2", results[0].PageContent)
+
+	scores := retriever.Scores("the")
+	assert.Equal(t, []float64{0, 0, 0}, scores)
+}
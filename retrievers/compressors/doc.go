@@ -0,0 +1,8 @@
+// Package compressors contains implementations of the retrievers.DocumentCompressor
+// interface, used by retrievers.ContextualCompressionRetriever to shorten retrieved documents.
+//
+// LLMExtractor asks a language model to extract the parts of each document relevant
+// to the query. EmbeddingsFilter drops the documents whose embedding is not similar
+// enough to the query, and RedundantFilter the documents similar to a previous one.
+// TokenBudget keeps the first documents fitting in a number of tokens.
+package compressors
@@ -0,0 +1,145 @@
+package compressors
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/vxcontrol/langchaingo/embeddings"
+	"github.com/vxcontrol/langchaingo/retrievers"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	// DefaultEmbeddingsSimilarityThreshold is the default minimum similarity of EmbeddingsFilter.
+	DefaultEmbeddingsSimilarityThreshold = 0.76
+	// DefaultRedundantSimilarityThreshold is the default similarity above which
+	// RedundantFilter considers two documents redundant.
+	DefaultRedundantSimilarityThreshold = 0.95
+)
+
+// ErrEmbeddingsMismatch is returned when the embedder does not return one vector per document.
+var ErrEmbeddingsMismatch = errors.New("number of embeddings does not match number of documents")
+
+var (
+	_ retrievers.DocumentCompressor = &EmbeddingsFilter{}
+	_ retrievers.DocumentCompressor = &RedundantFilter{}
+)
+
+// EmbeddingsFilter is a compressor dropping the documents whose embedding has a
+// cosine similarity with the query embedding lower than SimilarityThreshold.
+type EmbeddingsFilter struct {
+	Embedder embeddings.Embedder
+	// SimilarityThreshold is the minimum similarity of the documents kept.
+	SimilarityThreshold float32
+	// TopN is the maximum number of documents returned, all when 0.
+	TopN int
+}
+
+// NewEmbeddingsFilter returns a compressor keeping the documents whose similarity with the query
+// is at least similarityThreshold, e.g. DefaultEmbeddingsSimilarityThreshold.
+func NewEmbeddingsFilter(embedder embeddings.Embedder, similarityThreshold float32) *EmbeddingsFilter {
+	return &EmbeddingsFilter{
+		Embedder:            embedder,
+		SimilarityThreshold: similarityThreshold,
+	}
+}
+
+// CompressDocuments returns the documents similar enough to the query, sorted by
+// decreasing similarity, with the similarity set in schema.Document.Score.
+func (f *EmbeddingsFilter) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	queryVector, err := f.Embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	vectors, err := embedDocuments(ctx, f.Embedder, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]schema.Document, 0, len(docs))
+	for i, doc := range docs {
+		similarity := embeddings.CosineSimilarity(queryVector, vectors[i])
+		if similarity < f.SimilarityThreshold {
+			continue
+		}
+		doc.Score = similarity
+		filtered = append(filtered, doc)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Score > filtered[j].Score
+	})
+
+	if f.TopN > 0 && len(filtered) > f.TopN {
+		filtered = filtered[:f.TopN]
+	}
+	return filtered, nil
+}
+
+// RedundantFilter is a compressor dropping the documents whose embedding has a
+// cosine similarity higher than SimilarityThreshold with a previous document, such
+// as the overlapping chunks returned by several retrievers.
+type RedundantFilter struct {
+	Embedder embeddings.Embedder
+	// SimilarityThreshold is the similarity above which two documents are redundant.
+	SimilarityThreshold float32
+}
+
+// NewRedundantFilter returns a compressor dropping redundant documents, using
+// DefaultRedundantSimilarityThreshold.
+func NewRedundantFilter(embedder embeddings.Embedder) *RedundantFilter {
+	return &RedundantFilter{
+		Embedder:            embedder,
+		SimilarityThreshold: DefaultRedundantSimilarityThreshold,
+	}
+}
+
+// CompressDocuments returns the documents that are not redundant with a previous
+// one, keeping their order, so the best ranked of redundant documents is kept.
+func (f *RedundantFilter) CompressDocuments(
+	ctx context.Context,
+	_ string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	vectors, err := embedDocuments(ctx, f.Embedder, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]schema.Document, 0, len(docs))
+	kept := make([][]float32, 0, len(docs))
+	for i, doc := range docs {
+		redundant := false
+		for _, vector := range kept {
+			if embeddings.CosineSimilarity(vectors[i], vector) > f.SimilarityThreshold {
+				redundant = true
+				break
+			}
+		}
+		if redundant {
+			continue
+		}
+		filtered = append(filtered, doc)
+		kept = append(kept, vectors[i])
+	}
+	return filtered, nil
+}
+
+func embedDocuments(ctx context.Context, embedder embeddings.Embedder, docs []schema.Document) ([][]float32, error) {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbeddingsMismatch
+	}
+	return vectors, nil
+}
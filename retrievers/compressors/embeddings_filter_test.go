@@ -0,0 +1,71 @@
+package compressors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapEmbedder returns the vectors of its map, keyed by text.
+type mapEmbedder map[string][]float32
+
+func (e mapEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, e[text])
+	}
+	return vectors, nil
+}
+
+func (e mapEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return e[text], nil
+}
+
+func TestEmbeddingsFilter(t *testing.T) {
+	t.Parallel()
+
+	embedder := mapEmbedder{
+		"query": {1, 0},
+		"close": {0.9, 0.1},
+		"far":   {0, 1},
+		"exact": {2, 0},
+	}
+	docs := []schema.Document{{PageContent: "close"}, {PageContent: "far"}, {PageContent: "exact"}}
+
+	filter := NewEmbeddingsFilter(embedder, DefaultEmbeddingsSimilarityThreshold)
+	filtered, err := filter.CompressDocuments(t.Context(), "query", docs)
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "exact", filtered[0].PageContent)
+	assert.InDelta(t, 1, filtered[0].Score, 1e-6)
+	assert.Equal(t, "close", filtered[1].PageContent)
+
+	filter.TopN = 1
+	filtered, err = filter.CompressDocuments(t.Context(), "query", docs)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "exact", filtered[0].PageContent)
+}
+
+func TestRedundantFilter(t *testing.T) {
+	t.Parallel()
+
+	embedder := mapEmbedder{
+		"a":  {1, 0},
+		"b":  {0, 1},
+		"a2": {0.99, 0.01},
+		"ab": {1, 1},
+	}
+	filtered, err := NewRedundantFilter(embedder).CompressDocuments(t.Context(), "query", []schema.Document{
+		{PageContent: "a"}, {PageContent: "b"}, {PageContent: "a2"}, {PageContent: "ab"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "a"}, {PageContent: "b"}, {PageContent: "ab"}}, filtered)
+
+	_, err = NewRedundantFilter(mapEmbedder{}).CompressDocuments(t.Context(), "query", nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,146 @@
+package compressors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/retrievers"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	// NoOutput is the response of the language model when a document has no relevant part.
+	NoOutput = "NO_OUTPUT"
+
+	//nolint:lll
+	_defaultExtractTemplate = `Given the following question and context, extract any part of the context *AS IS* that is relevant to answer the question. If none of the context is relevant return ` + NoOutput + `.
+
+Remember, *DO NOT* edit the extracted parts of the context.
+
+Question: {{.question}}
+
+Context:
+>>>
+{{.context}}
+>>>
+
+Extracted relevant parts:`
+)
+
+var _ retrievers.DocumentCompressor = &LLMExtractor{}
+
+// LLMExtractor is a compressor asking a language model to extract, verbatim, the
+// parts of each document relevant to the query. The documents without relevant
+// part are dropped, the others have their content replaced by the extracted parts.
+type LLMExtractor struct {
+	LLM llms.Model
+	// Prompt is formatted with the "question" and "context" input variables,
+	// the model responds NoOutput when nothing is relevant.
+	Prompt prompts.FormatPrompter
+	// Number of workers to concurrently compress the documents.
+	MaxConcurrentWorkers int
+	CallOptions          []llms.CallOption
+}
+
+// LLMExtractorOption is a function type that can be used to modify the LLMExtractor.
+type LLMExtractorOption func(e *LLMExtractor)
+
+// WithPrompt is an option for providing the prompt, formatted with the "question"
+// and "context" input variables.
+func WithPrompt(prompt prompts.FormatPrompter) LLMExtractorOption {
+	return func(e *LLMExtractor) {
+		e.Prompt = prompt
+	}
+}
+
+// WithMaxConcurrentWorkers is an option for specifying the number of documents compressed concurrently.
+func WithMaxConcurrentWorkers(workers int) LLMExtractorOption {
+	return func(e *LLMExtractor) {
+		e.MaxConcurrentWorkers = workers
+	}
+}
+
+// WithCallOptions is an option for specifying the options of the LLM calls.
+func WithCallOptions(options ...llms.CallOption) LLMExtractorOption {
+	return func(e *LLMExtractor) {
+		e.CallOptions = options
+	}
+}
+
+// NewLLMExtractor returns a compressor extracting the relevant parts of the documents with the language model.
+func NewLLMExtractor(llm llms.Model, opts ...LLMExtractorOption) *LLMExtractor {
+	e := &LLMExtractor{
+		LLM:                  llm,
+		Prompt:               prompts.NewPromptTemplate(_defaultExtractTemplate, []string{"question", "context"}),
+		MaxConcurrentWorkers: 1,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// CompressDocuments extracts the parts of the documents relevant to the query,
+// keeping the order of the documents.
+func (e *LLMExtractor) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	extracted := make([]string, len(docs))
+	errs := make([]error, len(docs))
+
+	sem := make(chan struct{}, max(e.MaxConcurrentWorkers, 1))
+	var wg sync.WaitGroup
+	for i, doc := range docs {
+		wg.Add(1)
+		go func(i int, doc schema.Document) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			extracted[i], errs[i] = e.extract(ctx, query, doc)
+		}(i, doc)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	compressed := make([]schema.Document, 0, len(docs))
+	for i, doc := range docs {
+		if extracted[i] == "" {
+			continue
+		}
+		doc.PageContent = extracted[i]
+		compressed = append(compressed, doc)
+	}
+	return compressed, nil
+}
+
+// extract returns the relevant parts of the document, empty if there is none.
+func (e *LLMExtractor) extract(ctx context.Context, query string, doc schema.Document) (string, error) {
+	prompt, err := e.Prompt.FormatPrompt(map[string]any{
+		"question": query,
+		"context":  doc.PageContent,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	completion, err := llms.GenerateFromSinglePrompt(ctx, e.LLM, prompt.String(), e.CallOptions...)
+	if err != nil {
+		return "", err
+	}
+
+	completion = strings.TrimSpace(completion)
+	if strings.HasPrefix(completion, NoOutput) {
+		return "", nil
+	}
+	return completion, nil
+}
@@ -0,0 +1,56 @@
+package compressors
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extractLLM answers with the sentences of the context containing the question.
+type extractLLM struct{}
+
+func (extractLLM) GenerateContent(
+	_ context.Context,
+	messages []llms.MessageContent,
+	_ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	prompt := messages[0].Parts[0].(llms.TextContent).Text
+	question := strings.TrimSpace(strings.SplitN(strings.SplitN(prompt, "Question: ", 2)[1], "\n", 2)[0])
+	text := strings.SplitN(prompt, ">>>\n", 3)[1]
+
+	extracted := make([]string, 0)
+	for _, sentence := range strings.Split(strings.TrimSpace(text), ". ") {
+		if strings.Contains(sentence, question) {
+			extracted = append(extracted, sentence)
+		}
+	}
+	content := strings.Join(extracted, ". ")
+	if content == "" {
+		content = NoOutput
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: content}}}, nil
+}
+
+func (e extractLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, e, prompt, options...)
+}
+
+func TestLLMExtractor(t *testing.T) {
+	t.Parallel()
+
+	extractor := NewLLMExtractor(extractLLM{}, WithMaxConcurrentWorkers(2))
+	docs, err := extractor.CompressDocuments(t.Context(), "invoice", []schema.Document{
+		{PageContent: "The login page was down. Users could not log in", Metadata: map[string]any{"id": 1}},
+		{PageContent: "The invoice job failed. Payments were delayed. The invoice was resent", Metadata: map[string]any{"id": 2}},
+	})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "The invoice job failed. The invoice was resent", docs[0].PageContent)
+	assert.Equal(t, map[string]any{"id": 2}, docs[0].Metadata)
+}
@@ -0,0 +1,59 @@
+package compressors
+
+import (
+	"context"
+
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/retrievers"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+var _ retrievers.DocumentCompressor = &TokenBudget{}
+
+// TokenBudget is a compressor keeping the first documents whose contents fit in
+// MaxTokens tokens, so the context of the prompt does not exceed a budget. It is
+// usually the last compressor of a pipeline, after the documents are ranked.
+type TokenBudget struct {
+	// Model is the model name given to llms.CountTokens.
+	Model string
+	// MaxTokens is the maximum number of tokens of the documents kept.
+	MaxTokens int
+	// CountTokens counts the tokens of a text, llms.CountTokens for Model when nil.
+	CountTokens func(text string) int
+}
+
+// NewTokenBudget returns a compressor keeping the documents fitting in maxTokens
+// tokens of the model, counted with llms.CountTokens.
+func NewTokenBudget(model string, maxTokens int) *TokenBudget {
+	return &TokenBudget{
+		Model:     model,
+		MaxTokens: maxTokens,
+	}
+}
+
+// CompressDocuments returns the documents, in order, until the next one exceeds
+// the remaining budget. Lower ranked documents are not used to fill the budget.
+func (b *TokenBudget) CompressDocuments(
+	_ context.Context,
+	_ string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	countTokens := b.CountTokens
+	if countTokens == nil {
+		countTokens = func(text string) int {
+			return llms.CountTokens(b.Model, text)
+		}
+	}
+
+	kept := make([]schema.Document, 0, len(docs))
+	remaining := b.MaxTokens
+	for _, doc := range docs {
+		tokens := countTokens(doc.PageContent)
+		if tokens > remaining {
+			break
+		}
+		remaining -= tokens
+		kept = append(kept, doc)
+	}
+	return kept, nil
+}
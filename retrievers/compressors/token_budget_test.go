@@ -0,0 +1,36 @@
+package compressors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := NewTokenBudget("gpt-4", 5)
+	budget.CountTokens = func(text string) int {
+		return len(strings.Fields(text))
+	}
+
+	docs, err := budget.CompressDocuments(t.Context(), "query", []schema.Document{
+		{PageContent: "one two"},
+		{PageContent: "three four five"},
+		{PageContent: "six"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "one two"}, {PageContent: "three four five"}}, docs)
+
+	budget.MaxTokens = 1
+	docs, err = budget.CompressDocuments(t.Context(), "query", []schema.Document{
+		{PageContent: "one two"},
+		{PageContent: "three"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
@@ -0,0 +1,95 @@
+package retrievers
+
+import (
+	"context"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+var (
+	_ schema.Retriever   = &ContextualCompressionRetriever{}
+	_ DocumentCompressor = DocumentCompressorPipeline{}
+)
+
+// DocumentCompressor shortens or filters documents given the query they were retrieved for.
+// Implementations are available in the compressors package.
+type DocumentCompressor interface {
+	// CompressDocuments returns the documents, or the parts of them, relevant to the query.
+	CompressDocuments(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error)
+}
+
+// DocumentCompressorPipeline is a DocumentCompressor applying its compressors in order,
+// each one to the documents returned by the previous one.
+type DocumentCompressorPipeline []DocumentCompressor
+
+// CompressDocuments applies the compressors of the pipeline in order.
+func (p DocumentCompressorPipeline) CompressDocuments(
+	ctx context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	var err error
+	for _, compressor := range p {
+		if len(docs) == 0 {
+			break
+		}
+		docs, err = compressor.CompressDocuments(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// ContextualCompressionRetriever is a retriever that compresses the documents of a
+// base retriever with a DocumentCompressor, dropping the irrelevant documents and
+// the irrelevant text of the others, so less of the prompt is spent on context.
+type ContextualCompressionRetriever struct {
+	Retriever        schema.Retriever
+	Compressor       DocumentCompressor
+	CallbacksHandler callbacks.Handler
+}
+
+// NewContextualCompressionRetriever creates a new ContextualCompressionRetriever
+// applying the compressors in order.
+func NewContextualCompressionRetriever(
+	retriever schema.Retriever,
+	compressors ...DocumentCompressor,
+) ContextualCompressionRetriever {
+	var compressor DocumentCompressor = DocumentCompressorPipeline(compressors)
+	if len(compressors) == 1 {
+		compressor = compressors[0]
+	}
+	return ContextualCompressionRetriever{
+		Retriever:  retriever,
+		Compressor: compressor,
+	}
+}
+
+// GetRelevantDocuments returns the documents of the base retriever compressed for the query.
+func (r *ContextualCompressionRetriever) GetRelevantDocuments(
+	ctx context.Context,
+	query string,
+) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs, err := r.Retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) != 0 && r.Compressor != nil {
+		docs, err = r.Compressor.CompressDocuments(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
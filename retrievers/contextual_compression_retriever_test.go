@@ -0,0 +1,62 @@
+package retrievers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// containsCompressor keeps the documents containing the query.
+type containsCompressor struct{}
+
+func (containsCompressor) CompressDocuments(
+	_ context.Context,
+	query string,
+	docs []schema.Document,
+) ([]schema.Document, error) {
+	compressed := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if strings.Contains(doc.PageContent, query) {
+			compressed = append(compressed, doc)
+		}
+	}
+	return compressed, nil
+}
+
+// firstCompressor keeps the first document.
+type firstCompressor struct{}
+
+func (firstCompressor) CompressDocuments(_ context.Context, _ string, docs []schema.Document) ([]schema.Document, error) {
+	return docs[:1], nil
+}
+
+func TestContextualCompressionRetriever(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	base := &Fakeretriever{Docs: []schema.Document{
+		{PageContent: "go tour"},
+		{PageContent: "rust book"},
+		{PageContent: "go spec"},
+	}}
+
+	retriever := NewContextualCompressionRetriever(base, containsCompressor{})
+	docs, err := retriever.GetRelevantDocuments(ctx, "go")
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "go tour"}, {PageContent: "go spec"}}, docs)
+
+	retriever = NewContextualCompressionRetriever(base, containsCompressor{}, firstCompressor{})
+	docs, err = retriever.GetRelevantDocuments(ctx, "go")
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "go tour"}}, docs)
+
+	// the pipeline stops once no document is left
+	docs, err = retriever.GetRelevantDocuments(ctx, "python")
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+}
@@ -0,0 +1,25 @@
+// Package docstore contains stores of whole documents addressed by id, used by
+// retrievers that search small pieces of a document but return the whole of it,
+// such as retrievers.ParentDocumentRetriever and retrievers.MultiVectorRetriever.
+package docstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+// ErrIDsDocumentsMismatch is returned when the number of ids does not match the number of documents.
+var ErrIDsDocumentsMismatch = errors.New("number of ids does not match number of documents")
+
+// Store is a key-value store of documents.
+type Store interface {
+	// Get returns the documents stored under the given ids, in the order of ids.
+	// Ids that are not present in the store are skipped.
+	Get(ctx context.Context, ids []string) ([]schema.Document, error)
+	// Set stores the documents under the given ids, replacing existing ones.
+	Set(ctx context.Context, ids []string, docs []schema.Document) error
+	// Delete removes the documents stored under the given ids.
+	Delete(ctx context.Context, ids []string) error
+}
@@ -0,0 +1,46 @@
+package docstore
+
+import (
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	t.Parallel()
+
+	file, err := NewFile(t.TempDir())
+	require.NoError(t, err)
+
+	for name, store := range map[string]Store{
+		"inmemory": NewInMemory(),
+		"file":     file,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			docs := []schema.Document{
+				{PageContent: "first", Metadata: map[string]any{"source": "a.txt"}},
+				{PageContent: "second"},
+			}
+			require.NoError(t, store.Set(ctx, []string{"id/1", "id 2"}, docs))
+			require.ErrorIs(t, store.Set(ctx, []string{"id/1"}, docs), ErrIDsDocumentsMismatch)
+
+			got, err := store.Get(ctx, []string{"id 2", "missing", "id/1"})
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			require.Equal(t, "second", got[0].PageContent)
+			require.Equal(t, docs[0], got[1])
+
+			require.NoError(t, store.Set(ctx, []string{"id 2"}, []schema.Document{{PageContent: "replaced"}}))
+			require.NoError(t, store.Delete(ctx, []string{"id/1", "missing"}))
+
+			got, err = store.Get(ctx, []string{"id/1", "id 2"})
+			require.NoError(t, err)
+			require.Equal(t, []schema.Document{{PageContent: "replaced"}}, got)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package docstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+var _ Store = &File{}
+
+// File is a Store keeping each document as a JSON file in a directory.
+// File names are the base64url encoded ids, so any id can be used.
+// Metadata is encoded as JSON, so numbers are restored as float64.
+type File struct {
+	dir string
+}
+
+// fileDocument is the JSON encoding of a document in a file.
+type fileDocument struct {
+	PageContent string         `json:"page_content"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+// NewFile creates a File store in the directory, creating it if needed.
+func NewFile(dir string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir}, nil
+}
+
+// Get returns the documents stored under the given ids, in the order of ids.
+func (s *File) Get(ctx context.Context, ids []string) ([]schema.Document, error) {
+	docs := make([]schema.Document, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(s.path(id))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var doc fileDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, schema.Document{PageContent: doc.PageContent, Metadata: doc.Metadata})
+	}
+	return docs, nil
+}
+
+// Set stores the documents under the given ids. Each file is written to a
+// temporary file first and then renamed, so readers never see partial documents.
+func (s *File) Set(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(fileDocument{PageContent: docs[i].PageContent, Metadata: docs[i].Metadata})
+		if err != nil {
+			return err
+		}
+		if err := s.write(id, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes the documents stored under the given ids.
+func (s *File) Delete(_ context.Context, ids []string) error {
+	for _, id := range ids {
+		if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// write atomically writes the data of the document with the given id.
+func (s *File) write(id string, data []byte) error {
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(id))
+}
+
+// path returns the path of the file of the document with the given id.
+func (s *File) path(id string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(id))+".json")
+}
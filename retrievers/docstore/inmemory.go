@@ -0,0 +1,71 @@
+package docstore
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+var _ Store = &InMemory{}
+
+// InMemory is a Store keeping the documents in memory.
+type InMemory struct {
+	mu   sync.RWMutex
+	docs map[string]schema.Document
+}
+
+// NewInMemory creates a new empty InMemory store.
+func NewInMemory() *InMemory {
+	return &InMemory{docs: make(map[string]schema.Document)}
+}
+
+// Get returns the documents stored under the given ids, in the order of ids.
+func (s *InMemory) Get(_ context.Context, ids []string) ([]schema.Document, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	docs := make([]schema.Document, 0, len(ids))
+	for _, id := range ids {
+		if doc, ok := s.docs[id]; ok {
+			docs = append(docs, copyDocument(doc))
+		}
+	}
+	return docs, nil
+}
+
+// Set stores the documents under the given ids.
+func (s *InMemory) Set(_ context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return ErrIDsDocumentsMismatch
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, id := range ids {
+		s.docs[id] = copyDocument(docs[i])
+	}
+	return nil
+}
+
+// Delete removes the documents stored under the given ids.
+func (s *InMemory) Delete(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.docs, id)
+	}
+	return nil
+}
+
+// copyDocument returns a copy of the document with its own metadata map,
+// so callers can not modify the stored document.
+func copyDocument(doc schema.Document) schema.Document {
+	if doc.Metadata != nil {
+		doc.Metadata = maps.Clone(doc.Metadata)
+	}
+	return doc
+}
@@ -0,0 +1,222 @@
+package retrievers
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+// DefaultRRFConstant is the default rank constant of reciprocal rank fusion.
+const DefaultRRFConstant = 60
+
+// ErrWeightsMismatch is returned when the number of weights of an EnsembleRetriever
+// does not match the number of retrievers.
+var ErrWeightsMismatch = errors.New("number of weights does not match number of retrievers")
+
+var _ schema.Retriever = &EnsembleRetriever{}
+
+// FusionMethod is the method used by EnsembleRetriever to combine the rankings.
+type FusionMethod string
+
+const (
+	// FusionReciprocalRank scores a document with the sum over retrievers of
+	// weight / (RRFConstant + rank), where rank starts at 1. It only relies on the
+	// ranks, so retrievers with incomparable scores can be mixed.
+	FusionReciprocalRank FusionMethod = "rrf"
+	// FusionWeightedScore scores a document with the sum over retrievers of weight
+	// times its score, min-max normalized to [0, 1] among the retriever results.
+	FusionWeightedScore FusionMethod = "weighted"
+)
+
+// EnsembleRetriever is a retriever that fuses the rankings of multiple retrievers,
+// for example a BM25Retriever and a vector store retriever for hybrid search.
+// Unlike MergerRetriever, documents returned by several retrievers are returned
+// once, and the results are sorted by their fused score, set in schema.Document.Score.
+type EnsembleRetriever struct {
+	Retrievers []schema.Retriever
+	// Weights of the retrievers, all retrievers weigh 1 when empty.
+	Weights []float64
+	// Method used to fuse the rankings, FusionReciprocalRank by default.
+	Method FusionMethod
+	// RRFConstant is the rank constant of reciprocal rank fusion, 60 by default.
+	RRFConstant int
+	// NumDocuments is the maximum number of documents returned, all when 0.
+	NumDocuments int
+	// DocumentKey identifies the same document returned by different retrievers,
+	// the page content is used by default.
+	DocumentKey      func(schema.Document) string
+	CallbacksHandler callbacks.Handler
+}
+
+// EnsembleOption is a function that configures an EnsembleRetriever.
+type EnsembleOption func(*EnsembleRetriever)
+
+// WithEnsembleWeights sets the weights of the retrievers, in the same order.
+func WithEnsembleWeights(weights ...float64) EnsembleOption {
+	return func(r *EnsembleRetriever) {
+		r.Weights = weights
+	}
+}
+
+// WithReciprocalRankFusion fuses the rankings with reciprocal rank fusion using the
+// given rank constant (60 when not positive). This is the default method.
+func WithReciprocalRankFusion(constant int) EnsembleOption {
+	return func(r *EnsembleRetriever) {
+		r.Method = FusionReciprocalRank
+		r.RRFConstant = constant
+	}
+}
+
+// WithWeightedScoreFusion fuses the rankings with the weighted sum of the
+// normalized scores returned by the retrievers.
+func WithWeightedScoreFusion() EnsembleOption {
+	return func(r *EnsembleRetriever) {
+		r.Method = FusionWeightedScore
+	}
+}
+
+// WithEnsembleNumDocuments sets the maximum number of documents returned.
+func WithEnsembleNumDocuments(numDocuments int) EnsembleOption {
+	return func(r *EnsembleRetriever) {
+		r.NumDocuments = numDocuments
+	}
+}
+
+// WithEnsembleDocumentKey sets the function identifying the same document
+// returned by different retrievers.
+func WithEnsembleDocumentKey(key func(schema.Document) string) EnsembleOption {
+	return func(r *EnsembleRetriever) {
+		r.DocumentKey = key
+	}
+}
+
+// NewEnsembleRetriever creates a new EnsembleRetriever.
+func NewEnsembleRetriever(retrievers []schema.Retriever, opts ...EnsembleOption) EnsembleRetriever {
+	r := EnsembleRetriever{
+		Retrievers:  retrievers,
+		Method:      FusionReciprocalRank,
+		RRFConstant: DefaultRRFConstant,
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// GetRelevantDocuments returns the fused results of all the retrievers.
+func (r *EnsembleRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+	if len(r.Weights) != 0 && len(r.Weights) != len(r.Retrievers) {
+		return nil, ErrWeightsMismatch
+	}
+
+	results := make([][]schema.Document, 0, len(r.Retrievers))
+	for _, retriever := range r.Retrievers {
+		docs, err := retriever.GetRelevantDocuments(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, docs)
+	}
+
+	docs := r.Fuse(results)
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
+
+// Fuse combines the rankings returned by the retrievers, in the order of the
+// retrievers, into a single ranking sorted by decreasing fused score.
+func (r *EnsembleRetriever) Fuse(results [][]schema.Document) []schema.Document {
+	keyOf := r.DocumentKey
+	if keyOf == nil {
+		keyOf = func(doc schema.Document) string { return doc.PageContent }
+	}
+
+	fused := make([]schema.Document, 0)
+	scores := make([]float64, 0)
+	positions := make(map[string]int)
+	for i, docs := range results {
+		weight := 1.0
+		if i < len(r.Weights) {
+			weight = r.Weights[i]
+		}
+
+		seen := make(map[string]bool, len(docs))
+		for rank, score := range r.rankScores(docs) {
+			key := keyOf(docs[rank])
+			if seen[key] {
+				// only the best rank of a document counts
+				continue
+			}
+			seen[key] = true
+
+			pos, ok := positions[key]
+			if !ok {
+				pos = len(fused)
+				positions[key] = pos
+				fused = append(fused, docs[rank])
+				scores = append(scores, 0)
+			}
+			scores[pos] += weight * score
+		}
+	}
+
+	order := make([]int, len(fused))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return scores[order[a]] > scores[order[b]]
+	})
+	if r.NumDocuments > 0 && len(order) > r.NumDocuments {
+		order = order[:r.NumDocuments]
+	}
+
+	ranked := make([]schema.Document, 0, len(order))
+	for _, pos := range order {
+		doc := fused[pos]
+		doc.Score = float32(scores[pos])
+		ranked = append(ranked, doc)
+	}
+	return ranked
+}
+
+// rankScores returns the unweighted contribution of each document of a ranking.
+func (r *EnsembleRetriever) rankScores(docs []schema.Document) []float64 {
+	scores := make([]float64, len(docs))
+	if r.Method != FusionWeightedScore {
+		constant := r.RRFConstant
+		if constant <= 0 {
+			constant = DefaultRRFConstant
+		}
+		for rank := range docs {
+			scores[rank] = 1 / float64(constant+rank+1)
+		}
+		return scores
+	}
+
+	if len(docs) == 0 {
+		return scores
+	}
+	lowest, highest := docs[0].Score, docs[0].Score
+	for _, doc := range docs {
+		lowest = min(lowest, doc.Score)
+		highest = max(highest, doc.Score)
+	}
+	for i, doc := range docs {
+		if highest == lowest {
+			scores[i] = 1
+			continue
+		}
+		scores[i] = float64((doc.Score - lowest) / (highest - lowest))
+	}
+	return scores
+}
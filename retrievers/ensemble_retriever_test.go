@@ -0,0 +1,74 @@
+package retrievers
+
+import (
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsembleRetrieverReciprocalRank(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	docA := schema.Document{PageContent: "a", Score: 0.9}
+	docB := schema.Document{PageContent: "b", Score: 0.8}
+	docC := schema.Document{PageContent: "c", Score: 12}
+	keyword := &Fakeretriever{Docs: []schema.Document{docC, {PageContent: "b", Score: 3}}}
+	vector := &Fakeretriever{Docs: []schema.Document{docA, docB}}
+
+	ensemble := NewEnsembleRetriever([]schema.Retriever{keyword, vector})
+	docs, err := ensemble.GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	// b is ranked by both retrievers
+	assert.Equal(t, "b", docs[0].PageContent)
+	assert.InDelta(t, 2.0/62, docs[0].Score, 1e-6)
+	assert.Equal(t, "c", docs[1].PageContent)
+	assert.Equal(t, "a", docs[2].PageContent)
+
+	ensemble = NewEnsembleRetriever([]schema.Retriever{keyword, vector},
+		WithEnsembleWeights(0.2, 0.8), WithReciprocalRankFusion(1), WithEnsembleNumDocuments(2))
+	docs, err = ensemble.GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "a", docs[0].PageContent)
+	assert.Equal(t, "b", docs[1].PageContent)
+
+	ensemble = NewEnsembleRetriever([]schema.Retriever{keyword, vector}, WithEnsembleWeights(1))
+	_, err = ensemble.GetRelevantDocuments(ctx, "query")
+	require.ErrorIs(t, err, ErrWeightsMismatch)
+}
+
+func TestEnsembleRetrieverWeightedScore(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	keyword := &Fakeretriever{Docs: []schema.Document{
+		{PageContent: "c", Score: 12},
+		{PageContent: "b", Score: 6},
+		{PageContent: "d", Score: 2},
+	}}
+	vector := &Fakeretriever{Docs: []schema.Document{
+		{PageContent: "a", Score: 0.9},
+		{PageContent: "b", Score: 0.85},
+		{PageContent: "c", Score: 0.4},
+	}}
+
+	ensemble := NewEnsembleRetriever([]schema.Retriever{keyword, vector},
+		WithWeightedScoreFusion(), WithEnsembleWeights(0.5, 0.5))
+	docs, err := ensemble.GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 4)
+
+	contents := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		contents = append(contents, doc.PageContent)
+	}
+	assert.Equal(t, []string{"b", "c", "a", "d"}, contents)
+	assert.InDelta(t, 0.5*0.4+0.5*0.9, docs[0].Score, 1e-6)
+	assert.InDelta(t, 0.5, docs[1].Score, 1e-6)
+	assert.InDelta(t, 0, docs[3].Score, 1e-6)
+}
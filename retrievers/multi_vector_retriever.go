@@ -0,0 +1,248 @@
+package retrievers
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/retrievers/docstore"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMultiVectorIDKey is the default metadata key linking indexed texts to their document.
+	DefaultMultiVectorIDKey = "doc_id"
+	// DefaultMultiVectorNumDocuments is the default number of texts searched in the vector store.
+	DefaultMultiVectorNumDocuments = 4
+
+	_defaultSummaryTemplate = `Summarize the following document in a few sentences, keeping its key facts, names and identifiers.
+
+Document:
+{{.document}}
+
+Summary:`
+	_defaultQuestionsTemplate = `Write {{.count}} questions that the following document answers. Write one question per line, without numbering.
+
+Document:
+{{.document}}
+
+Questions:`
+)
+
+// ErrMissingGenerator is returned when adding documents to a MultiVectorRetriever without generator.
+var ErrMissingGenerator = errors.New("multi vector retriever has no generator")
+
+var _listMarkerRE = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*•])\s*`)
+
+var _ schema.Retriever = &MultiVectorRetriever{}
+
+// MultiVectorGenerator returns the texts indexed in the vector store in place of a
+// document, such as chunks, a summary or hypothetical questions it answers.
+type MultiVectorGenerator func(ctx context.Context, doc schema.Document) ([]string, error)
+
+// MultiVectorRetriever is a retriever that indexes several texts per document in a
+// vector store, and returns the whole documents, kept in a document store, whose
+// texts are the most similar to the query.
+type MultiVectorRetriever struct {
+	VectorStore vectorstores.VectorStore
+	DocStore    docstore.Store
+	// Generator returns the texts indexed for each document added with AddDocuments.
+	Generator MultiVectorGenerator
+	// IDKey is the metadata key of the indexed texts holding the document id, "doc_id" by default.
+	IDKey string
+	// NumDocuments is the number of texts searched in the vector store, 4 by default.
+	// At most as many documents are returned, fewer if several texts share a document.
+	NumDocuments int
+	// SearchOptions are passed to the vector store similarity search.
+	SearchOptions    []vectorstores.Option
+	CallbacksHandler callbacks.Handler
+}
+
+// MultiVectorOption is a function that configures a MultiVectorRetriever.
+type MultiVectorOption func(*MultiVectorRetriever)
+
+// WithMultiVectorIDKey sets the metadata key holding the document id of the indexed texts.
+func WithMultiVectorIDKey(key string) MultiVectorOption {
+	return func(r *MultiVectorRetriever) {
+		r.IDKey = key
+	}
+}
+
+// WithMultiVectorNumDocuments sets the number of texts searched in the vector store.
+func WithMultiVectorNumDocuments(numDocuments int) MultiVectorOption {
+	return func(r *MultiVectorRetriever) {
+		r.NumDocuments = numDocuments
+	}
+}
+
+// WithMultiVectorSearchOptions sets the options of the vector store similarity search.
+func WithMultiVectorSearchOptions(options ...vectorstores.Option) MultiVectorOption {
+	return func(r *MultiVectorRetriever) {
+		r.SearchOptions = options
+	}
+}
+
+// NewMultiVectorRetriever creates a new MultiVectorRetriever indexing the texts
+// returned by the generator, see NewSummaryGenerator and NewHypotheticalQuestionsGenerator.
+func NewMultiVectorRetriever(
+	store vectorstores.VectorStore,
+	docStore docstore.Store,
+	generator MultiVectorGenerator,
+	opts ...MultiVectorOption,
+) MultiVectorRetriever {
+	r := MultiVectorRetriever{
+		VectorStore:  store,
+		DocStore:     docStore,
+		Generator:    generator,
+		IDKey:        DefaultMultiVectorIDKey,
+		NumDocuments: DefaultMultiVectorNumDocuments,
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
+// AddDocuments stores the documents in the document store and indexes the texts
+// returned by the generator for each of them in the vector store. The document id
+// is taken from the IDKey metadata if set, generated otherwise. It returns the ids.
+func (r *MultiVectorRetriever) AddDocuments(ctx context.Context, docs []schema.Document) ([]string, error) {
+	if r.Generator == nil {
+		return nil, ErrMissingGenerator
+	}
+
+	ids := make([]string, 0, len(docs))
+	indexed := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		id := r.documentID(doc)
+		texts, err := r.Generator(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		for _, text := range texts {
+			metadata := maps.Clone(doc.Metadata)
+			if metadata == nil {
+				metadata = make(map[string]any, 1)
+			}
+			metadata[r.idKey()] = id
+			indexed = append(indexed, schema.Document{PageContent: text, Metadata: metadata})
+		}
+		ids = append(ids, id)
+	}
+
+	if len(indexed) != 0 {
+		if _, err := r.VectorStore.AddDocuments(ctx, indexed); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.DocStore.Set(ctx, ids, docs); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetRelevantDocuments returns the documents whose indexed texts are the most
+// similar to the query, in the order of their best matching text.
+func (r *MultiVectorRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	numDocuments := r.NumDocuments
+	if numDocuments <= 0 {
+		numDocuments = DefaultMultiVectorNumDocuments
+	}
+	matches, err := r.VectorStore.SimilaritySearch(ctx, query, numDocuments, r.SearchOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		id, ok := match.Metadata[r.idKey()].(string)
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	docs, err := r.DocStore.Get(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, docs)
+	}
+	return docs, nil
+}
+
+func (r *MultiVectorRetriever) idKey() string {
+	if r.IDKey == "" {
+		return DefaultMultiVectorIDKey
+	}
+	return r.IDKey
+}
+
+func (r *MultiVectorRetriever) documentID(doc schema.Document) string {
+	if id, ok := doc.Metadata[r.idKey()].(string); ok && id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// NewSummaryGenerator returns a MultiVectorGenerator indexing a summary of each
+// document written by the language model.
+func NewSummaryGenerator(llm llms.Model, options ...llms.CallOption) MultiVectorGenerator {
+	prompt := prompts.NewPromptTemplate(_defaultSummaryTemplate, []string{"document"})
+	return func(ctx context.Context, doc schema.Document) ([]string, error) {
+		text, err := prompt.Format(map[string]any{"document": doc.PageContent})
+		if err != nil {
+			return nil, err
+		}
+		summary, err := llms.GenerateFromSinglePrompt(ctx, llm, text, options...)
+		if err != nil {
+			return nil, err
+		}
+		return []string{strings.TrimSpace(summary)}, nil
+	}
+}
+
+// NewHypotheticalQuestionsGenerator returns a MultiVectorGenerator indexing up to
+// numQuestions questions answered by each document, written by the language model.
+func NewHypotheticalQuestionsGenerator(llm llms.Model, numQuestions int, options ...llms.CallOption) MultiVectorGenerator {
+	prompt := prompts.NewPromptTemplate(_defaultQuestionsTemplate, []string{"count", "document"})
+	return func(ctx context.Context, doc schema.Document) ([]string, error) {
+		text, err := prompt.Format(map[string]any{"count": numQuestions, "document": doc.PageContent})
+		if err != nil {
+			return nil, err
+		}
+		completion, err := llms.GenerateFromSinglePrompt(ctx, llm, text, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		questions := make([]string, 0, numQuestions)
+		for _, line := range strings.Split(completion, "\n") {
+			question := strings.TrimSpace(_listMarkerRE.ReplaceAllString(line, ""))
+			if question == "" {
+				continue
+			}
+			questions = append(questions, question)
+			if len(questions) == numQuestions {
+				break
+			}
+		}
+		return questions, nil
+	}
+}
@@ -0,0 +1,140 @@
+package retrievers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/llms/fake"
+	"github.com/vxcontrol/langchaingo/retrievers/docstore"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/textsplitter"
+	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ vectorstores.VectorStore = &keywordStore{}
+
+// keywordStore is a vector store returning the documents containing the query, in insertion order.
+type keywordStore struct {
+	docs []schema.Document
+}
+
+func (s *keywordStore) AddDocuments(
+	_ context.Context,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	s.docs = append(s.docs, docs...)
+	return make([]string, len(docs)), nil
+}
+
+func (s *keywordStore) SimilaritySearch(
+	_ context.Context,
+	query string,
+	numDocuments int,
+	_ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	docs := make([]schema.Document, 0)
+	for _, doc := range s.docs {
+		if len(docs) < numDocuments && strings.Contains(doc.PageContent, query) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func TestParentDocumentRetriever(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := &keywordStore{}
+	splitter := textsplitter.NewRecursiveCharacter(
+		textsplitter.WithChunkSize(12),
+		textsplitter.WithChunkOverlap(0),
+		textsplitter.WithSeparators([]string{"\n"}),
+	)
+	retriever := NewParentDocumentRetriever(store, docstore.NewInMemory(), splitter)
+
+	ids, err := retriever.AddDocuments(ctx, []schema.Document{
+		{PageContent: "red apple\ngreen pear\nblue berry", Metadata: map[string]any{"doc_id": "fruits"}},
+		{PageContent: "red car\nblue bike", Metadata: map[string]any{"source": "vehicles.txt"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Equal(t, "fruits", ids[0])
+	require.Len(t, store.docs, 5)
+	assert.Equal(t, map[string]any{"source": "vehicles.txt", "doc_id": ids[1]}, store.docs[4].Metadata)
+
+	docs, err := retriever.GetRelevantDocuments(ctx, "red")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "red apple\ngreen pear\nblue berry", docs[0].PageContent)
+	assert.Equal(t, "red car\nblue bike", docs[1].PageContent)
+
+	// children of the same parent return the parent once
+	docs, err = retriever.GetRelevantDocuments(ctx, "l")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "fruits", docs[0].Metadata["doc_id"])
+	assert.Equal(t, "vehicles.txt", docs[1].Metadata["source"])
+}
+
+func TestParentDocumentRetrieverWithParentSplitter(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := &keywordStore{}
+	retriever := NewParentDocumentRetriever(store, docstore.NewInMemory(),
+		textsplitter.NewRecursiveCharacter(
+			textsplitter.WithChunkSize(5), textsplitter.WithChunkOverlap(0), textsplitter.WithSeparators([]string{" "})),
+		WithMultiVectorNumDocuments(10),
+	)
+	retriever.ParentSplitter = textsplitter.NewRecursiveCharacter(
+		textsplitter.WithChunkSize(12), textsplitter.WithChunkOverlap(0), textsplitter.WithSeparators([]string{"\n"}))
+
+	ids, err := retriever.AddDocuments(ctx, []schema.Document{
+		{PageContent: "one two\nthree four", Metadata: map[string]any{"doc_id": "numbers"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.NotEqual(t, ids[0], ids[1])
+
+	docs, err := retriever.GetRelevantDocuments(ctx, "four")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "three four", docs[0].PageContent)
+}
+
+func TestMultiVectorRetriever(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := &keywordStore{}
+	llm := fake.NewFakeLLM([]string{"1. What is the capital of France?\n2. Where is the Eiffel tower?\n3. Extra?"})
+	retriever := NewMultiVectorRetriever(store, docstore.NewInMemory(),
+		NewHypotheticalQuestionsGenerator(llm, 2), WithMultiVectorIDKey("parent"))
+
+	ids, err := retriever.AddDocuments(ctx, []schema.Document{{PageContent: "Paris is the capital of France."}})
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	require.Len(t, store.docs, 2)
+	assert.Equal(t, "Where is the Eiffel tower?", store.docs[1].PageContent)
+	assert.Equal(t, ids[0], store.docs[1].Metadata["parent"])
+
+	docs, err := retriever.GetRelevantDocuments(ctx, "Eiffel")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "Paris is the capital of France.", docs[0].PageContent)
+
+	retriever.Generator = NewSummaryGenerator(fake.NewFakeLLM([]string{" A summary. "}))
+	_, err = retriever.AddDocuments(ctx, []schema.Document{{PageContent: "A long document."}})
+	require.NoError(t, err)
+	assert.Equal(t, "A summary.", store.docs[2].PageContent)
+
+	retriever.Generator = nil
+	_, err = retriever.AddDocuments(ctx, []schema.Document{{PageContent: "A long document."}})
+	require.ErrorIs(t, err, ErrMissingGenerator)
+}
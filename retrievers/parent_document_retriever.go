@@ -0,0 +1,63 @@
+package retrievers
+
+import (
+	"context"
+
+	"github.com/vxcontrol/langchaingo/retrievers/docstore"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/textsplitter"
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+var _ schema.Retriever = &ParentDocumentRetriever{}
+
+// ParentDocumentRetriever is a retriever that indexes small chunks of the documents
+// in a vector store, for precise embeddings, and returns the larger parent documents
+// kept in a document store. It is a MultiVectorRetriever whose indexed texts are
+// the chunks of ChildSplitter.
+type ParentDocumentRetriever struct {
+	MultiVectorRetriever
+	// ChildSplitter splits the parent documents into the chunks indexed in the vector store.
+	ChildSplitter textsplitter.TextSplitter
+	// ParentSplitter, if set, first splits the added documents into the parent
+	// documents, e.g. sections returned instead of whole documents.
+	ParentSplitter textsplitter.TextSplitter
+}
+
+// NewParentDocumentRetriever creates a new ParentDocumentRetriever.
+func NewParentDocumentRetriever(
+	store vectorstores.VectorStore,
+	docStore docstore.Store,
+	childSplitter textsplitter.TextSplitter,
+	opts ...MultiVectorOption,
+) ParentDocumentRetriever {
+	return ParentDocumentRetriever{
+		MultiVectorRetriever: NewMultiVectorRetriever(store, docStore, nil, opts...),
+		ChildSplitter:        childSplitter,
+	}
+}
+
+// AddDocuments splits the documents into parents with ParentSplitter if set, stores
+// the parents in the document store and indexes their chunks in the vector store.
+// It returns the ids of the parents. As for MultiVectorRetriever.AddDocuments, the
+// id of a parent is taken from its IDKey metadata, unless the document was split.
+func (r *ParentDocumentRetriever) AddDocuments(ctx context.Context, docs []schema.Document) ([]string, error) {
+	parents := docs
+	if r.ParentSplitter != nil {
+		var err error
+		parents, err = textsplitter.SplitDocuments(r.ParentSplitter, docs)
+		if err != nil {
+			return nil, err
+		}
+		// the parents split from a document get their own ids
+		for _, parent := range parents {
+			delete(parent.Metadata, r.idKey())
+		}
+	}
+
+	multiVector := r.MultiVectorRetriever
+	multiVector.Generator = func(_ context.Context, doc schema.Document) ([]string, error) {
+		return r.ChildSplitter.SplitText(doc.PageContent)
+	}
+	return multiVector.AddDocuments(ctx, parents)
+}
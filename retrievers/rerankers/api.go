@@ -0,0 +1,185 @@
+package rerankers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/vxcontrol/langchaingo/httputil"
+	"github.com/vxcontrol/langchaingo/retrievers"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	// CohereAPIURL is the URL of the Cohere rerank API.
+	CohereAPIURL = "https://api.cohere.com/v2/rerank"
+	// CohereDefaultModel is the Cohere rerank model used by default.
+	CohereDefaultModel = "rerank-v3.5"
+	// JinaAPIURL is the URL of the Jina rerank API.
+	JinaAPIURL = "https://api.jina.ai/v1/rerank"
+	// JinaDefaultModel is the Jina rerank model used by default.
+	JinaDefaultModel = "jina-reranker-v2-base-multilingual"
+)
+
+var (
+	// ErrRerankRequest is returned when the rerank API responds with an error.
+	ErrRerankRequest = errors.New("rerank request failed")
+	// ErrInvalidResponse is returned when the rerank API response references unknown documents.
+	ErrInvalidResponse = errors.New("invalid rerank response")
+	// ErrMissingAPIKey is returned when creating a reranker without API key.
+	ErrMissingAPIKey = errors.New("missing the rerank API key")
+)
+
+var _ retrievers.Reranker = &APIReranker{}
+
+// APIReranker is a reranker calling a Cohere-compatible /rerank HTTP API.
+type APIReranker struct {
+	Model      string
+	APIBaseURL string
+	APIKey     string
+	client     *http.Client
+}
+
+// Option is a function type that can be used to modify the APIReranker.
+type Option func(r *APIReranker)
+
+// WithModel is an option for providing the rerank model name to use.
+func WithModel(model string) Option {
+	return func(r *APIReranker) {
+		r.Model = model
+	}
+}
+
+// WithAPIBaseURL is an option for specifying the URL of the rerank endpoint.
+func WithAPIBaseURL(apiBaseURL string) Option {
+	return func(r *APIReranker) {
+		r.APIBaseURL = apiBaseURL
+	}
+}
+
+// WithAPIKey is an option for specifying the API key.
+func WithAPIKey(apiKey string) Option {
+	return func(r *APIReranker) {
+		r.APIKey = apiKey
+	}
+}
+
+// WithClient is an option for providing a custom HTTP client.
+func WithClient(client *http.Client) Option {
+	return func(r *APIReranker) {
+		r.client = client
+	}
+}
+
+// NewCohere returns a reranker for the Cohere rerank API. The API key is read
+// from the COHERE_API_KEY environment variable unless set with WithAPIKey.
+func NewCohere(opts ...Option) (*APIReranker, error) {
+	return newAPIReranker(CohereAPIURL, CohereDefaultModel, "COHERE_API_KEY", opts...)
+}
+
+// NewJina returns a reranker for the Jina rerank API. The API key is read
+// from the JINA_API_KEY environment variable unless set with WithAPIKey.
+func NewJina(opts ...Option) (*APIReranker, error) {
+	return newAPIReranker(JinaAPIURL, JinaDefaultModel, "JINA_API_KEY", opts...)
+}
+
+func newAPIReranker(url, model, apiKeyEnv string, opts ...Option) (*APIReranker, error) {
+	r := &APIReranker{
+		Model:      model,
+		APIBaseURL: url,
+		APIKey:     os.Getenv(apiKeyEnv),
+		client:     httputil.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.APIKey == "" {
+		return nil, fmt.Errorf("%w, set it in the %s environment variable or with WithAPIKey",
+			ErrMissingAPIKey, apiKeyEnv)
+	}
+	return r, nil
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// Rerank sends the documents to the rerank API and returns them sorted by
+// decreasing relevance score.
+func (r *APIReranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	if len(docs) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	body, err := json.Marshal(rerankRequest{
+		Model:     r.Model,
+		Query:     query,
+		Documents: texts,
+		TopN:      len(texts),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.APIBaseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.APIKey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %s: %s", ErrRerankRequest, resp.Status, respBody)
+	}
+
+	var response rerankResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	reranked := make([]schema.Document, 0, len(response.Results))
+	for _, result := range response.Results {
+		if result.Index < 0 || result.Index >= len(docs) {
+			return nil, fmt.Errorf("%w: document index %d out of range", ErrInvalidResponse, result.Index)
+		}
+		doc := docs[result.Index]
+		doc.Score = float32(result.RelevanceScore)
+		reranked = append(reranked, doc)
+	}
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+
+	return reranked, nil
+}
@@ -0,0 +1,107 @@
+package rerankers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIReranker(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		var req rerankRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "test-model", req.Model)
+		assert.Equal(t, "query", req.Query)
+		assert.Equal(t, []string{"a", "b", "c"}, req.Documents)
+		assert.Equal(t, 3, req.TopN)
+
+		_, _ = w.Write([]byte(`{"results": [
+			{"index": 2, "relevance_score": 0.9},
+			{"index": 0, "relevance_score": 0.2},
+			{"index": 1, "relevance_score": 0.5}
+		]}`))
+	}))
+	defer server.Close()
+
+	for name, newReranker := range map[string]func(...Option) (*APIReranker, error){
+		"cohere": NewCohere,
+		"jina":   NewJina,
+	} {
+		t.Run(name, func(t *testing.T) {
+			reranker, err := newReranker(
+				WithAPIBaseURL(server.URL),
+				WithAPIKey("test-key"),
+				WithModel("test-model"),
+				WithClient(server.Client()),
+			)
+			require.NoError(t, err)
+
+			docs, err := reranker.Rerank(t.Context(), "query", []schema.Document{
+				{PageContent: "a"},
+				{PageContent: "b"},
+				{PageContent: "c", Metadata: map[string]any{"source": "c.txt"}},
+			})
+			require.NoError(t, err)
+			require.Len(t, docs, 3)
+			assert.Equal(t, "c", docs[0].PageContent)
+			assert.Equal(t, map[string]any{"source": "c.txt"}, docs[0].Metadata)
+			assert.InDelta(t, 0.9, docs[0].Score, 1e-6)
+			assert.Equal(t, "b", docs[1].PageContent)
+			assert.Equal(t, "a", docs[2].PageContent)
+		})
+	}
+}
+
+func TestAPIRerankerErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invalid" {
+			_, _ = w.Write([]byte(`{"results": [{"index": 5, "relevance_score": 0.9}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message": "invalid api token"}`))
+	}))
+	defer server.Close()
+
+	docs := []schema.Document{{PageContent: "a"}}
+
+	reranker, err := NewCohere(WithAPIBaseURL(server.URL), WithAPIKey("test-key"), WithClient(server.Client()))
+	require.NoError(t, err)
+	_, err = reranker.Rerank(t.Context(), "query", docs)
+	require.ErrorIs(t, err, ErrRerankRequest)
+	require.ErrorContains(t, err, "invalid api token")
+
+	reranker, err = NewJina(WithAPIBaseURL(server.URL+"/invalid"), WithAPIKey("test-key"), WithClient(server.Client()))
+	require.NoError(t, err)
+	_, err = reranker.Rerank(t.Context(), "query", docs)
+	require.ErrorIs(t, err, ErrInvalidResponse)
+}
+
+func TestAPIRerankerAPIKey(t *testing.T) {
+	t.Setenv("COHERE_API_KEY", "")
+	t.Setenv("JINA_API_KEY", "jina-key")
+
+	_, err := NewCohere()
+	require.ErrorIs(t, err, ErrMissingAPIKey)
+	require.ErrorContains(t, err, "COHERE_API_KEY")
+
+	reranker, err := NewCohere(WithAPIKey("cohere-key"))
+	require.NoError(t, err)
+	assert.Equal(t, "cohere-key", reranker.APIKey)
+
+	reranker, err = NewJina()
+	require.NoError(t, err)
+	assert.Equal(t, "jina-key", reranker.APIKey)
+}
@@ -0,0 +1,7 @@
+// Package rerankers contains implementations of the retrievers.Reranker interface,
+// used by retrievers.RerankingRetriever to reorder retrieved documents.
+//
+// APIReranker calls a Cohere-compatible /rerank HTTP API, such as the ones of
+// Cohere (NewCohere) and Jina (NewJina), or self-hosted servers exposing the same API.
+// LLMReranker asks any llms.Model to judge the relevance of each document.
+package rerankers
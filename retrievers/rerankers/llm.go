@@ -0,0 +1,156 @@
+package rerankers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/retrievers"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	//nolint:lll
+	_defaultLLMRerankTemplate = `Rate how relevant the document is to the question on a scale from 0 to 10, where 0 means unrelated and 10 means the document fully answers the question. Respond with the number only.
+
+Question: {{.question}}
+
+Document: {{.document}}
+
+Relevance:`
+	_defaultLLMRerankMaxScore = 10
+)
+
+// ErrInvalidScore is returned when the LLM response does not contain a relevance score.
+var ErrInvalidScore = errors.New("llm response does not contain a relevance score")
+
+var _scoreRE = regexp.MustCompile(`\d+(\.\d+)?`)
+
+var _ retrievers.Reranker = &LLMReranker{}
+
+// LLMReranker is a reranker asking a language model to judge the relevance of each
+// document to the query (LLM-as-judge). The model is prompted once per document and
+// the first number of its response, divided by MaxScore, is the relevance score.
+type LLMReranker struct {
+	LLM llms.Model
+	// Prompt is formatted with the "question" and "document" input variables.
+	Prompt prompts.FormatPrompter
+	// MaxScore is the highest score the prompt asks for, 10 by default.
+	MaxScore float64
+	// Number of workers to concurrently score the documents.
+	MaxConcurrentWorkers int
+	CallOptions          []llms.CallOption
+}
+
+// LLMOption is a function type that can be used to modify the LLMReranker.
+type LLMOption func(r *LLMReranker)
+
+// WithPrompt is an option for providing the prompt, formatted with the "question" and
+// "document" input variables, asking for a score between 0 and maxScore.
+func WithPrompt(prompt prompts.FormatPrompter, maxScore float64) LLMOption {
+	return func(r *LLMReranker) {
+		r.Prompt = prompt
+		r.MaxScore = maxScore
+	}
+}
+
+// WithMaxConcurrentWorkers is an option for specifying the number of documents scored concurrently.
+func WithMaxConcurrentWorkers(workers int) LLMOption {
+	return func(r *LLMReranker) {
+		r.MaxConcurrentWorkers = workers
+	}
+}
+
+// WithCallOptions is an option for specifying the options of the LLM calls.
+func WithCallOptions(options ...llms.CallOption) LLMOption {
+	return func(r *LLMReranker) {
+		r.CallOptions = options
+	}
+}
+
+// NewLLM returns a reranker using the language model as a judge.
+func NewLLM(llm llms.Model, opts ...LLMOption) *LLMReranker {
+	r := &LLMReranker{
+		LLM:                  llm,
+		Prompt:               prompts.NewPromptTemplate(_defaultLLMRerankTemplate, []string{"question", "document"}),
+		MaxScore:             _defaultLLMRerankMaxScore,
+		MaxConcurrentWorkers: 1,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Rerank scores every document with the language model and returns them sorted
+// by decreasing relevance score.
+func (r *LLMReranker) Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error) {
+	reranked := make([]schema.Document, len(docs))
+	errs := make([]error, len(docs))
+
+	sem := make(chan struct{}, max(r.MaxConcurrentWorkers, 1))
+	var wg sync.WaitGroup
+	for i, doc := range docs {
+		wg.Add(1)
+		go func(i int, doc schema.Document) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			score, err := r.score(ctx, query, doc)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			doc.Score = score
+			reranked[i] = doc
+		}(i, doc)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+	return reranked, nil
+}
+
+// score returns the relevance score of the document, between 0 and 1.
+func (r *LLMReranker) score(ctx context.Context, query string, doc schema.Document) (float32, error) {
+	prompt, err := r.Prompt.FormatPrompt(map[string]any{
+		"question": query,
+		"document": doc.PageContent,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	completion, err := llms.GenerateFromSinglePrompt(ctx, r.LLM, prompt.String(), r.CallOptions...)
+	if err != nil {
+		return 0, err
+	}
+
+	match := _scoreRE.FindString(completion)
+	if match == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidScore, completion)
+	}
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidScore, err)
+	}
+
+	maxScore := r.MaxScore
+	if maxScore <= 0 {
+		maxScore = _defaultLLMRerankMaxScore
+	}
+	return float32(min(score, maxScore) / maxScore), nil
+}
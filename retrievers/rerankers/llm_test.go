@@ -0,0 +1,65 @@
+package rerankers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// judgeLLM answers with the score of the first document content found in the prompt.
+type judgeLLM struct {
+	scores map[string]string
+}
+
+func (j *judgeLLM) GenerateContent(
+	_ context.Context,
+	messages []llms.MessageContent,
+	_ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	prompt := messages[0].Parts[0].(llms.TextContent).Text
+	for content, score := range j.scores {
+		if strings.Contains(prompt, "Document: "+content+"\n") {
+			return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: score}}}, nil
+		}
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "0"}}}, nil
+}
+
+func (j *judgeLLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, j, prompt, options...)
+}
+
+func TestLLMReranker(t *testing.T) {
+	t.Parallel()
+
+	llm := &judgeLLM{scores: map[string]string{
+		"go":     "7",
+		"python": "Relevance: 2/10",
+		"rust":   "12",
+	}}
+	reranker := NewLLM(llm, WithMaxConcurrentWorkers(2))
+
+	docs, err := reranker.Rerank(t.Context(), "compiled languages", []schema.Document{
+		{PageContent: "python"},
+		{PageContent: "go"},
+		{PageContent: "rust"},
+	})
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, "rust", docs[0].PageContent)
+	assert.InDelta(t, 1, docs[0].Score, 1e-6)
+	assert.Equal(t, "go", docs[1].PageContent)
+	assert.InDelta(t, 0.7, docs[1].Score, 1e-6)
+	assert.Equal(t, "python", docs[2].PageContent)
+	assert.InDelta(t, 0.2, docs[2].Score, 1e-6)
+
+	llm.scores["go"] = "not relevant"
+	_, err = reranker.Rerank(t.Context(), "compiled languages", []schema.Document{{PageContent: "go"}})
+	require.ErrorIs(t, err, ErrInvalidScore)
+}
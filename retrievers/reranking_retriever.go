@@ -0,0 +1,77 @@
+package retrievers
+
+import (
+	"context"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+var _ schema.Retriever = &RerankingRetriever{}
+
+// Reranker reorders documents by their relevance to a query.
+// Implementations are available in the rerankers package.
+type Reranker interface {
+	// Rerank returns the documents sorted by decreasing relevance to the query,
+	// with the relevance score set in schema.Document.Score.
+	Rerank(ctx context.Context, query string, docs []schema.Document) ([]schema.Document, error)
+}
+
+// RerankingRetriever is a retriever that reorders the documents of a base retriever
+// with a Reranker and keeps the TopN most relevant ones. The base retriever should
+// over-fetch, e.g. a vector store retriever returning 20 documents for a TopN of 4,
+// so the reranker can surface relevant documents ranked low by the first stage.
+type RerankingRetriever struct {
+	Retriever schema.Retriever
+	Reranker  Reranker
+	// TopN is the maximum number of documents returned, all when 0.
+	TopN int
+	// ScoreThreshold filters out documents with a lower relevance score.
+	ScoreThreshold   float32
+	CallbacksHandler callbacks.Handler
+}
+
+// NewRerankingRetriever creates a new RerankingRetriever.
+func NewRerankingRetriever(retriever schema.Retriever, reranker Reranker, topN int) RerankingRetriever {
+	return RerankingRetriever{
+		Retriever: retriever,
+		Reranker:  reranker,
+		TopN:      topN,
+	}
+}
+
+// GetRelevantDocuments returns the TopN documents of the base retriever
+// most relevant to the query according to the reranker.
+func (r *RerankingRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
+	}
+
+	docs, err := r.Retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) != 0 {
+		docs, err = r.Reranker.Rerank(ctx, query, docs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ranked := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if r.TopN > 0 && len(ranked) == r.TopN {
+			break
+		}
+		if doc.Score < r.ScoreThreshold {
+			continue
+		}
+		ranked = append(ranked, doc)
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, query, ranked)
+	}
+	return ranked, nil
+}
@@ -0,0 +1,52 @@
+package retrievers
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lengthReranker ranks longer documents first.
+type lengthReranker struct{}
+
+func (lengthReranker) Rerank(_ context.Context, _ string, docs []schema.Document) ([]schema.Document, error) {
+	reranked := slices.Clone(docs)
+	for i := range reranked {
+		reranked[i].Score = float32(len(reranked[i].PageContent)) / 10
+	}
+	slices.SortStableFunc(reranked, func(a, b schema.Document) int {
+		return len(b.PageContent) - len(a.PageContent)
+	})
+	return reranked, nil
+}
+
+func TestRerankingRetriever(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	base := &Fakeretriever{Docs: []schema.Document{
+		{PageContent: "a"},
+		{PageContent: "abc"},
+		{PageContent: "ab"},
+		{PageContent: "abcd"},
+	}}
+
+	retriever := NewRerankingRetriever(base, lengthReranker{}, 2)
+	docs, err := retriever.GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "abcd", docs[0].PageContent)
+	assert.Equal(t, "abc", docs[1].PageContent)
+
+	retriever = NewRerankingRetriever(base, lengthReranker{}, 0)
+	retriever.ScoreThreshold = 0.2
+	docs, err = retriever.GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, "ab", docs[2].PageContent)
+}
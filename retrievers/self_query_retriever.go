@@ -0,0 +1,228 @@
+package retrievers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/callbacks"
+	"github.com/vxcontrol/langchaingo/llms"
+	"github.com/vxcontrol/langchaingo/prompts"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+const (
+	// DefaultSelfQueryNumDocuments is the default number of documents returned by SelfQueryRetriever.
+	DefaultSelfQueryNumDocuments = 4
+
+	//nolint:lll
+	_defaultSelfQueryTemplate = `Your goal is to structure the user's question to match the request schema below.
+
+Respond with a JSON object with the following fields:
+- "query": the text to compare to the content of the documents, without the conditions expressed in the filter. Use an empty string if there is nothing left to compare.
+- "filter": a condition on the document metadata, or null if the question has no condition on the metadata.
+
+A condition is a JSON object of one of these forms:
+- {"operator": "<comparator>", "key": "<attribute>", "value": <value>} where comparator is one of eq, ne, gt, gte, lt, lte and value is a string, a number or a boolean.
+- {"operator": "in" or "nin", "key": "<attribute>", "value": [<values>]}
+- {"operator": "exists", "key": "<attribute>"}
+- {"operator": "and" or "or", "filters": [<conditions>]}
+- {"operator": "not", "filters": [<condition>]}
+
+Only use the attributes listed below, with values of their type. Dates are strings in the YYYY-MM-DD format, so they can be compared. Today is {{.today}}.
+
+Documents: {{.contents}}
+
+Attributes:
+{{.attributes}}
+
+Example:
+Question: songs by Taylor Swift or Katy Perry about teenage romance under 3 minutes long
+Response: {"query": "teenager love", "filter": {"operator": "and", "filters": [{"operator": "in", "key": "artist", "value": ["Taylor Swift", "Katy Perry"]}, {"operator": "lt", "key": "length", "value": 180}]}}
+
+Question: {{.question}}
+Response:`
+)
+
+// ErrInvalidStructuredQuery is returned when the language model response is not
+// a valid structured query.
+var ErrInvalidStructuredQuery = errors.New("invalid structured query")
+
+var _ schema.Retriever = &SelfQueryRetriever{}
+
+// AttributeInfo describes a metadata attribute the SelfQueryRetriever can filter on.
+type AttributeInfo struct {
+	// Name is the metadata key, nested keys are separated by dots.
+	Name string
+	// Type of the values, e.g. "string", "integer", "float", "boolean" or "date".
+	Type string
+	// Description of the attribute for the language model.
+	Description string
+}
+
+// StructuredQuery is a question split by the SelfQueryRetriever into a text
+// query and a metadata filter.
+type StructuredQuery struct {
+	Query  string               `json:"query"`
+	Filter *vectorstores.Filter `json:"filter"`
+}
+
+// SelfQueryRetriever is a retriever that asks a language model to split the question
+// into a text query and a metadata filter, built from the declared metadata
+// attributes, before searching the vector store with vectorstores.WithFilters.
+// This lets questions such as "incidents from 2024 about billing" be answered with a
+// similarity search for "billing incidents" restricted to documents dated 2024.
+type SelfQueryRetriever struct {
+	LLM         llms.Model
+	VectorStore vectorstores.VectorStore
+	// DocumentContents describes the content of the documents.
+	DocumentContents string
+	// Attributes are the metadata attributes the filter may use.
+	Attributes []AttributeInfo
+	// NumDocuments is the number of documents returned, 4 by default.
+	NumDocuments int
+	// Prompt is formatted with the "question", "contents", "attributes" and "today" input variables.
+	Prompt prompts.FormatPrompter
+	// FilterTranslator converts the filter before it is given to vectorstores.WithFilters,
+	// for vector stores that do not accept a vectorstores.Filter. The filter is passed as is when nil.
+	FilterTranslator func(vectorstores.Filter) (any, error)
+	// SearchOptions are passed to the vector store similarity search.
+	SearchOptions    []vectorstores.Option
+	CallOptions      []llms.CallOption
+	CallbacksHandler callbacks.Handler
+}
+
+// NewSelfQueryRetriever creates a new SelfQueryRetriever.
+func NewSelfQueryRetriever(
+	llm llms.Model,
+	store vectorstores.VectorStore,
+	documentContents string,
+	attributes []AttributeInfo,
+) SelfQueryRetriever {
+	return SelfQueryRetriever{
+		LLM:              llm,
+		VectorStore:      store,
+		DocumentContents: documentContents,
+		Attributes:       attributes,
+		NumDocuments:     DefaultSelfQueryNumDocuments,
+		Prompt: prompts.NewPromptTemplate(_defaultSelfQueryTemplate,
+			[]string{"question", "contents", "attributes", "today"}),
+	}
+}
+
+// GetRelevantDocuments structures the question and returns the documents most
+// similar to its text query that match its filter.
+func (r *SelfQueryRetriever) GetRelevantDocuments(ctx context.Context, question string) ([]schema.Document, error) {
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverStart(ctx, question)
+	}
+
+	structured, err := r.StructureQuery(ctx, question)
+	if err != nil {
+		return nil, err
+	}
+
+	query := structured.Query
+	if strings.TrimSpace(query) == "" {
+		query = question
+	}
+
+	options := r.SearchOptions
+	if structured.Filter != nil {
+		var filters any = *structured.Filter
+		if r.FilterTranslator != nil {
+			filters, err = r.FilterTranslator(*structured.Filter)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(append([]vectorstores.Option{}, options...), vectorstores.WithFilters(filters))
+	}
+
+	numDocuments := r.NumDocuments
+	if numDocuments <= 0 {
+		numDocuments = DefaultSelfQueryNumDocuments
+	}
+	docs, err := r.VectorStore.SimilaritySearch(ctx, query, numDocuments, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.CallbacksHandler != nil {
+		r.CallbacksHandler.HandleRetrieverEnd(ctx, question, docs)
+	}
+	return docs, nil
+}
+
+// StructureQuery asks the language model to split the question into a text
+// query and a metadata filter. The filter may only use the declared attributes.
+func (r *SelfQueryRetriever) StructureQuery(ctx context.Context, question string) (StructuredQuery, error) {
+	attributes := make([]string, 0, len(r.Attributes))
+	for _, attribute := range r.Attributes {
+		attributes = append(attributes, fmt.Sprintf("- %s (%s): %s", attribute.Name, attribute.Type, attribute.Description))
+	}
+
+	prompt, err := r.Prompt.FormatPrompt(map[string]any{
+		"question":   question,
+		"contents":   r.DocumentContents,
+		"attributes": strings.Join(attributes, "\n"),
+		"today":      time.Now().Format(time.DateOnly),
+	})
+	if err != nil {
+		return StructuredQuery{}, err
+	}
+
+	completion, err := llms.GenerateFromSinglePrompt(ctx, r.LLM, prompt.String(), r.CallOptions...)
+	if err != nil {
+		return StructuredQuery{}, err
+	}
+
+	return r.parseStructuredQuery(completion)
+}
+
+// parseStructuredQuery parses the JSON object of the language model response
+// and validates its filter.
+func (r *SelfQueryRetriever) parseStructuredQuery(completion string) (StructuredQuery, error) {
+	start, end := strings.Index(completion, "{"), strings.LastIndex(completion, "}")
+	if start < 0 || end < start {
+		return StructuredQuery{}, fmt.Errorf("%w: no JSON object in %q", ErrInvalidStructuredQuery, completion)
+	}
+
+	var structured StructuredQuery
+	if err := json.Unmarshal([]byte(completion[start:end+1]), &structured); err != nil {
+		return StructuredQuery{}, fmt.Errorf("%w: %w", ErrInvalidStructuredQuery, err)
+	}
+	if structured.Filter == nil {
+		return structured, nil
+	}
+
+	if err := structured.Filter.Validate(); err != nil {
+		return StructuredQuery{}, fmt.Errorf("%w: %w", ErrInvalidStructuredQuery, err)
+	}
+	if err := r.checkAttributes(*structured.Filter); err != nil {
+		return StructuredQuery{}, err
+	}
+	return structured, nil
+}
+
+// checkAttributes checks that the filter only uses declared attributes.
+func (r *SelfQueryRetriever) checkAttributes(filter vectorstores.Filter) error {
+	for _, f := range filter.Filters {
+		if err := r.checkAttributes(f); err != nil {
+			return err
+		}
+	}
+	if filter.Key == "" {
+		return nil
+	}
+	for _, attribute := range r.Attributes {
+		if attribute.Name == filter.Key {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unknown attribute %q", ErrInvalidStructuredQuery, filter.Key)
+}
@@ -0,0 +1,134 @@
+package retrievers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/llms/fake"
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// filterStore is a vector store returning its documents matching the vectorstores.Filter.
+type filterStore struct {
+	docs    []schema.Document
+	query   string
+	filters any
+}
+
+func (s *filterStore) AddDocuments(context.Context, []schema.Document, ...vectorstores.Option) ([]string, error) {
+	return nil, nil
+}
+
+func (s *filterStore) SimilaritySearch(
+	_ context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := vectorstores.Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	s.query, s.filters = query, opts.Filters
+
+	filter, ok := vectorstores.GetFilter(opts.Filters)
+	docs := make([]schema.Document, 0)
+	for _, doc := range s.docs {
+		if len(docs) < numDocuments && (!ok || filter.Match(doc.Metadata)) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func TestSelfQueryRetriever(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store := &filterStore{docs: []schema.Document{
+		{PageContent: "billing outage", Metadata: map[string]any{"date": "2023-05-01", "team": "billing"}},
+		{PageContent: "invoice errors", Metadata: map[string]any{"date": "2024-02-10", "team": "billing"}},
+		{PageContent: "login failures", Metadata: map[string]any{"date": "2024-03-01", "team": "auth"}},
+	}}
+	attributes := []AttributeInfo{
+		{Name: "date", Type: "date", Description: "The day the incident started"},
+		{Name: "team", Type: "string", Description: "The team owning the incident"},
+	}
+	llm := fake.NewFakeLLM([]string{"```json\n" + `{"query": "billing", "filter": {"operator": "and", "filters": [
+		{"operator": "gte", "key": "date", "value": "2024-01-01"},
+		{"operator": "lt", "key": "date", "value": "2025-01-01"}]}}` + "\n```"})
+
+	retriever := NewSelfQueryRetriever(llm, store, "Incident reports", attributes)
+	docs, err := retriever.GetRelevantDocuments(ctx, "incidents from 2024 about billing")
+	require.NoError(t, err)
+	assert.Equal(t, "billing", store.query)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "invoice errors", docs[0].PageContent)
+	assert.Equal(t, "login failures", docs[1].PageContent)
+
+	retriever.FilterTranslator = func(filter vectorstores.Filter) (any, error) {
+		return map[string]any{"translated": string(filter.Operator)}, nil
+	}
+	_, err = retriever.GetRelevantDocuments(ctx, "incidents from 2024 about billing")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"translated": "and"}, store.filters)
+}
+
+func TestSelfQueryRetrieverStructureQuery(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	attributes := []AttributeInfo{{Name: "year", Type: "integer", Description: "Release year"}}
+
+	tests := []struct {
+		name       string
+		completion string
+		want       StructuredQuery
+		wantErr    bool
+	}{
+		{
+			name:       "no filter",
+			completion: `{"query": "space movies", "filter": null}`,
+			want:       StructuredQuery{Query: "space movies"},
+		},
+		{
+			name:       "filter only",
+			completion: `Response: {"query": "", "filter": {"operator": "eq", "key": "year", "value": 1999}}`,
+			want:       StructuredQuery{Filter: &vectorstores.Filter{Operator: "eq", Key: "year", Value: 1999.0}},
+		},
+		{
+			name:       "unknown attribute",
+			completion: `{"query": "movies", "filter": {"operator": "eq", "key": "genre", "value": "sf"}}`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid filter",
+			completion: `{"query": "movies", "filter": {"operator": "like", "key": "year", "value": 1}}`,
+			wantErr:    true,
+		},
+		{
+			name:       "no json",
+			completion: `I don't know`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			retriever := NewSelfQueryRetriever(fake.NewFakeLLM([]string{tt.completion}), &filterStore{}, "Movies", attributes)
+			got, err := retriever.StructureQuery(ctx, "question")
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidStructuredQuery)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,2 +1,6 @@
 // Package tools defines a standard interface for tools to be used by agents.
+//
+// A Tool takes a string input. A StructuredTool takes a JSON object of typed
+// arguments, described by a JSON schema that agents advertise to the model;
+// NewStructured derives this schema from the Go struct the arguments are decoded into.
 package tools
@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vxcontrol/langchaingo/jsonschema"
+)
+
+// ErrInvalidArguments is returned by structured tools called with arguments not
+// matching their schema. The agent executor gives such errors to the model as the
+// observation, so it can fix the arguments.
+var ErrInvalidArguments = errors.New("invalid tool arguments")
+
+// StructuredTool is a tool taking a JSON object of typed arguments as input.
+// Agents advertise its schema to the model instead of a single string argument.
+type StructuredTool interface {
+	Tool
+	// Schema returns the JSON schema of the arguments object.
+	Schema() jsonschema.Definition
+}
+
+// Structured is a StructuredTool decoding its input into a T, whose schema is derived
+// from the type with jsonschema.GenerateSchemaForType, before calling a function.
+type Structured[T any] struct {
+	name        string
+	description string
+	schema      jsonschema.Definition
+	fn          func(ctx context.Context, args T) (string, error)
+}
+
+var _ StructuredTool = &Structured[struct{}]{}
+
+// NewStructured creates a StructuredTool calling fn with the arguments decoded into a T,
+// typically a struct whose fields are tagged with json, description and enum tags.
+func NewStructured[T any](
+	name, description string,
+	fn func(ctx context.Context, args T) (string, error),
+) (*Structured[T], error) {
+	var args T
+	schema, err := jsonschema.GenerateSchemaForType(args)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Type != jsonschema.Object {
+		return nil, fmt.Errorf("%w: arguments must be an object, not %q", jsonschema.ErrUnsupportedType, schema.Type)
+	}
+	return NewStructuredWithSchema(name, description, *schema, fn), nil
+}
+
+// NewStructuredWithSchema creates a StructuredTool with an explicit schema, for
+// arguments whose constraints cannot be expressed with struct tags.
+func NewStructuredWithSchema[T any](
+	name, description string,
+	schema jsonschema.Definition,
+	fn func(ctx context.Context, args T) (string, error),
+) *Structured[T] {
+	return &Structured[T]{
+		name:        name,
+		description: description,
+		schema:      schema,
+		fn:          fn,
+	}
+}
+
+// Name returns the name of the tool.
+func (s *Structured[T]) Name() string {
+	return s.name
+}
+
+// Description returns the description of the tool.
+func (s *Structured[T]) Description() string {
+	return s.description
+}
+
+// Schema returns the JSON schema of the arguments of the tool.
+func (s *Structured[T]) Schema() jsonschema.Definition {
+	return s.schema
+}
+
+// Call validates the JSON arguments against the schema, decodes them and calls the
+// function of the tool. Invalid arguments return an error wrapping ErrInvalidArguments.
+func (s *Structured[T]) Call(ctx context.Context, input string) (string, error) {
+	args, err := DecodeArguments[T](s.schema, input)
+	if err != nil {
+		return "", err
+	}
+	return s.fn(ctx, args)
+}
+
+// DecodeArguments validates the JSON input of a structured tool against its schema
+// and decodes it into a T. Errors wrap ErrInvalidArguments.
+func DecodeArguments[T any](schema jsonschema.Definition, input string) (T, error) {
+	var args T
+	if err := jsonschema.VerifySchemaAndUnmarshal(schema, []byte(input), &args); err != nil {
+		return args, fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	return args, nil
+}
+
+// ArgumentsSchema returns the schema advertised to models for the arguments of the
+// tool: the schema of a StructuredTool, or else an object with a single "__arg1" string.
+func ArgumentsSchema(tool Tool) any {
+	if structured, ok := tool.(StructuredTool); ok {
+		return structured.Schema()
+	}
+	return map[string]any{
+		"properties": map[string]any{
+			"__arg1": map[string]string{"title": "__arg1", "type": "string"},
+		},
+		"required": []string{"__arg1"},
+		"type":     "object",
+	}
+}
+
+// Describe returns the description of the tool, followed for a StructuredTool by the
+// JSON schema of its arguments, for agents prompting models with tool descriptions.
+func Describe(tool Tool) string {
+	structured, ok := tool.(StructuredTool)
+	if !ok {
+		return tool.Description()
+	}
+	schema, err := json.Marshal(structured.Schema())
+	if err != nil {
+		return tool.Description()
+	}
+	return fmt.Sprintf("%s The input must be a JSON object matching this schema: %s", tool.Description(), schema)
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/jsonschema"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"The city name"`
+	Days int    `json:"days,omitempty"`
+}
+
+func TestStructured(t *testing.T) {
+	t.Parallel()
+
+	tool, err := NewStructured("weather", "Get the weather forecast.",
+		func(_ context.Context, args weatherArgs) (string, error) {
+			return fmt.Sprintf("%d days of sun in %s", args.Days, args.City), nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city": {Type: jsonschema.String, Description: "The city name"},
+			"days": {Type: jsonschema.Integer},
+		},
+		Required: []string{"city"},
+	}, ArgumentsSchema(tool))
+	assert.Contains(t, Describe(tool), `Get the weather forecast. The input must be a JSON object matching this schema: {"type":"object"`)
+
+	result, err := tool.Call(t.Context(), `{"city": "Paris", "days": 2}`)
+	require.NoError(t, err)
+	assert.Equal(t, "2 days of sun in Paris", result)
+
+	_, err = tool.Call(t.Context(), `{"days": 2}`)
+	require.ErrorIs(t, err, ErrInvalidArguments)
+	assert.Contains(t, err.Error(), `missing required property "city"`)
+
+	_, err = NewStructured("invalid", "Takes a string.", func(_ context.Context, _ string) (string, error) {
+		return "", nil
+	})
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
+
+func TestArgumentsSchema(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]any{
+		"properties": map[string]any{
+			"__arg1": map[string]string{"title": "__arg1", "type": "string"},
+		},
+		"required": []string{"__arg1"},
+		"type":     "object",
+	}, ArgumentsSchema(Calculator{}))
+	assert.Equal(t, Calculator{}.Description(), Describe(Calculator{}))
+}
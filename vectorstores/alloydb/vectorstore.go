@@ -3,6 +3,7 @@ package alloydb
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -48,7 +49,14 @@ type SearchDocument struct {
 	Distance          float32
 }
 
-var _ vectorstores.VectorStore = &VectorStore{}
+// ErrInvalidFilters is returned by DeleteDocumentsByFilter when the filters are
+// not a SQL condition.
+var ErrInvalidFilters = errors.New("filters must be a non-empty SQL condition")
+
+var (
+	_ vectorstores.VectorStore     = &VectorStore{}
+	_ vectorstores.DocumentManager = &VectorStore{}
+)
 
 // NewVectorStore creates a new VectorStore with options.
 func NewVectorStore(engine alloydbutil.PostgresEngine,
@@ -66,16 +74,8 @@ func NewVectorStore(engine alloydbutil.PostgresEngine,
 // AddDocuments adds documents to the Postgres collection, and returns the ids
 // of the added documents.
 func (vs *VectorStore) AddDocuments(ctx context.Context, docs []schema.Document, _ ...vectorstores.Option) ([]string, error) {
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
-	}
-	embeddings, err := vs.embedder.EmbedDocuments(ctx, texts)
-	if err != nil {
-		return nil, fmt.Errorf("failed embed documents: %w", err)
-	}
 	// If no ids provided, generate them.
-	ids := make([]string, len(texts))
+	ids := make([]string, len(docs))
 	for i, doc := range docs {
 		if val, ok := doc.Metadata["id"].(string); ok {
 			ids[i] = val
@@ -83,6 +83,149 @@ func (vs *VectorStore) AddDocuments(ctx context.Context, docs []schema.Document,
 			ids[i] = uuid.New().String()
 		}
 	}
+	if err := vs.insertDocuments(ctx, ids, docs, ""); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetDocuments returns the documents with the given ids in the order of ids.
+// The metadata of the documents is read from the metadata JSON column and the
+// metadata columns.
+func (vs *VectorStore) GetDocuments(ctx context.Context, ids []string, _ ...vectorstores.Option) ([]schema.Document, error) {
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	columns := []string{vs.idColumn + "::text", vs.contentColumn}
+	if vs.metadataJSONColumn != "" {
+		columns = append(columns, vs.metadataJSONColumn+"::text")
+	}
+	columns = append(columns, vs.metadataColumns...)
+	query := fmt.Sprintf(`SELECT %s FROM %q.%q WHERE %s::text = ANY($1)`,
+		strings.Join(columns, ", "), vs.schemaName, vs.tableName, vs.idColumn)
+	rows, err := vs.engine.Pool.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[string]schema.Document, len(ids))
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan result: %w", err)
+		}
+		id, doc, err := vs.documentFromValues(values)
+		if err != nil {
+			return nil, err
+		}
+		found[id] = doc
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// documentFromValues returns the id and the document of a row selected by GetDocuments.
+func (vs *VectorStore) documentFromValues(values []any) (string, schema.Document, error) {
+	id, _ := values[0].(string)
+	content, _ := values[1].(string)
+	values = values[2:]
+
+	metadata := make(map[string]any)
+	if vs.metadataJSONColumn != "" {
+		if metadataJSON, ok := values[0].(string); ok {
+			if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+				return "", schema.Document{}, fmt.Errorf("failed to unmarshal langchain metadata: %w", err)
+			}
+		}
+		values = values[1:]
+	}
+	for i, metadataColumn := range vs.metadataColumns {
+		if values[i] != nil {
+			metadata[metadataColumn] = values[i]
+		}
+	}
+
+	return id, schema.Document{PageContent: content, Metadata: metadata}, nil
+}
+
+// UpsertDocuments embeds the documents and stores them under the given ids,
+// replacing the documents that already exist with the same ids.
+func (vs *VectorStore) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	updates := []string{
+		fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", vs.contentColumn),
+		fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", vs.embeddingColumn),
+	}
+	for _, metadataColumn := range vs.metadataColumns {
+		updates = append(updates, fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", metadataColumn))
+	}
+	if vs.metadataJSONColumn != "" {
+		updates = append(updates, fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", vs.metadataJSONColumn))
+	}
+	onConflict := fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", vs.idColumn, strings.Join(updates, ", "))
+
+	if err := vs.insertDocuments(ctx, ids, docs, onConflict); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteDocuments removes the documents with the given ids.
+func (vs *VectorStore) DeleteDocuments(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := fmt.Sprintf(`DELETE FROM %q.%q WHERE %s::text = ANY($1)`, vs.schemaName, vs.tableName, vs.idColumn)
+	if _, err := vs.engine.Pool.Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return nil
+}
+
+// DeleteDocumentsByFilter removes the documents matching the filters. As for
+// SimilaritySearch, the filters are a SQL condition on the columns of the table,
+// an empty condition is rejected.
+func (vs *VectorStore) DeleteDocumentsByFilter(ctx context.Context, filters any, _ ...vectorstores.Option) error {
+	if filters == nil || strings.TrimSpace(fmt.Sprint(filters)) == "" {
+		return ErrInvalidFilters
+	}
+	query := fmt.Sprintf(`DELETE FROM %q.%q WHERE %s`, vs.schemaName, vs.tableName, filters)
+	if _, err := vs.engine.Pool.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return nil
+}
+
+// insertDocuments embeds the documents and inserts them under the given ids, with
+// the conflict clause appended to the insert statements.
+func (vs *VectorStore) insertDocuments(ctx context.Context, ids []string, docs []schema.Document, onConflict string) error {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	embeddings, err := vs.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed embed documents: %w", err)
+	}
 	// If no metadata provided, initialize with empty maps
 	metadatas := make([]map[string]any, len(docs))
 	for i := range docs {
@@ -101,17 +244,16 @@ func (vs *VectorStore) AddDocuments(ctx context.Context, docs []schema.Document,
 		metadata := metadatas[i]
 		query, values, err := vs.generateAddDocumentsQuery(id, content, embedding, metadata)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate query: %w", err)
+			return fmt.Errorf("failed to generate query: %w", err)
 		}
-		b.Queue(query, values...)
+		b.Queue(query+onConflict, values...)
 	}
 
 	batchResults := vs.engine.Pool.SendBatch(ctx, b)
 	if err := batchResults.Close(); err != nil {
-		return nil, fmt.Errorf("failed to execute batch: %w", err)
+		return fmt.Errorf("failed to execute batch: %w", err)
 	}
-
-	return ids, nil
+	return nil
 }
 
 func (vs *VectorStore) generateAddDocumentsQuery(id, content, embedding string, metadata map[string]any) (string, []any, error) {
@@ -652,6 +652,69 @@ func TestStore_RetrieveIndex(t *testing.T) {
 	assert.Equal(t, "test-index", result["name"])
 }
 
+func TestStore_DocumentManager(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/indexes/test-index/docs/doc-1":
+			err := json.NewEncoder(w).Encode(map[string]any{
+				"content":  "first",
+				"metadata": `{"source":"a"}`,
+			})
+			assert.NoError(t, err)
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/docs/search"):
+			var payload SearchDocumentsRequestInput
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, "source eq 'a'", payload.Filter)
+			assert.Equal(t, "id", payload.Select)
+			err := json.NewEncoder(w).Encode(map[string]any{
+				"value": []map[string]any{{"id": "doc-1"}, {"id": "doc-3"}},
+			})
+			assert.NoError(t, err)
+		case strings.HasSuffix(r.URL.Path, "/docs/index"):
+			var payload struct {
+				Value []map[string]any `json:"value"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			mu.Lock()
+			for _, action := range payload.Value {
+				assert.Equal(t, "delete", action["@search.action"])
+				deleted = append(deleted, fmt.Sprint(action["id"]))
+			}
+			mu.Unlock()
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	store := Store{
+		azureAISearchEndpoint: server.URL,
+		azureAISearchAPIKey:   "test-key",
+		client:                httputil.DefaultClient,
+		embedder:              &testEmbedder{},
+	}
+	ctx := t.Context()
+	namespace := vectorstores.WithNameSpace("test-index")
+
+	docs, err := store.GetDocuments(ctx, []string{"doc-1", "missing"}, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{{PageContent: "first", Metadata: map[string]any{"source": "a"}}}, docs)
+
+	require.NoError(t, store.DeleteDocuments(ctx, []string{"doc-2"}, namespace))
+	require.NoError(t, store.DeleteDocumentsByFilter(ctx, "source eq 'a'", namespace))
+	assert.Equal(t, []string{"doc-2", "doc-1", "doc-3"}, deleted)
+
+	require.ErrorIs(t, store.DeleteDocumentsByFilter(ctx, nil, namespace), ErrInvalidFilters)
+	_, err = store.UpsertDocuments(ctx, []string{"doc-1"}, nil, namespace)
+	require.ErrorIs(t, err, vectorstores.ErrIDsDocumentsMismatch)
+}
+
 func TestAssertResultValues(t *testing.T) {
 	t.Parallel()
 
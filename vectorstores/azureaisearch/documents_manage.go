@@ -0,0 +1,197 @@
+package azureaisearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+// documentsBatchSize is the number of documents of a search page or an indexing request
+// of DeleteDocumentsByFilter.
+const documentsBatchSize = 1000
+
+// ErrInvalidFilters is returned by DeleteDocumentsByFilter when the filters are not an
+// OData filter expression.
+var ErrInvalidFilters = errors.New("filters must be a non-empty OData filter expression")
+
+var _ vectorstores.DocumentManager = &Store{}
+
+// storedDocument is a document as returned by a lookup.
+type storedDocument struct {
+	Content  string `json:"content"`
+	Metadata string `json:"metadata"`
+}
+
+// GetDocuments looks up the documents with the given ids in the index set with
+// WithNameSpace, and returns them in the order of ids.
+func (s *Store) GetDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	docs := make([]schema.Document, 0, len(ids))
+	for _, id := range ids {
+		doc, found, err := s.LookupDocument(ctx, opts.NameSpace, id)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// LookupDocument makes a request to azure AI search to get the document with the id.
+// found is false when the index has no document with the id.
+func (s *Store) LookupDocument(ctx context.Context, indexName string, id string) (schema.Document, bool, error) {
+	URL := fmt.Sprintf("%s/indexes/%s/docs/%s?api-version=2020-06-30&$select=content,metadata",
+		s.azureAISearchEndpoint, indexName, url.PathEscape(id))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, URL, nil)
+	if err != nil {
+		return schema.Document{}, false, fmt.Errorf("err setting request for azure ai search lookup document: %w", err)
+	}
+	if s.azureAISearchAPIKey != "" {
+		req.Header.Add("api-key", s.azureAISearchAPIKey)
+	}
+
+	response, err := s.client.Do(req)
+	if err != nil {
+		return schema.Document{}, false, fmt.Errorf("err sending request for azure ai search lookup document: %w", err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return schema.Document{}, false, nil
+	}
+
+	var stored storedDocument
+	if err := httpReadBody(response, "azure ai search lookup document", &stored); err != nil {
+		return schema.Document{}, false, err
+	}
+	metadata := map[string]any{}
+	if stored.Metadata != "" {
+		if err := json.Unmarshal([]byte(stored.Metadata), &metadata); err != nil {
+			return schema.Document{}, false, fmt.Errorf("couldn't unmarshall metadata %w", err)
+		}
+	}
+	return schema.Document{PageContent: stored.Content, Metadata: metadata}, true, nil
+}
+
+// UpsertDocuments embeds the documents and uploads them under the given ids to the
+// index set with WithNameSpace, replacing the documents with the same ids.
+func (s *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrNumberOfVectorDoesNotMatch
+	}
+
+	for i, doc := range docs {
+		if err := s.UploadDocument(ctx, ids[i], opts.NameSpace, doc.PageContent, vectors[i], doc.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// DeleteDocuments removes the documents with the given ids from the index set with
+// WithNameSpace.
+func (s *Store) DeleteDocuments(ctx context.Context, ids []string, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	for start := 0; start < len(ids); start += documentsBatchSize {
+		batch := ids[start:min(start+documentsBatchSize, len(ids))]
+		if err := s.DeleteDocumentsAPIRequest(ctx, opts.NameSpace, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteDocumentsByFilter removes the documents matching the filters from the index
+// set with WithNameSpace. As for SimilaritySearch the filters are an OData filter
+// expression. The ids of the matching documents are searched first, then deleted.
+func (s *Store) DeleteDocumentsByFilter(ctx context.Context, filters any, options ...vectorstores.Option) error {
+	opts := s.getOptions(options...)
+	filter, ok := filters.(string)
+	if !ok || filter == "" {
+		return ErrInvalidFilters
+	}
+
+	var ids []string
+	for {
+		payload := SearchDocumentsRequestInput{
+			Search: "*",
+			Filter: filter,
+			Select: "id",
+			Top:    documentsBatchSize,
+			Skip:   len(ids),
+		}
+		searchResults := SearchDocumentsRequestOuput{}
+		if err := s.SearchDocuments(ctx, opts.NameSpace, payload, &searchResults); err != nil {
+			return err
+		}
+		for _, result := range searchResults.Value {
+			if id, ok := result["id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+		if len(searchResults.Value) < documentsBatchSize {
+			break
+		}
+	}
+
+	return s.DeleteDocuments(ctx, ids, options...)
+}
+
+// DeleteDocumentsAPIRequest makes a request to azure AI search to delete documents.
+func (s *Store) DeleteDocumentsAPIRequest(ctx context.Context, indexName string, ids []string) error {
+	URL := fmt.Sprintf("%s/indexes/%s/docs/index?api-version=2020-06-30", s.azureAISearchEndpoint, indexName)
+
+	actions := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		actions = append(actions, map[string]any{
+			"@search.action": "delete",
+			"id":             id,
+		})
+	}
+	body, err := json.Marshal(map[string]any{"value": actions})
+	if err != nil {
+		return fmt.Errorf("err marshalling body for azure ai search: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, URL, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("err setting request for azure ai search delete documents: %w", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	if s.azureAISearchAPIKey != "" {
+		req.Header.Add("api-key", s.azureAISearchAPIKey)
+	}
+
+	return s.httpDefaultSend(req, "azure ai search delete documents", nil)
+}
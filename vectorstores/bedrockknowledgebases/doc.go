@@ -1 +1,5 @@
+// Package bedrockknowledgebases implements a vector store on top of Amazon Bedrock
+// Knowledge Bases. The documents are uploaded to the S3 data sources of the knowledge
+// base, which the service ingests and indexes itself, so the store does not implement
+// vectorstores.DocumentManager.
 package bedrockknowledgebases
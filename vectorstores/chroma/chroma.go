@@ -20,6 +20,7 @@ var (
 	ErrUnexpectedResponseLength = errors.New("unexpected length of response")
 	ErrNewClient                = errors.New("error creating collection")
 	ErrAddDocument              = errors.New("error adding document")
+	ErrDeleteDocument           = errors.New("error deleting document")
 	ErrRemoveCollection         = errors.New("error resetting collection")
 	ErrUnsupportedOptions       = errors.New("unsupported options")
 )
@@ -37,7 +38,11 @@ type Store struct {
 	includes     []chromatypes.QueryEnum
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore              = Store{}
+	_ vectorstores.DocumentManager          = Store{}
+	_ vectorstores.VectorSimilaritySearcher = Store{}
+)
 
 // New creates an active client connection to the (specified, or default) collection in the Chroma server
 // and returns the `Store` object needed by the other accessors.
@@ -80,63 +85,231 @@ func (s Store) AddDocuments(ctx context.Context,
 	docs []schema.Document,
 	options ...vectorstores.Option,
 ) ([]string, error) {
+	ids := make([]string, len(docs))
+	for docIdx := range docs {
+		ids[docIdx] = uuid.New().String() // TODO (noodnik2): find & use something more meaningful
+	}
+
+	texts, metadatas, err := s.prepareDocuments(docs, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	col := s.collection
+	if _, addErr := col.Add(ctx, nil, metadatas, texts, ids); addErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAddDocument, addErr)
+	}
+	return ids, nil
+}
+
+// GetDocuments returns the documents with the given ids from the Chroma collection
+// in the order of ids. Only documents of the store (or WithNameSpace) nameSpace are returned.
+func (s Store) GetDocuments(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 	if opts.Embedder != nil || opts.ScoreThreshold != 0 || opts.Filters != nil {
 		return nil, ErrUnsupportedOptions
 	}
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
 
-	nameSpace := s.getNameSpace(opts)
-	if nameSpace != "" && s.nameSpaceKey == "" {
-		return nil, fmt.Errorf("%w: nameSpace without nameSpaceKey", ErrUnsupportedOptions)
+	includes := []chromatypes.QueryEnum{chromatypes.IDocuments, chromatypes.IMetadatas}
+	filter, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+	gr, getErr := s.collection.Get(ctx, filter, nil, ids, includes)
+	if getErr != nil {
+		return nil, getErr
 	}
 
-	ids := make([]string, len(docs))
-	texts := make([]string, len(docs))
-	metadatas := make([]map[string]any, len(docs))
-	for docIdx, doc := range docs {
-		ids[docIdx] = uuid.New().String() // TODO (noodnik2): find & use something more meaningful
-		texts[docIdx] = doc.PageContent
-		mc := make(map[string]any, 0)
-		maps.Copy(mc, doc.Metadata)
-		metadatas[docIdx] = mc
-		if nameSpace != "" {
-			metadatas[docIdx][s.nameSpaceKey] = nameSpace
+	if len(gr.Ids) != len(gr.Documents) || len(gr.Ids) != len(gr.Metadatas) {
+		return nil, fmt.Errorf("%w: gr.Ids[%d], gr.Documents[%d], gr.Metadatas[%d]",
+			ErrUnexpectedResponseLength, len(gr.Ids), len(gr.Documents), len(gr.Metadatas))
+	}
+
+	found := make(map[string]schema.Document, len(gr.Ids))
+	for i, id := range gr.Ids {
+		found[id] = schema.Document{
+			PageContent: gr.Documents[i],
+			Metadata:    gr.Metadatas[i],
 		}
 	}
 
-	col := s.collection
-	if _, addErr := col.Add(ctx, nil, metadatas, texts, ids); addErr != nil {
-		return nil, fmt.Errorf("%w: %w", ErrAddDocument, addErr)
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// UpsertDocuments adds or replaces the documents with the given ids in the Chroma collection.
+func (s Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	texts, metadatas, err := s.prepareDocuments(docs, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, upsertErr := s.collection.Upsert(ctx, nil, metadatas, texts, ids); upsertErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAddDocument, upsertErr)
 	}
 	return ids, nil
 }
 
+// DeleteDocuments removes the documents with the given ids from the Chroma collection.
+func (s Store) DeleteDocuments(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if opts.Embedder != nil || opts.ScoreThreshold != 0 || opts.Filters != nil {
+		return ErrUnsupportedOptions
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	filter, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return err
+	}
+	if _, delErr := s.collection.Delete(ctx, ids, filter, nil); delErr != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteDocument, delErr)
+	}
+	return nil
+}
+
+// DeleteDocumentsByFilter removes the documents matching the Chroma "where" filter
+// or the vectorstores.Filter from the store (or WithNameSpace) nameSpace.
+func (s Store) DeleteDocumentsByFilter(ctx context.Context,
+	filters any,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if opts.Embedder != nil || opts.ScoreThreshold != 0 || opts.Filters != nil {
+		return ErrUnsupportedOptions
+	}
+
+	if _, ok := vectorstores.GetFilter(filters); !ok {
+		filter, ok := filters.(map[string]any)
+		if !ok || len(filter) == 0 {
+			return fmt.Errorf("%w: filters must be a non-empty map or a vectorstores.Filter", ErrUnsupportedOptions)
+		}
+	}
+	opts.Filters = filters
+
+	filter, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return err
+	}
+	if _, delErr := s.collection.Delete(ctx, nil, filter, nil); delErr != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteDocument, delErr)
+	}
+	return nil
+}
+
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int,
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
+	docs, _, _, err := s.similaritySearch(ctx, query, numDocuments, options...)
+	return docs, err
+}
+
+// SimilaritySearchWithVectors searches like SimilaritySearch and returns the embedding
+// of the query and the stored embeddings of the documents found, so that
+// vectorstores.MaxMarginalRelevanceSearch does not embed the documents again.
+func (s Store) SimilaritySearchWithVectors(ctx context.Context, query string, numDocuments int,
+	options ...vectorstores.Option,
+) (vectorstores.VectorSearchResult, error) {
+	docs, ids, qr, err := s.similaritySearch(ctx, query, numDocuments, options...)
+	if err != nil {
+		return vectorstores.VectorSearchResult{}, err
+	}
+	if len(qr.QueryTextsGeneratedEmbeddings) != 1 || qr.QueryTextsGeneratedEmbeddings[0].GetFloat32() == nil {
+		return vectorstores.VectorSearchResult{}, fmt.Errorf("%w: qr.QueryTextsGeneratedEmbeddings[%d]",
+			ErrUnexpectedResponseLength, len(qr.QueryTextsGeneratedEmbeddings))
+	}
+	result := vectorstores.VectorSearchResult{
+		QueryVector: *qr.QueryTextsGeneratedEmbeddings[0].GetFloat32(),
+		Documents:   docs,
+		Vectors:     make([][]float32, 0, len(docs)),
+	}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	// the query results of the client have no embeddings, they are fetched by id
+	gr, getErr := s.collection.Get(ctx, nil, nil, ids, []chromatypes.QueryEnum{chromatypes.IEmbeddings})
+	if getErr != nil {
+		return vectorstores.VectorSearchResult{}, getErr
+	}
+	if len(gr.Ids) != len(gr.Embeddings) {
+		return vectorstores.VectorSearchResult{}, fmt.Errorf("%w: gr.Ids[%d], gr.Embeddings[%d]",
+			ErrUnexpectedResponseLength, len(gr.Ids), len(gr.Embeddings))
+	}
+	vectors := make(map[string][]float32, len(gr.Ids))
+	for i, id := range gr.Ids {
+		if vector := gr.Embeddings[i].GetFloat32(); vector != nil {
+			vectors[id] = *vector
+		}
+	}
+	for _, id := range ids {
+		vector, ok := vectors[id]
+		if !ok {
+			return vectorstores.VectorSearchResult{}, fmt.Errorf("%w: no embedding for document %s",
+				ErrUnexpectedResponseLength, id)
+		}
+		result.Vectors = append(result.Vectors, vector)
+	}
+	return result, nil
+}
+
+// similaritySearch returns the documents most similar to the query above the score
+// threshold, their ids and the query results.
+func (s Store) similaritySearch(ctx context.Context, query string, numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, []string, *chromago.QueryResults, error) {
 	opts := s.getOptions(options...)
 
 	if opts.Embedder != nil {
 		// embedder is not used by this method, so shouldn't ever be specified
-		return nil, fmt.Errorf("%w: Embedder", ErrUnsupportedOptions)
+		return nil, nil, nil, fmt.Errorf("%w: Embedder", ErrUnsupportedOptions)
 	}
 
 	scoreThreshold, stErr := s.getScoreThreshold(opts)
 	if stErr != nil {
-		return nil, stErr
+		return nil, nil, nil, stErr
 	}
 
-	filter := s.getNamespacedFilter(opts)
+	filter, err := s.getNamespacedFilter(opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	qr, queryErr := s.collection.Query(ctx, []string{query}, safeIntToInt32(numDocuments), filter, nil, s.includes)
 	if queryErr != nil {
-		return nil, queryErr
+		return nil, nil, nil, queryErr
 	}
 
-	if len(qr.Documents) != len(qr.Metadatas) || len(qr.Metadatas) != len(qr.Distances) {
-		return nil, fmt.Errorf("%w: qr.Documents[%d], qr.Metadatas[%d], qr.Distances[%d]",
-			ErrUnexpectedResponseLength, len(qr.Documents), len(qr.Metadatas), len(qr.Distances))
+	if len(qr.Documents) != len(qr.Metadatas) || len(qr.Metadatas) != len(qr.Distances) ||
+		len(qr.Distances) != len(qr.Ids) {
+		return nil, nil, nil, fmt.Errorf("%w: qr.Documents[%d], qr.Metadatas[%d], qr.Distances[%d], qr.Ids[%d]",
+			ErrUnexpectedResponseLength, len(qr.Documents), len(qr.Metadatas), len(qr.Distances), len(qr.Ids))
 	}
 	var sDocs []schema.Document
+	var ids []string
 	for docsI := range qr.Documents {
 		for docI := range qr.Documents[docsI] {
 			if score := 1.0 - qr.Distances[docsI][docI]; score >= scoreThreshold {
@@ -145,11 +318,12 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 					PageContent: qr.Documents[docsI][docI],
 					Score:       score,
 				})
+				ids = append(ids, qr.Ids[docsI][docI])
 			}
 		}
 	}
 
-	return sDocs, nil
+	return sDocs, ids, qr, nil
 }
 
 func (s Store) RemoveCollection() error {
@@ -163,6 +337,36 @@ func (s Store) RemoveCollection() error {
 	return nil
 }
 
+// prepareDocuments returns the texts and the metadatas (including the nameSpace key)
+// of the documents to be stored in the Chroma collection.
+func (s Store) prepareDocuments(
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, []map[string]any, error) {
+	opts := s.getOptions(options...)
+	if opts.Embedder != nil || opts.ScoreThreshold != 0 || opts.Filters != nil {
+		return nil, nil, ErrUnsupportedOptions
+	}
+
+	nameSpace := s.getNameSpace(opts)
+	if nameSpace != "" && s.nameSpaceKey == "" {
+		return nil, nil, fmt.Errorf("%w: nameSpace without nameSpaceKey", ErrUnsupportedOptions)
+	}
+
+	texts := make([]string, len(docs))
+	metadatas := make([]map[string]any, len(docs))
+	for docIdx, doc := range docs {
+		texts[docIdx] = doc.PageContent
+		mc := make(map[string]any, 0)
+		maps.Copy(mc, doc.Metadata)
+		metadatas[docIdx] = mc
+		if nameSpace != "" {
+			metadatas[docIdx][s.nameSpaceKey] = nameSpace
+		}
+	}
+	return texts, metadatas, nil
+}
+
 func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	opts := vectorstores.Options{}
 	for _, opt := range options {
@@ -185,20 +389,30 @@ func (s Store) getNameSpace(opts vectorstores.Options) string {
 	return s.nameSpace
 }
 
-func (s Store) getNamespacedFilter(opts vectorstores.Options) map[string]any {
+func (s Store) getNamespacedFilter(opts vectorstores.Options) (map[string]any, error) {
 	filter, _ := opts.Filters.(map[string]any)
+	if f, ok := vectorstores.GetFilter(opts.Filters); ok {
+		if err := f.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnsupportedOptions, err)
+		}
+		where, err := filterToWhere(f)
+		if err != nil {
+			return nil, err
+		}
+		filter = where
+	}
 
 	nameSpace := s.getNameSpace(opts)
 	if nameSpace == "" || s.nameSpaceKey == "" {
-		return filter
+		return filter, nil
 	}
 
 	nameSpaceFilter := map[string]any{s.nameSpaceKey: nameSpace}
 	if filter == nil {
-		return nameSpaceFilter
+		return nameSpaceFilter, nil
 	}
 
-	return map[string]any{"$and": []map[string]any{nameSpaceFilter, filter}}
+	return map[string]any{"$and": []map[string]any{nameSpaceFilter, filter}}, nil
 }
 
 func safeIntToInt32(n int) int32 {
@@ -0,0 +1,82 @@
+package chroma
+
+import (
+	"fmt"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+// negatedOperators maps the comparison operators to their negation. Only the negations
+// of Ne and Nin are comparisons matching the same documents: Chroma comparisons never
+// match documents missing the key, which the negations of the other operators match.
+var negatedOperators = map[vectorstores.FilterOperator]vectorstores.FilterOperator{
+	vectorstores.FilterNe:  vectorstores.FilterEq,
+	vectorstores.FilterNin: vectorstores.FilterIn,
+}
+
+// filterToWhere translates the filter into a Chroma "where" filter. Chroma has no
+// negation and no existence operator, so Not is pushed down to the Ne and Nin
+// comparisons, other negated comparisons and Exists are not supported. Chroma metadata
+// is flat, so keys are used verbatim.
+func filterToWhere(filter vectorstores.Filter) (map[string]any, error) {
+	switch filter.Operator {
+	case vectorstores.FilterAnd, vectorstores.FilterOr:
+		if len(filter.Filters) == 1 {
+			// Chroma requires at least two filters for $and and $or
+			return filterToWhere(filter.Filters[0])
+		}
+		wheres := make([]map[string]any, 0, len(filter.Filters))
+		for _, f := range filter.Filters {
+			where, err := filterToWhere(f)
+			if err != nil {
+				return nil, err
+			}
+			wheres = append(wheres, where)
+		}
+		return map[string]any{"$" + string(filter.Operator): wheres}, nil
+	case vectorstores.FilterNot:
+		negated, err := negateFilter(filter.Filters[0])
+		if err != nil {
+			return nil, err
+		}
+		return filterToWhere(negated)
+	case vectorstores.FilterEq, vectorstores.FilterNe, vectorstores.FilterGt, vectorstores.FilterGte,
+		vectorstores.FilterLt, vectorstores.FilterLte:
+		return map[string]any{filter.Key: map[string]any{"$" + string(filter.Operator): filter.Value}}, nil
+	case vectorstores.FilterIn, vectorstores.FilterNin:
+		values, _ := filter.Values()
+		return map[string]any{filter.Key: map[string]any{"$" + string(filter.Operator): values}}, nil
+	case vectorstores.FilterExists:
+		return nil, fmt.Errorf("%w: %q", vectorstores.ErrUnsupportedFilter, filter.Operator)
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", vectorstores.ErrInvalidFilter, filter.Operator)
+	}
+}
+
+// negateFilter returns the negation of the filter without the Not operator.
+func negateFilter(filter vectorstores.Filter) (vectorstores.Filter, error) {
+	switch filter.Operator {
+	case vectorstores.FilterNot:
+		return filter.Filters[0], nil
+	case vectorstores.FilterAnd, vectorstores.FilterOr:
+		negated := make([]vectorstores.Filter, 0, len(filter.Filters))
+		for _, f := range filter.Filters {
+			n, err := negateFilter(f)
+			if err != nil {
+				return vectorstores.Filter{}, err
+			}
+			negated = append(negated, n)
+		}
+		if filter.Operator == vectorstores.FilterAnd {
+			return vectorstores.Or(negated...), nil
+		}
+		return vectorstores.And(negated...), nil
+	}
+
+	operator, ok := negatedOperators[filter.Operator]
+	if !ok {
+		return vectorstores.Filter{}, fmt.Errorf("%w: negated %q", vectorstores.ErrUnsupportedFilter, filter.Operator)
+	}
+	filter.Operator = operator
+	return filter, nil
+}
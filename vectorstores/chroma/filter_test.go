@@ -0,0 +1,47 @@
+package chroma
+
+import (
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterToWhere(t *testing.T) {
+	t.Parallel()
+
+	where, err := filterToWhere(vectorstores.And(
+		vectorstores.Eq("source", "a.txt"),
+		vectorstores.Not(vectorstores.Or(vectorstores.Ne("year", 2024), vectorstores.Nin("tag", "draft"))),
+	))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"$and": []map[string]any{
+			{"source": map[string]any{"$eq": "a.txt"}},
+			{"$and": []map[string]any{
+				{"year": map[string]any{"$eq": 2024}},
+				{"tag": map[string]any{"$in": []any{"draft"}}},
+			}},
+		},
+	}, where)
+
+	where, err = filterToWhere(vectorstores.Or(vectorstores.Ne("source", "a.txt")))
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"source": map[string]any{"$ne": "a.txt"}}, where)
+
+	_, err = filterToWhere(vectorstores.Not(vectorstores.Exists("source")))
+	require.ErrorIs(t, err, vectorstores.ErrUnsupportedFilter)
+
+	// the negated comparisons would not match the documents missing the key
+	for _, filter := range []vectorstores.Filter{
+		vectorstores.Eq("year", 2024),
+		vectorstores.Gt("year", 2024),
+		vectorstores.Lte("year", 2024),
+		vectorstores.In("tag", "draft"),
+		vectorstores.And(vectorstores.Ne("tag", "draft"), vectorstores.Lt("year", 2024)),
+	} {
+		_, err = filterToWhere(vectorstores.Not(filter))
+		require.ErrorIs(t, err, vectorstores.ErrUnsupportedFilter)
+	}
+}
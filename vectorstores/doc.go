@@ -5,6 +5,16 @@ and querying documents as vector embeddings.
 The main components of this package are:
 
 - VectorStore interface: a common interface for saving and querying vector embeddings of documents.
+- DocumentManager interface: an optional interface for fetching, upserting and deleting stored documents,
+  implemented by every store but bedrockknowledgebases, whose documents are ingested by the service
+  from their data sources. Milvus collections generate the primary keys, so its UpsertDocuments fails.
+- MaxMarginalRelevanceSearch: a search selecting relevant but diverse documents, implemented natively
+  by stores that implement MaxMarginalRelevanceSearcher, with the stored vectors of the candidates by
+  stores that implement VectorSimilaritySearcher, and generically on top of SimilaritySearch otherwise.
+- Filter: a metadata filter expression (Eq, In, Gt, Exists, And, Or, Not...) passed to WithFilters,
+  evaluated by the in-memory store and translated into the native filter syntax of other stores.
+  Stores fail with ErrUnsupportedFilter on the filters they cannot express with the same meaning,
+  e.g. Chroma on Exists and on the negation of comparisons other than Ne and Nin.
 - Options: a set of options for similarity search and document addition.
 - Retriever: a retriever for vector stores that implements the schema.Retriever interface.
 
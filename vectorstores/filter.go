@@ -0,0 +1,386 @@
+package vectorstores
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrInvalidFilter is returned when a Filter is malformed, see Filter.Validate.
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrUnsupportedFilter is returned by vector stores that can not translate
+	// a Filter into their native filter syntax.
+	ErrUnsupportedFilter = errors.New("unsupported filter")
+)
+
+// FilterOperator is the operator of a Filter.
+type FilterOperator string
+
+const (
+	// FilterEq matches documents whose metadata value equals the filter value.
+	FilterEq FilterOperator = "eq"
+	// FilterNe matches documents whose metadata value does not equal the filter value,
+	// including documents without the key.
+	FilterNe FilterOperator = "ne"
+	// FilterGt matches documents whose metadata value is greater than the filter value.
+	FilterGt FilterOperator = "gt"
+	// FilterGte matches documents whose metadata value is greater than or equal to the filter value.
+	FilterGte FilterOperator = "gte"
+	// FilterLt matches documents whose metadata value is less than the filter value.
+	FilterLt FilterOperator = "lt"
+	// FilterLte matches documents whose metadata value is less than or equal to the filter value.
+	FilterLte FilterOperator = "lte"
+	// FilterIn matches documents whose metadata value equals one of the filter values.
+	FilterIn FilterOperator = "in"
+	// FilterNin matches documents whose metadata value equals none of the filter values,
+	// including documents without the key.
+	FilterNin FilterOperator = "nin"
+	// FilterExists matches documents that have a non-null metadata value for the key.
+	FilterExists FilterOperator = "exists"
+	// FilterAnd matches documents matching all the sub-filters.
+	FilterAnd FilterOperator = "and"
+	// FilterOr matches documents matching at least one of the sub-filters.
+	FilterOr FilterOperator = "or"
+	// FilterNot matches documents not matching its single sub-filter.
+	FilterNot FilterOperator = "not"
+)
+
+// Filter is a metadata filter expression that can be passed to WithFilters and
+// to DocumentManager.DeleteDocumentsByFilter. It is evaluated by the in-memory store
+// and translated into the native filter syntax by the other vector stores.
+//
+// Leaf filters compare the metadata value at Key with Value. Key may address
+// nested metadata with dots ("author.name"); a key that exists verbatim in the
+// metadata takes precedence over the nested lookup. Values must be strings,
+// numbers or booleans; In and Nin take a list of them. When the metadata value is
+// a list, a comparison matches if any of its elements matches.
+//
+// Filters are JSON serializable, so they can be stored or generated by an LLM.
+type Filter struct {
+	Operator FilterOperator `json:"operator"`
+	Key      string         `json:"key,omitempty"`
+	Value    any            `json:"value,omitempty"`
+	Filters  []Filter       `json:"filters,omitempty"`
+}
+
+// Eq returns a filter matching documents whose metadata value at key equals value.
+func Eq(key string, value any) Filter {
+	return Filter{Operator: FilterEq, Key: key, Value: value}
+}
+
+// Ne returns a filter matching documents whose metadata value at key does not equal value.
+func Ne(key string, value any) Filter {
+	return Filter{Operator: FilterNe, Key: key, Value: value}
+}
+
+// Gt returns a filter matching documents whose metadata value at key is greater than value.
+func Gt(key string, value any) Filter {
+	return Filter{Operator: FilterGt, Key: key, Value: value}
+}
+
+// Gte returns a filter matching documents whose metadata value at key is greater than or equal to value.
+func Gte(key string, value any) Filter {
+	return Filter{Operator: FilterGte, Key: key, Value: value}
+}
+
+// Lt returns a filter matching documents whose metadata value at key is less than value.
+func Lt(key string, value any) Filter {
+	return Filter{Operator: FilterLt, Key: key, Value: value}
+}
+
+// Lte returns a filter matching documents whose metadata value at key is less than or equal to value.
+func Lte(key string, value any) Filter {
+	return Filter{Operator: FilterLte, Key: key, Value: value}
+}
+
+// In returns a filter matching documents whose metadata value at key equals one of values.
+func In(key string, values ...any) Filter {
+	return Filter{Operator: FilterIn, Key: key, Value: values}
+}
+
+// Nin returns a filter matching documents whose metadata value at key equals none of values.
+func Nin(key string, values ...any) Filter {
+	return Filter{Operator: FilterNin, Key: key, Value: values}
+}
+
+// Exists returns a filter matching documents that have a non-null metadata value at key.
+func Exists(key string) Filter {
+	return Filter{Operator: FilterExists, Key: key}
+}
+
+// And returns a filter matching documents that match all the filters.
+func And(filters ...Filter) Filter {
+	return Filter{Operator: FilterAnd, Filters: filters}
+}
+
+// Or returns a filter matching documents that match at least one of the filters.
+func Or(filters ...Filter) Filter {
+	return Filter{Operator: FilterOr, Filters: filters}
+}
+
+// Not returns a filter matching documents that do not match the filter.
+func Not(filter Filter) Filter {
+	return Filter{Operator: FilterNot, Filters: []Filter{filter}}
+}
+
+// GetFilter returns the Filter given to WithFilters, either as a Filter or a *Filter.
+// The second value reports whether filters holds a Filter.
+func GetFilter(filters any) (Filter, bool) {
+	switch f := filters.(type) {
+	case Filter:
+		return f, true
+	case *Filter:
+		if f != nil {
+			return *f, true
+		}
+	}
+	return Filter{}, false
+}
+
+// Validate checks that the filter and its sub-filters are well formed.
+func (f Filter) Validate() error {
+	switch f.Operator {
+	case FilterAnd, FilterOr:
+		if len(f.Filters) == 0 {
+			return fmt.Errorf("%w: %q requires at least one filter", ErrInvalidFilter, f.Operator)
+		}
+	case FilterNot:
+		if len(f.Filters) != 1 {
+			return fmt.Errorf("%w: %q requires exactly one filter", ErrInvalidFilter, f.Operator)
+		}
+	case FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn, FilterNin, FilterExists:
+		return f.validateLeaf()
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, f.Operator)
+	}
+
+	for _, filter := range f.Filters {
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLeaf checks the key and the value of a comparison filter.
+func (f Filter) validateLeaf() error {
+	if f.Key == "" {
+		return fmt.Errorf("%w: %q requires a key", ErrInvalidFilter, f.Operator)
+	}
+
+	switch f.Operator {
+	case FilterExists:
+		return nil
+	case FilterIn, FilterNin:
+		values, ok := f.Values()
+		if !ok {
+			return fmt.Errorf("%w: %q requires a list of values", ErrInvalidFilter, f.Operator)
+		}
+		for _, value := range values {
+			if !isScalar(value) {
+				return fmt.Errorf("%w: unsupported value %v for %q", ErrInvalidFilter, value, f.Key)
+			}
+		}
+		return nil
+	case FilterGt, FilterGte, FilterLt, FilterLte:
+		if _, ok := toFloat64(f.Value); !ok {
+			if _, ok := f.Value.(string); !ok {
+				return fmt.Errorf("%w: %q requires a number or a string", ErrInvalidFilter, f.Operator)
+			}
+		}
+		return nil
+	default:
+		if !isScalar(f.Value) {
+			return fmt.Errorf("%w: unsupported value %v for %q", ErrInvalidFilter, f.Value, f.Key)
+		}
+		return nil
+	}
+}
+
+// Values returns the values of an In or Nin filter. The second value reports
+// whether Value is a list.
+func (f Filter) Values() ([]any, bool) {
+	return toList(f.Value)
+}
+
+// Match reports whether the metadata matches the filter. The filter is expected
+// to be valid, see Validate.
+func (f Filter) Match(metadata map[string]any) bool {
+	switch f.Operator {
+	case FilterAnd:
+		for _, filter := range f.Filters {
+			if !filter.Match(metadata) {
+				return false
+			}
+		}
+		return true
+	case FilterOr:
+		for _, filter := range f.Filters {
+			if filter.Match(metadata) {
+				return true
+			}
+		}
+		return false
+	case FilterNot:
+		return len(f.Filters) == 1 && !f.Filters[0].Match(metadata)
+	case FilterNe:
+		return !Eq(f.Key, f.Value).Match(metadata)
+	case FilterNin:
+		return !Filter{Operator: FilterIn, Key: f.Key, Value: f.Value}.Match(metadata)
+	}
+
+	value, ok := LookupMetadata(metadata, f.Key)
+	if !ok || value == nil {
+		return false
+	}
+	if f.Operator == FilterExists {
+		return true
+	}
+
+	// a list matches when any of its elements matches
+	elements, ok := toList(value)
+	if !ok {
+		elements = []any{value}
+	}
+	for _, element := range elements {
+		if f.matchValue(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchValue compares a single metadata value with the filter value.
+func (f Filter) matchValue(value any) bool {
+	switch f.Operator {
+	case FilterEq:
+		return equalValues(value, f.Value)
+	case FilterIn:
+		values, _ := f.Values()
+		for _, v := range values {
+			if equalValues(value, v) {
+				return true
+			}
+		}
+		return false
+	case FilterGt:
+		cmp, ok := compareValues(value, f.Value)
+		return ok && cmp > 0
+	case FilterGte:
+		cmp, ok := compareValues(value, f.Value)
+		return ok && cmp >= 0
+	case FilterLt:
+		cmp, ok := compareValues(value, f.Value)
+		return ok && cmp < 0
+	case FilterLte:
+		cmp, ok := compareValues(value, f.Value)
+		return ok && cmp <= 0
+	default:
+		return false
+	}
+}
+
+// LookupMetadata returns the metadata value at key. If the key is not present
+// verbatim, it is split on dots to look up nested maps.
+func LookupMetadata(metadata map[string]any, key string) (any, bool) {
+	if value, ok := metadata[key]; ok {
+		return value, true
+	}
+
+	head, rest, found := strings.Cut(key, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := metadata[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return LookupMetadata(nested, rest)
+}
+
+// isScalar reports whether the value is a string, a number or a boolean.
+func isScalar(value any) bool {
+	switch value.(type) {
+	case string, bool:
+		return true
+	}
+	_, ok := toFloat64(value)
+	return ok
+}
+
+// equalValues compares two scalars, numbers are compared by value regardless of their type.
+func equalValues(a, b any) bool {
+	if x, ok := toFloat64(a); ok {
+		y, ok := toFloat64(b)
+		return ok && x == y
+	}
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case bool:
+		y, ok := b.(bool)
+		return ok && x == y
+	}
+	return false
+}
+
+// compareValues compares two numbers or two strings. The second value reports
+// whether the values are comparable.
+func compareValues(a, b any) (int, bool) {
+	if x, ok := toFloat64(a); ok {
+		y, ok := toFloat64(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	x, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	y, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+	return strings.Compare(x, y), true
+}
+
+// toFloat64 converts any Go number to float64.
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64:
+		return float64(reflect.ValueOf(v).Int()), true
+	case uint, uint8, uint16, uint32, uint64:
+		return float64(reflect.ValueOf(v).Uint()), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// toList converts a slice or an array of any type to []any.
+func toList(value any) ([]any, bool) {
+	if values, ok := value.([]any); ok {
+		return values, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	values := make([]any, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, true
+}
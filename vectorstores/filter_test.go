@@ -0,0 +1,105 @@
+package vectorstores
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	metadata := map[string]any{
+		"source": "a.txt",
+		"page":   3,
+		"score":  0.5,
+		"draft":  false,
+		"tags":   []any{"go", "ai"},
+		"author": map[string]any{"name": "Ann", "age": float64(42)},
+		"a.b":    "verbatim",
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"eq string", Eq("source", "a.txt"), true},
+		{"eq number across types", Eq("page", 3.0), true},
+		{"eq bool", Eq("draft", false), true},
+		{"eq missing", Eq("missing", "a.txt"), false},
+		{"eq list element", Eq("tags", "ai"), true},
+		{"eq nested", Eq("author.name", "Ann"), true},
+		{"eq verbatim dotted key", Eq("a.b", "verbatim"), true},
+		{"ne", Ne("source", "b.txt"), true},
+		{"ne missing", Ne("missing", "b.txt"), true},
+		{"ne list element", Ne("tags", "go"), false},
+		{"gt", Gt("page", 2), true},
+		{"gte", Gte("page", 3), true},
+		{"lt", Lt("score", 0.5), false},
+		{"lte nested", Lte("author.age", 42), true},
+		{"gt string", Gt("source", "a"), true},
+		{"gt mismatched types", Gt("source", 1), false},
+		{"in", In("source", "b.txt", "a.txt"), true},
+		{"in list", In("tags", "rust", "go"), true},
+		{"nin", Nin("page", 1, 2), true},
+		{"nin missing", Nin("missing", 1), true},
+		{"exists", Exists("author.name"), true},
+		{"exists missing", Exists("author.email"), false},
+		{"and", And(Eq("source", "a.txt"), Gt("page", 5)), false},
+		{"or", Or(Eq("source", "b.txt"), Gt("page", 2)), true},
+		{"not", Not(Eq("source", "a.txt")), false},
+		{"nested logic", And(Exists("tags"), Or(Not(Lt("page", 3)), Eq("draft", true))), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.NoError(t, tt.filter.Validate())
+			require.Equal(t, tt.want, tt.filter.Match(metadata))
+		})
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	invalid := []Filter{
+		{Operator: "like", Key: "source", Value: "a"},
+		Eq("", "a"),
+		Eq("source", nil),
+		Eq("source", []string{"a"}),
+		Gt("page", true),
+		{Operator: FilterIn, Key: "source", Value: "a"},
+		In("source", map[string]any{}),
+		And(),
+		{Operator: FilterNot, Filters: []Filter{Exists("a"), Exists("b")}},
+		Or(Exists("a"), Eq("b", nil)),
+	}
+	for _, filter := range invalid {
+		require.ErrorIs(t, filter.Validate(), ErrInvalidFilter, "%+v", filter)
+	}
+
+	require.NoError(t, Filter{Operator: FilterIn, Key: "source", Value: []string{"a", "b"}}.Validate())
+}
+
+func TestFilterJSON(t *testing.T) {
+	t.Parallel()
+
+	filter := And(Eq("source", "a.txt"), Not(In("page", 1, 2)), Exists("author"))
+	data, err := json.Marshal(filter)
+	require.NoError(t, err)
+
+	var decoded Filter
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, decoded.Validate())
+	require.True(t, decoded.Match(map[string]any{"source": "a.txt", "page": 3, "author": "Ann"}))
+	require.False(t, decoded.Match(map[string]any{"source": "a.txt", "page": 2, "author": "Ann"}))
+
+	filter, ok := GetFilter(&decoded)
+	require.True(t, ok)
+	require.Equal(t, decoded, filter)
+	_, ok = GetFilter(map[string]any{"source": "a.txt"})
+	require.False(t, ok)
+}
@@ -3,6 +3,9 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"os"
 	"slices"
 	"strconv"
 	"sync"
@@ -14,10 +17,21 @@ import (
 	"github.com/coder/hnsw"
 )
 
+var (
+	_ vectorstores.VectorStore                  = &Store{}
+	_ vectorstores.DocumentManager              = &Store{}
+	_ vectorstores.MaxMarginalRelevanceSearcher = &Store{}
+)
+
 var (
 	ErrEmbedderWrongNumberVectors = errors.New("number of vectors from embedder does not match number of documents")
 	ErrInvalidScoreThreshold      = errors.New("score threshold must be between 0 and 1")
 	ErrUnsupportedOptions         = errors.New("unsupported options")
+	ErrInvalidFilters             = errors.New("invalid filters")
+	ErrInvalidDocumentID          = errors.New("invalid document id")
+	// ErrAutoSave is returned by a write whose changes were applied to the store but
+	// could not be saved to the file set by WithAutoSave.
+	ErrAutoSave = errors.New("autosave failed")
 )
 
 // Store is a struct that holds the in-memory vector store.
@@ -39,6 +53,10 @@ type Store struct {
 	// size limit of the store
 	sizeLimit int
 	lastID    uint32
+
+	// file the store is saved to after every write
+	autoSavePath string
+	saveMu       sync.Mutex
 }
 
 // New returns a new InMemory store with options.
@@ -50,23 +68,37 @@ func New(ctx context.Context, opts ...Option) (*Store, error) {
 	store := applyOptions(opts)
 
 	// Initialize the HNSW graph
-	store.index = hnsw.NewGraph[uint32]()
+	store.index = store.newGraph()
+
+	// Initialize maps
+	store.content = make(map[uint32]string)
+	store.meta = make(map[uint32]map[string]any)
+
+	if store.autoSavePath != "" {
+		err := store.LoadFile(store.autoSavePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+// newGraph returns an empty HNSW graph configured with the store parameters.
+func (s *Store) newGraph() *hnsw.Graph[uint32] {
+	graph := hnsw.NewGraph[uint32]()
 
 	// Configure graph parameters
-	store.index.M = store.m
-	store.index.Ml = 0.5 // Default parameter in the library
+	graph.M = s.m
+	graph.Ml = 0.5 // Default parameter in the library
 
 	// Set the distance function to cosine distance
-	store.index.Distance = hnsw.CosineDistance
+	graph.Distance = hnsw.CosineDistance
 
 	// Set efSearch parameter
-	store.index.EfSearch = store.efSearch
-
-	// Initialize maps
-	store.content = make(map[uint32]string)
-	store.meta = make(map[uint32]map[string]any)
+	graph.EfSearch = s.efSearch
 
-	return store, nil
+	return graph
 }
 
 // AddDocuments adds documents to the in-memory store
@@ -84,25 +116,11 @@ func (s *Store) AddDocuments(
 
 	docs = s.deduplicate(ctx, opts, docs)
 
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
-	}
-
-	embedder := s.embedder
-	if opts.Embedder != nil {
-		embedder = opts.Embedder
-	}
-
-	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	vectors, err := s.embedDocuments(ctx, opts, docs)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(vectors) != len(docs) {
-		return nil, ErrEmbedderWrongNumberVectors
-	}
-
 	ids := make([]string, len(vectors))
 	for i, vec := range vectors {
 		s.Lock()
@@ -120,6 +138,10 @@ func (s *Store) AddDocuments(
 		ids[i] = strconv.FormatUint(uint64(id), 10)
 	}
 
+	if err := s.autoSave(); err != nil {
+		return ids, err
+	}
+
 	return ids, nil
 }
 
@@ -135,9 +157,56 @@ func (s *Store) SimilaritySearch(
 		return nil, ErrUnsupportedOptions
 	}
 
-	var filters map[string]any
-	if f, ok := opts.Filters.(map[string]any); ok {
-		filters = f
+	_, docs, _, err := s.search(ctx, query, numDocuments, opts)
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// MaxMarginalRelevanceSearch fetches the candidates most similar to the query
+// (see vectorstores.WithMaxMarginalRelevance) and selects numDocuments of them
+// with maximal marginal relevance using the vectors stored in the HNSW graph.
+func (s *Store) MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	if opts.NameSpace != "" {
+		// in-memory store does not support these options
+		return nil, ErrUnsupportedOptions
+	}
+
+	mmr, err := vectorstores.GetMMROptions(opts, numDocuments)
+	if err != nil {
+		return nil, err
+	}
+
+	queryVector, docs, vectors, err := s.search(ctx, query, mmr.FetchK, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return vectorstores.SelectByMaximalMarginalRelevance(queryVector, docs, vectors, numDocuments, mmr.Lambda)
+}
+
+// search returns the query vector and up to numDocuments documents, with their vectors,
+// most similar to the query that pass the filters and the score threshold.
+func (s *Store) search(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	opts vectorstores.Options,
+) ([]float32, []schema.Document, [][]float32, error) {
+	if opts.ScoreThreshold < 0 || opts.ScoreThreshold > 1 {
+		return nil, nil, nil, ErrInvalidScoreThreshold
+	}
+
+	match, err := getFilter(opts.Filters)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
 	embedder := s.embedder
@@ -146,13 +215,19 @@ func (s *Store) SimilaritySearch(
 	}
 	embedderData, err := embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	// this returns a slice of Node objects that contain both the key and the vector
+	s.RLock()
 	neighbors := s.index.Search(embedderData, numDocuments)
+	s.RUnlock()
+
+	// already sorted by increasing distance, so reverse to get highest similarity first
+	slices.Reverse(neighbors)
 
 	docs := make([]schema.Document, 0, len(neighbors))
+	vectors := make([][]float32, 0, len(neighbors))
 	for _, n := range neighbors {
 		s.RLock()
 
@@ -166,20 +241,248 @@ func (s *Store) SimilaritySearch(
 		}
 		s.RUnlock()
 
+		if !match(doc.Metadata) || doc.Score < opts.ScoreThreshold {
+			continue
+		}
+
 		docs = append(docs, doc)
+		vectors = append(vectors, n.Value)
 	}
 
-	docs = applyFilters(docs, filters)
-	docs, err = applyScoreThreshold(docs, opts.ScoreThreshold)
+	return embedderData, docs, vectors, nil
+}
+
+// GetDocuments returns the documents stored under the given ids.
+// Ids that are not present in the store are skipped.
+func (s *Store) GetDocuments(
+	_ context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	if opts.NameSpace != "" {
+		// in-memory store does not support these options
+		return nil, ErrUnsupportedOptions
+	}
+
+	keys, err := parseIDs(ids)
 	if err != nil {
 		return nil, err
 	}
 
-	// already sorted by increasing distance, so reverse to get highest similarity first
-	slices.Reverse(docs)
+	s.RLock()
+	defer s.RUnlock()
+
+	docs := make([]schema.Document, 0, len(keys))
+	for _, key := range keys {
+		content, ok := s.content[key]
+		if !ok {
+			continue
+		}
+		docs = append(docs, schema.Document{
+			PageContent: content,
+			Metadata:    s.meta[key],
+		})
+	}
+
 	return docs, nil
 }
 
+// UpsertDocuments adds documents to the in-memory store under the given ids,
+// replacing the documents already stored with the same ids.
+func (s *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+	if opts.NameSpace != "" {
+		// in-memory store does not support these options
+		return nil, ErrUnsupportedOptions
+	}
+
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	keys, err := parseIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := s.embedDocuments(ctx, opts, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Lock()
+	replaced := make(map[uint32][]float32)
+	for i, key := range keys {
+		if _, ok := s.content[key]; ok {
+			replaced[key] = vectors[i]
+		} else {
+			s.index.Add(hnsw.MakeNode(key, vectors[i]))
+		}
+
+		s.content[key] = docs[i].PageContent
+		s.meta[key] = docs[i].Metadata
+		s.lastID = max(s.lastID, key)
+	}
+
+	if len(replaced) != 0 {
+		s.rebuildIndex(replaced)
+	}
+	s.Unlock()
+
+	if err := s.autoSave(); err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
+
+// DeleteDocuments removes the documents with the given ids from the store.
+func (s *Store) DeleteDocuments(
+	_ context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if opts.NameSpace != "" {
+		// in-memory store does not support these options
+		return ErrUnsupportedOptions
+	}
+
+	keys, err := parseIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	s.deleteKeys(keys)
+	s.Unlock()
+
+	return s.autoSave()
+}
+
+// DeleteDocumentsByFilter removes the documents which metadata matches the
+// filters. Filters must be a vectorstores.Filter or a non-empty map[string]any,
+// as for SimilaritySearch.
+func (s *Store) DeleteDocumentsByFilter(
+	_ context.Context,
+	filters any,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if opts.NameSpace != "" {
+		// in-memory store does not support these options
+		return ErrUnsupportedOptions
+	}
+
+	if f, ok := filters.(map[string]any); filters == nil || ok && len(f) == 0 {
+		return ErrInvalidFilters
+	}
+	match, err := getFilter(filters)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	keys := make([]uint32, 0)
+	for key, meta := range s.meta {
+		if match(meta) {
+			keys = append(keys, key)
+		}
+	}
+	s.deleteKeys(keys)
+	s.Unlock()
+
+	return s.autoSave()
+}
+
+// deleteKeys removes the given keys from the store. The caller must hold the lock.
+func (s *Store) deleteKeys(keys []uint32) {
+	deleted := false
+	for _, key := range keys {
+		if _, ok := s.content[key]; !ok {
+			continue
+		}
+		delete(s.content, key)
+		delete(s.meta, key)
+		deleted = true
+	}
+
+	if deleted {
+		s.rebuildIndex(nil)
+	}
+}
+
+// rebuildIndex replaces the HNSW graph with a new one that contains only the
+// nodes still present in the content map. Vectors of replaced nodes are taken
+// from the given map. The graph library can not reliably remove nodes in place
+// (searches may hit dangling neighbors), so the graph is rebuilt instead.
+// The caller must hold the lock.
+func (s *Store) rebuildIndex(replaced map[uint32][]float32) {
+	keys := slices.Sorted(maps.Keys(s.content))
+
+	nodes := make([]hnsw.Node[uint32], 0, len(keys))
+	for _, key := range keys {
+		vec, ok := replaced[key]
+		if !ok {
+			if vec, ok = s.index.Lookup(key); !ok {
+				continue
+			}
+		}
+		nodes = append(nodes, hnsw.MakeNode(key, vec))
+	}
+
+	s.index = s.newGraph()
+	s.index.Add(nodes...)
+}
+
+// embedDocuments returns the embeddings of the given documents using the embedder
+// from the options or the store embedder.
+func (s *Store) embedDocuments(
+	ctx context.Context,
+	opts vectorstores.Options,
+	docs []schema.Document,
+) ([][]float32, error) {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	embedder := s.embedder
+	if opts.Embedder != nil {
+		embedder = opts.Embedder
+	}
+
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	return vectors, nil
+}
+
+// parseIDs converts the document ids returned by AddDocuments into graph keys.
+func parseIDs(ids []string) ([]uint32, error) {
+	keys := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		key, err := strconv.ParseUint(id, 10, 32)
+		if err != nil || key == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDocumentID, id)
+		}
+		keys = append(keys, uint32(key))
+	}
+	return keys, nil
+}
+
 // getOptions applies given options to default Options and returns it
 // This uses options pattern so clients can easily pass options without changing function signature.
 func (s *Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
@@ -211,30 +514,23 @@ func (s *Store) deduplicate(
 	return filtered
 }
 
-// applyScoreThreshold applies the score threshold to the given slice of documents.
-func applyScoreThreshold(docs []schema.Document, threshold float32) ([]schema.Document, error) {
-	if threshold < 0 || threshold > 1 {
-		return nil, ErrInvalidScoreThreshold
-	}
-
-	filtered := make([]schema.Document, 0, len(docs))
-	for _, doc := range docs {
-		if doc.Score >= threshold {
-			filtered = append(filtered, doc)
+// getFilter returns a function matching metadata against the filters, which can be
+// a vectorstores.Filter or a map[string]any of values that must be equal.
+func getFilter(filters any) (func(map[string]any) bool, error) {
+	if filter, ok := vectorstores.GetFilter(filters); ok {
+		if err := filter.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
 		}
+		return filter.Match, nil
 	}
-	return filtered, nil
-}
 
-// applyFilters applies the filters to the given slice of documents.
-func applyFilters(docs []schema.Document, filters map[string]any) []schema.Document {
-	filtered := make([]schema.Document, 0, len(docs))
-	for _, doc := range docs {
-		if matchesFilters(doc.Metadata, filters) {
-			filtered = append(filtered, doc)
-		}
+	f, ok := filters.(map[string]any)
+	if filters != nil && !ok {
+		return nil, ErrInvalidFilters
 	}
-	return filtered
+	return func(meta map[string]any) bool {
+		return matchesFilters(meta, f)
+	}, nil
 }
 
 // matchesFilters returns true if the given metadata matches the filters.
@@ -507,3 +507,171 @@ func TestDeduplicater(t *testing.T) {
 	require.Equal(t, "potato", docs[0].PageContent)
 	require.Equal(t, "vegetable", docs[0].Metadata["type"])
 }
+
+func TestDocumentManager(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	store, err := inmemory.New(
+		ctx,
+		inmemory.WithEmbedder(&mockEmbedder{}),
+		inmemory.WithVectorSize(3),
+	)
+	require.NoError(t, err)
+
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "similar1", Metadata: map[string]any{"source": "a"}},
+		{PageContent: "similar2", Metadata: map[string]any{"source": "b"}},
+		{PageContent: "different", Metadata: map[string]any{"source": "b"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 3)
+
+	docs, err := store.GetDocuments(ctx, []string{ids[2], "100", ids[0]})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "different", docs[0].PageContent)
+	require.Equal(t, "similar1", docs[1].PageContent)
+
+	_, err = store.GetDocuments(ctx, []string{"not-a-number"})
+	require.ErrorIs(t, err, inmemory.ErrInvalidDocumentID)
+
+	// replace the first document and add a new one with an explicit id
+	_, err = store.UpsertDocuments(ctx, []string{ids[0], "10"}, []schema.Document{
+		{PageContent: "different", Metadata: map[string]any{"source": "a", "version": 2}},
+		{PageContent: "similar1", Metadata: map[string]any{"source": "c"}},
+	})
+	require.NoError(t, err)
+
+	_, err = store.UpsertDocuments(ctx, []string{"11"}, nil)
+	require.ErrorIs(t, err, vectorstores.ErrIDsDocumentsMismatch)
+
+	docs, err = store.GetDocuments(ctx, []string{ids[0]})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "different", docs[0].PageContent)
+	require.Equal(t, 2, docs[0].Metadata["version"])
+
+	docs, err = store.SimilaritySearch(ctx, "similar", 4)
+	require.NoError(t, err)
+	require.Len(t, docs, 4)
+
+	sources := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		sources = append(sources, doc.Metadata["source"])
+	}
+	require.ElementsMatch(t, []any{"a", "b", "b", "c"}, sources)
+
+	// new documents must not reuse the explicit id
+	newIDs, err := store.AddDocuments(ctx, []schema.Document{{PageContent: "similar2"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"11"}, newIDs)
+
+	err = store.DeleteDocuments(ctx, []string{ids[1], "10"})
+	require.NoError(t, err)
+
+	err = store.DeleteDocumentsByFilter(ctx, map[string]any{"source": "b"})
+	require.NoError(t, err)
+
+	err = store.DeleteDocumentsByFilter(ctx, nil)
+	require.ErrorIs(t, err, inmemory.ErrInvalidFilters)
+
+	docs, err = store.SimilaritySearch(ctx, "similar", 10)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	for _, doc := range docs {
+		require.NotEqual(t, "b", doc.Metadata["source"])
+		require.NotEqual(t, "c", doc.Metadata["source"])
+	}
+
+	err = store.DeleteDocuments(ctx, append(newIDs, ids[0]))
+	require.NoError(t, err)
+
+	docs, err = store.SimilaritySearch(ctx, "similar", 10)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestMaxMarginalRelevanceSearch(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	store, err := inmemory.New(
+		ctx,
+		inmemory.WithEmbedder(&mockEmbedder{}),
+		inmemory.WithVectorSize(3),
+	)
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "similar1"},
+		{PageContent: "similar2"},
+		{PageContent: "different"},
+	})
+	require.NoError(t, err)
+
+	// plain similarity search returns the near duplicates
+	docs, err := store.SimilaritySearch(ctx, "similar", 2)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.ElementsMatch(t, []string{"similar1", "similar2"}, []string{docs[0].PageContent, docs[1].PageContent})
+
+	docs, err = store.MaxMarginalRelevanceSearch(ctx, "similar", 2, vectorstores.WithMaxMarginalRelevance(3, 0.3))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "similar1", docs[0].PageContent)
+	require.Equal(t, "different", docs[1].PageContent)
+
+	retriever := vectorstores.ToRetriever(store, 2, vectorstores.WithMaxMarginalRelevance(3, 0.3))
+	docs, err = retriever.GetRelevantDocuments(ctx, "similar")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "different", docs[1].PageContent)
+
+	// with lambda = 1 only relevance matters
+	docs, err = store.MaxMarginalRelevanceSearch(ctx, "similar", 2, vectorstores.WithMaxMarginalRelevance(3, 1))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "similar2", docs[1].PageContent)
+
+	_, err = store.MaxMarginalRelevanceSearch(ctx, "similar", 2, vectorstores.WithMaxMarginalRelevance(3, -1))
+	require.ErrorIs(t, err, vectorstores.ErrInvalidMMRLambda)
+}
+
+func TestSimilaritySearchWithFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}))
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "similar1", Metadata: map[string]any{"year": 2023, "author": map[string]any{"name": "ann"}}},
+		{PageContent: "similar2", Metadata: map[string]any{"year": 2024, "author": map[string]any{"name": "bob"}}},
+		{PageContent: "different", Metadata: map[string]any{"year": 2025, "tags": []any{"draft"}}},
+	})
+	require.NoError(t, err)
+
+	docs, err := store.SimilaritySearch(ctx, "similar", 3, vectorstores.WithFilters(
+		vectorstores.Or(
+			vectorstores.Eq("author.name", "bob"),
+			vectorstores.And(vectorstores.Gt("year", 2024), vectorstores.Not(vectorstores.In("tags", "draft"))),
+		),
+	))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "similar2", docs[0].PageContent)
+
+	_, err = store.SimilaritySearch(ctx, "similar", 3, vectorstores.WithFilters(vectorstores.Eq("", "bob")))
+	require.ErrorIs(t, err, inmemory.ErrInvalidFilters)
+
+	err = store.DeleteDocumentsByFilter(ctx, vectorstores.Lte("year", 2024))
+	require.NoError(t, err)
+
+	docs, err = store.GetDocuments(ctx, []string{"1", "2", "3"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "different", docs[0].PageContent)
+}
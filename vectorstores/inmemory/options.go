@@ -48,6 +48,20 @@ func WithEmbedder(e embeddings.Embedder) Option {
 	}
 }
 
+// WithAutoSave is an option for persisting the store to the file at path.
+// New loads the snapshot from the file if it exists, and every write
+// (AddDocuments, UpsertDocuments, DeleteDocuments, DeleteDocumentsByFilter)
+// saves a new snapshot to it with SaveFile. Each write rewrites the whole
+// snapshot, so autosave suits small stores or infrequent writes.
+//
+// A write whose save fails is still applied in memory: it returns an error
+// wrapping ErrAutoSave, along with the ids of the written documents.
+func WithAutoSave(path string) Option {
+	return func(s *Store) {
+		s.autoSavePath = path
+	}
+}
+
 func applyOptions(opts []Option) *Store {
 	s := &Store{
 		lastID:   0,
@@ -0,0 +1,212 @@
+package inmemory
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/coder/hnsw"
+)
+
+const (
+	// snapshotMagic identifies the snapshots written by Store.Save.
+	snapshotMagic = "LCGOINMEM"
+	// snapshotVersion is the version of the snapshot format written by Store.Save.
+	snapshotVersion uint32 = 1
+)
+
+var (
+	ErrInvalidSnapshot            = errors.New("invalid snapshot")
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+)
+
+// snapshotDocument is a document stored in a snapshot.
+type snapshotDocument struct {
+	ID       uint32         `json:"id"`
+	Content  string         `json:"content"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// snapshotState is the store state, except for the HNSW graph, stored in a snapshot.
+type snapshotState struct {
+	LastID    uint32             `json:"last_id"`
+	Documents []snapshotDocument `json:"documents"`
+}
+
+// Save writes a snapshot of the store to w. The snapshot holds the HNSW graph,
+// the content and metadata of the documents and the last assigned id, and can be
+// restored with Load.
+//
+// The snapshot starts with a magic string and a format version, followed by the
+// length-prefixed JSON encoded documents and the exported HNSW graph. Metadata is
+// encoded as JSON, so it must be JSON serializable and numbers are restored as float64.
+func (s *Store) Save(w io.Writer) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	state := snapshotState{
+		LastID:    s.lastID,
+		Documents: make([]snapshotDocument, 0, len(s.content)),
+	}
+	for _, key := range slices.Sorted(maps.Keys(s.content)) {
+		state.Documents = append(state.Documents, snapshotDocument{
+			ID:       key,
+			Content:  s.content[key],
+			Metadata: s.meta[key],
+		})
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encode documents: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(snapshotMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, snapshotVersion); err != nil {
+		return err
+	}
+	if err := binary.Write(bw, binary.BigEndian, uint64(len(data))); err != nil {
+		return err
+	}
+	if _, err := bw.Write(data); err != nil {
+		return err
+	}
+	if err := s.index.Export(bw); err != nil {
+		return fmt.Errorf("encode index: %w", err)
+	}
+
+	return bw.Flush()
+}
+
+// Load replaces the content of the store with a snapshot written by Save.
+// The HNSW parameters of the store are kept. On error the store is left unchanged.
+func (s *Store) Load(r io.Reader) error {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != snapshotMagic {
+		return fmt.Errorf("%w: missing header", ErrInvalidSnapshot)
+	}
+
+	var version uint32
+	if err := binary.Read(br, binary.BigEndian, &version); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if version != snapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, version)
+	}
+
+	var size uint64
+	if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(br, int64(size))) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if uint64(len(data)) != size {
+		return fmt.Errorf("%w: truncated documents", ErrInvalidSnapshot)
+	}
+
+	var state snapshotState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("%w: decode documents: %w", ErrInvalidSnapshot, err)
+	}
+
+	graph := s.newGraph()
+	if err := graph.Import(br); err != nil {
+		return fmt.Errorf("%w: decode index: %w", ErrInvalidSnapshot, err)
+	}
+	// the snapshot parameters are overwritten by the import, restore the store ones
+	graph.M = s.m
+	graph.Ml = 0.5
+	graph.EfSearch = s.efSearch
+	graph.Distance = hnsw.CosineDistance
+
+	content := make(map[uint32]string, len(state.Documents))
+	meta := make(map[uint32]map[string]any, len(state.Documents))
+	for _, doc := range state.Documents {
+		if _, ok := graph.Lookup(doc.ID); !ok {
+			return fmt.Errorf("%w: document %d is missing from the index", ErrInvalidSnapshot, doc.ID)
+		}
+		content[doc.ID] = doc.Content
+		meta[doc.ID] = doc.Metadata
+	}
+	if graph.Len() != len(content) {
+		return fmt.Errorf("%w: index does not match documents", ErrInvalidSnapshot)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.index = graph
+	s.content = content
+	s.meta = meta
+	s.lastID = state.LastID
+
+	return nil
+}
+
+// SaveFile writes a snapshot of the store to the file at path. The snapshot is
+// written to a temporary file in the same directory first and then renamed, so
+// the file at path always holds a complete snapshot.
+func (s *Store) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := s.Save(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadFile replaces the content of the store with the snapshot stored in the file at path.
+func (s *Store) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return s.Load(f)
+}
+
+// autoSave saves the store to the file set by WithAutoSave, if any.
+// Saves are serialized so the file always ends up with the latest state.
+// A failed save is wrapped in ErrAutoSave.
+func (s *Store) autoSave() error {
+	if s.autoSavePath == "" {
+		return nil
+	}
+
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	if err := s.SaveFile(s.autoSavePath); err != nil {
+		return fmt.Errorf("%w: %w", ErrAutoSave, err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package inmemory_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+	"github.com/vxcontrol/langchaingo/vectorstores/inmemory"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}), inmemory.WithVectorSize(3))
+	require.NoError(t, err)
+
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "similar1", Metadata: map[string]any{"source": "a"}},
+		{PageContent: "similar2", Metadata: map[string]any{"source": "b"}},
+		{PageContent: "different", Metadata: map[string]any{"source": "c"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteDocuments(ctx, ids[1:2]))
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Save(&buf))
+
+	restored, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}), inmemory.WithVectorSize(3))
+	require.NoError(t, err)
+	require.NoError(t, restored.Load(&buf))
+
+	docs, err := restored.GetDocuments(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "similar1", docs[0].PageContent)
+	require.Equal(t, map[string]any{"source": "a"}, docs[0].Metadata)
+	require.Equal(t, "different", docs[1].PageContent)
+
+	docs, err = restored.SimilaritySearch(ctx, "similar", 1)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "similar1", docs[0].PageContent)
+
+	// ids keep increasing after the restore
+	newIDs, err := restored.AddDocuments(ctx, []schema.Document{{PageContent: "similar2"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"4"}, newIDs)
+}
+
+func TestLoadInvalidSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	store, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}))
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(ctx, []schema.Document{{PageContent: "similar1"}})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Save(&buf))
+	snapshot := buf.Bytes()
+
+	err = store.Load(bytes.NewReader([]byte("not a snapshot")))
+	require.ErrorIs(t, err, inmemory.ErrInvalidSnapshot)
+
+	unsupported := bytes.Clone(snapshot)
+	unsupported[len("LCGOINMEM")+3] = 2
+	err = store.Load(bytes.NewReader(unsupported))
+	require.ErrorIs(t, err, inmemory.ErrUnsupportedSnapshotVersion)
+
+	err = store.Load(bytes.NewReader(snapshot[:len(snapshot)-4]))
+	require.ErrorIs(t, err, inmemory.ErrInvalidSnapshot)
+
+	// the store is left unchanged on error
+	docs, err := store.GetDocuments(ctx, []string{"1"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+}
+
+func TestAutoSave(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "store.bin")
+
+	store, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}), inmemory.WithAutoSave(path))
+	require.NoError(t, err)
+
+	ids, err := store.AddDocuments(ctx, []schema.Document{
+		{PageContent: "similar1"},
+		{PageContent: "different"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteDocuments(ctx, ids[1:]))
+
+	reopened, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}), inmemory.WithAutoSave(path))
+	require.NoError(t, err)
+
+	docs, err := reopened.GetDocuments(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "similar1", docs[0].PageContent)
+}
+
+func TestAutoSaveFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "missing", "store.bin")
+
+	store, err := inmemory.New(ctx, inmemory.WithEmbedder(&mockEmbedder{}), inmemory.WithAutoSave(path))
+	require.NoError(t, err)
+
+	// the documents are added in memory even though they could not be saved
+	ids, err := store.AddDocuments(ctx, []schema.Document{{PageContent: "similar1"}})
+	require.ErrorIs(t, err, inmemory.ErrAutoSave)
+	require.Len(t, ids, 1)
+
+	ids, err = store.UpsertDocuments(ctx, ids, []schema.Document{{PageContent: "similar2"}})
+	require.ErrorIs(t, err, inmemory.ErrAutoSave)
+	require.Len(t, ids, 1)
+
+	docs, err := store.GetDocuments(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "similar2", docs[0].PageContent)
+
+	require.ErrorIs(t, store.DeleteDocuments(ctx, ids), inmemory.ErrAutoSave)
+}
@@ -1,3 +1,7 @@
+// Package milvus implements a vector store on top of Milvus. The collections generate
+// the primary keys of the documents: the store gets and deletes documents by the ids
+// returned by AddDocuments, and deletes them by filter, but UpsertDocuments returns
+// ErrUpsertUnsupported as documents cannot be stored under the ids of the caller.
 package milvus
 
 import (
@@ -6,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/vxcontrol/langchaingo/embeddings"
 	"github.com/vxcontrol/langchaingo/schema"
@@ -41,13 +46,18 @@ type Store struct {
 }
 
 var (
-	_ vectorstores.VectorStore = Store{}
+	_ vectorstores.VectorStore     = Store{}
+	_ vectorstores.DocumentManager = Store{}
 
 	ErrEmbedderWrongNumberVectors = errors.New(
 		"number of vectors from embedder does not match number of documents",
 	)
-	ErrColumnNotFound = errors.New("invalid field")
-	ErrInvalidFilters = errors.New("invalid filters")
+	ErrColumnNotFound    = errors.New("invalid field")
+	ErrInvalidFilters    = errors.New("invalid filters")
+	ErrInvalidDocumentID = errors.New("invalid document id")
+	// ErrUpsertUnsupported is returned by UpsertDocuments: the collection generates
+	// the primary keys, so documents cannot be stored under the ids of the caller.
+	ErrUpsertUnsupported = errors.New("upsert is not supported by milvus collections with generated primary keys")
 )
 
 // New creates an active client connection to the (specified, or default) collection in the Milvus server
@@ -224,16 +234,30 @@ func (s Store) AddDocuments(ctx context.Context, docs []schema.Document,
 		colsData = append(colsData, docMap)
 	}
 
-	_, err = s.client.InsertRows(ctx, s.collectionName, s.partitionName, colsData)
+	keys, err := s.client.InsertRows(ctx, s.collectionName, s.partitionName, colsData)
 	if err != nil {
 		return nil, err
 	}
-	if !s.skipFlushOnWrite {
-		if err = s.client.Flush(ctx, s.collectionName, false); err != nil {
+	if err := s.flush(ctx); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, keys.Len())
+	for i := 0; i < keys.Len(); i++ {
+		key, err := keys.Get(i)
+		if err != nil {
 			return nil, err
 		}
+		ids = append(ids, fmt.Sprint(key))
 	}
-	return nil, nil
+	return ids, nil
+}
+
+func (s Store) flush(ctx context.Context) error {
+	if s.skipFlushOnWrite {
+		return nil
+	}
+	return s.client.Flush(ctx, s.collectionName, false)
 }
 
 func (s *Store) getSearchFields() []string {
@@ -348,3 +372,127 @@ func (s Store) getFilters(opts vectorstores.Options) (string, error) {
 	}
 	return "", nil
 }
+
+// GetDocuments returns the documents with the given ids, as returned by AddDocuments,
+// in the order of ids.
+func (s Store) GetDocuments(ctx context.Context, ids []string, _ ...vectorstores.Option) ([]schema.Document, error) {
+	if len(ids) == 0 || !s.collectionExists {
+		return []schema.Document{}, nil
+	}
+	keys, err := s.primaryKeys(ids)
+	if err != nil {
+		return nil, err
+	}
+	partitions := []string{}
+	if s.partitionName != "" {
+		partitions = append(partitions, s.partitionName)
+	}
+	result, err := s.client.QueryByPks(ctx, s.collectionName, partitions, keys,
+		[]string{s.primaryField, s.textField, s.metaField},
+		client.WithSearchQueryConsistencyLevel(s.consistencyLevel),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pkcol := result.GetColumn(s.primaryField)
+	textcol, ok := result.GetColumn(s.textField).(*entity.ColumnVarChar)
+	if pkcol == nil || !ok {
+		return nil, fmt.Errorf("%w: primary key or text column missing", ErrColumnNotFound)
+	}
+	metacol, ok := result.GetColumn(s.metaField).(*entity.ColumnJSONBytes)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata column missing", ErrColumnNotFound)
+	}
+	found := make(map[string]schema.Document, pkcol.Len())
+	for i := 0; i < pkcol.Len(); i++ {
+		key, err := pkcol.Get(i)
+		if err != nil {
+			return nil, err
+		}
+		doc := schema.Document{}
+		if doc.PageContent, err = textcol.ValueByIdx(i); err != nil {
+			return nil, err
+		}
+		metaStr, err := metacol.ValueByIdx(i)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(metaStr, &doc.Metadata); err != nil {
+			return nil, err
+		}
+		found[fmt.Sprint(key)] = doc
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// UpsertDocuments returns ErrUpsertUnsupported: the collection generates the primary
+// keys of the documents.
+func (s Store) UpsertDocuments(
+	_ context.Context,
+	_ []string,
+	_ []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	return nil, ErrUpsertUnsupported
+}
+
+// DeleteDocuments removes the documents with the given ids, as returned by
+// AddDocuments.
+func (s Store) DeleteDocuments(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	if len(ids) == 0 || !s.collectionExists {
+		return nil
+	}
+	keys, err := s.primaryKeys(ids)
+	if err != nil {
+		return err
+	}
+	if err := s.client.DeleteByPks(ctx, s.collectionName, s.partitionName, keys); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+// DeleteDocumentsByFilter removes the documents matching the filters, a non-empty
+// boolean expression as for SimilaritySearch.
+func (s Store) DeleteDocumentsByFilter(ctx context.Context, filters any, _ ...vectorstores.Option) error {
+	expr, ok := filters.(string)
+	if !ok || strings.TrimSpace(expr) == "" {
+		return ErrInvalidFilters
+	}
+	if !s.collectionExists {
+		return nil
+	}
+	if err := s.client.Delete(ctx, s.collectionName, s.partitionName, expr); err != nil {
+		return err
+	}
+	return s.flush(ctx)
+}
+
+// primaryKeys returns the column of the primary keys with the given ids, which are
+// integers unless the primary field of the collection is a string.
+func (s Store) primaryKeys(ids []string) (entity.Column, error) {
+	if s.schema != nil {
+		for _, field := range s.schema.Fields {
+			if field.PrimaryKey && field.DataType == entity.FieldTypeVarChar {
+				return entity.NewColumnVarChar(s.primaryField, ids), nil
+			}
+		}
+	}
+	keys := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		key, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDocumentID, id)
+		}
+		keys = append(keys, key)
+	}
+	return entity.NewColumnInt64(s.primaryField, keys), nil
+}
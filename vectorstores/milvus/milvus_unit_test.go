@@ -0,0 +1,109 @@
+package milvus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// documentsClient is a client answering the document queries of the store. Calling
+// any other method panics.
+type documentsClient struct {
+	client.Client
+	deletedKeys []int64
+	deletedExpr string
+	flushes     int
+}
+
+func (c *documentsClient) QueryByPks(
+	_ context.Context,
+	_ string,
+	_ []string,
+	ids entity.Column,
+	_ []string,
+	_ ...client.SearchQueryOptionFunc,
+) (client.ResultSet, error) {
+	keys, ok := ids.(*entity.ColumnInt64)
+	if !ok {
+		return nil, ErrInvalidDocumentID
+	}
+	// the result is in the order of the collection, not of the ids
+	found := []int64{}
+	for _, key := range []int64{1, 2} {
+		for _, id := range keys.Data() {
+			if id == key {
+				found = append(found, key)
+			}
+		}
+	}
+	texts := map[int64]string{1: "first", 2: "second"}
+	metas := map[int64][]byte{1: []byte(`{"n":1}`), 2: []byte(`{"n":2}`)}
+	textValues, metaValues := []string{}, [][]byte{}
+	for _, key := range found {
+		textValues = append(textValues, texts[key])
+		metaValues = append(metaValues, metas[key])
+	}
+	return client.ResultSet{
+		entity.NewColumnInt64("pk", found),
+		entity.NewColumnVarChar("text", textValues),
+		entity.NewColumnJSONBytes("meta", metaValues),
+	}, nil
+}
+
+func (c *documentsClient) DeleteByPks(_ context.Context, _ string, _ string, ids entity.Column) error {
+	c.deletedKeys = append(c.deletedKeys, ids.(*entity.ColumnInt64).Data()...)
+	return nil
+}
+
+func (c *documentsClient) Delete(_ context.Context, _ string, _ string, expr string) error {
+	c.deletedExpr = expr
+	return nil
+}
+
+func (c *documentsClient) Flush(_ context.Context, _ string, _ bool, _ ...client.FlushOption) error {
+	c.flushes++
+	return nil
+}
+
+func TestStoreDocumentManager(t *testing.T) {
+	t.Parallel()
+
+	fake := &documentsClient{}
+	store := Store{
+		client:           fake,
+		collectionExists: true,
+		primaryField:     "pk",
+		textField:        "text",
+		metaField:        "meta",
+	}
+	ctx := t.Context()
+
+	docs, err := store.GetDocuments(ctx, []string{"2", "3", "1"})
+	require.NoError(t, err)
+	assert.Equal(t, []schema.Document{
+		{PageContent: "second", Metadata: map[string]any{"n": 2.0}},
+		{PageContent: "first", Metadata: map[string]any{"n": 1.0}},
+	}, docs)
+
+	_, err = store.GetDocuments(ctx, []string{"abc"})
+	require.ErrorIs(t, err, ErrInvalidDocumentID)
+
+	require.NoError(t, store.DeleteDocuments(ctx, []string{"1", "2"}))
+	assert.Equal(t, []int64{1, 2}, fake.deletedKeys)
+
+	require.NoError(t, store.DeleteDocumentsByFilter(ctx, `meta["n"] > 1`))
+	assert.Equal(t, `meta["n"] > 1`, fake.deletedExpr)
+	assert.Equal(t, 2, fake.flushes)
+
+	require.ErrorIs(t, store.DeleteDocumentsByFilter(ctx, ""), ErrInvalidFilters)
+	require.ErrorIs(t, store.DeleteDocumentsByFilter(ctx, map[string]any{"n": 1}), ErrInvalidFilters)
+
+	_, err = store.UpsertDocuments(ctx, []string{"1"}, []schema.Document{{PageContent: "first"}})
+	require.ErrorIs(t, err, ErrUpsertUnsupported)
+}
@@ -0,0 +1,229 @@
+package vectorstores
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/vxcontrol/langchaingo/embeddings"
+	"github.com/vxcontrol/langchaingo/schema"
+)
+
+const (
+	// DefaultMMRFetchK is the default number of candidates fetched for maximal marginal relevance search.
+	DefaultMMRFetchK = 20
+	// DefaultMMRLambda is the default trade-off between relevance and diversity.
+	DefaultMMRLambda = 0.5
+)
+
+var (
+	// ErrInvalidMMRLambda is returned when the lambda of maximal marginal relevance
+	// search is not between 0 and 1.
+	ErrInvalidMMRLambda = errors.New("mmr lambda must be between 0 and 1")
+	// ErrMMRUnsupported is returned when the vector store implements neither
+	// MaxMarginalRelevanceSearcher nor VectorSimilaritySearcher and no embedder is given
+	// to embed the candidates.
+	ErrMMRUnsupported = errors.New("vector store does not support maximal marginal relevance search without embedder")
+	// ErrMMREmbeddingsMismatch is returned when the number of candidate embeddings
+	// does not match the number of candidate documents.
+	ErrMMREmbeddingsMismatch = errors.New("number of embeddings does not match number of documents")
+)
+
+// MaxMarginalRelevanceSearcher is an optional interface for vector stores that can
+// search documents with maximal marginal relevance (MMR). The search fetches
+// MMROptions.FetchK candidates most similar to the query and selects numDocuments
+// of them, trading relevance to the query for diversity between the results.
+type MaxMarginalRelevanceSearcher interface {
+	MaxMarginalRelevanceSearch(ctx context.Context, query string, numDocuments int, options ...Option) ([]schema.Document, error) //nolint:lll
+}
+
+// VectorSearchResult is the result of a similarity search returning the vectors.
+type VectorSearchResult struct {
+	// QueryVector is the embedding of the query.
+	QueryVector []float32
+	// Documents are the documents found.
+	Documents []schema.Document
+	// Vectors are the stored vectors of the documents, by index in Documents.
+	Vectors [][]float32
+}
+
+// VectorSimilaritySearcher is an optional interface for vector stores whose similarity
+// search can return the embedding of the query and the stored vectors of the documents
+// found. MaxMarginalRelevanceSearch selects among them without embedding the
+// candidates again.
+type VectorSimilaritySearcher interface {
+	SimilaritySearchWithVectors(ctx context.Context, query string, numDocuments int, options ...Option) (VectorSearchResult, error) //nolint:lll
+}
+
+// MMROptions holds the parameters of maximal marginal relevance search.
+type MMROptions struct {
+	// FetchK is the number of candidates fetched from the vector store before selection.
+	FetchK int
+	// Lambda is the trade-off between relevance (1) and diversity (0).
+	Lambda float32
+}
+
+// GetMMROptions returns the maximal marginal relevance parameters from the options,
+// falling back to the defaults when WithMaxMarginalRelevance is not set. FetchK is never
+// less than numDocuments.
+func GetMMROptions(opts Options, numDocuments int) (MMROptions, error) {
+	mmr := MMROptions{FetchK: DefaultMMRFetchK, Lambda: DefaultMMRLambda}
+	if opts.MMR != nil {
+		mmr = *opts.MMR
+	}
+
+	if mmr.Lambda < 0 || mmr.Lambda > 1 {
+		return MMROptions{}, ErrInvalidMMRLambda
+	}
+	if mmr.FetchK <= 0 {
+		mmr.FetchK = DefaultMMRFetchK
+	}
+	mmr.FetchK = max(mmr.FetchK, numDocuments)
+
+	return mmr, nil
+}
+
+// MaxMarginalRelevanceSearch searches the vector store with maximal marginal relevance.
+// If the store implements MaxMarginalRelevanceSearcher its implementation is used.
+// Otherwise FetchK candidates are retrieved and selected with MaximalMarginalRelevance:
+// with their stored vectors if the store implements VectorSimilaritySearcher, else with
+// SimilaritySearch, the candidates being embedded again with the embedder set by
+// WithEmbedder. The maximal marginal relevance parameters are only used for the
+// selection: they are not passed to the search of the candidates, as stores may reject
+// them.
+func MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	store VectorStore,
+	query string,
+	numDocuments int,
+	options ...Option,
+) ([]schema.Document, error) {
+	if searcher, ok := store.(MaxMarginalRelevanceSearcher); ok {
+		return searcher.MaxMarginalRelevanceSearch(ctx, query, numDocuments, options...)
+	}
+
+	opts := Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	mmr, err := GetMMROptions(opts, numDocuments)
+	if err != nil {
+		return nil, err
+	}
+
+	if searcher, ok := store.(VectorSimilaritySearcher); ok {
+		result, err := searcher.SimilaritySearchWithVectors(ctx, query, mmr.FetchK, withoutMMROptions(opts))
+		if err != nil {
+			return nil, err
+		}
+		return SelectByMaximalMarginalRelevance(result.QueryVector, result.Documents, result.Vectors,
+			numDocuments, mmr.Lambda)
+	}
+
+	if opts.Embedder == nil {
+		return nil, ErrMMRUnsupported
+	}
+	candidates, err := store.SimilaritySearch(ctx, query, mmr.FetchK, withoutMMROptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	queryEmbedding, err := opts.Embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, 0, len(candidates))
+	for _, doc := range candidates {
+		texts = append(texts, doc.PageContent)
+	}
+	candidateEmbeddings, err := opts.Embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	return SelectByMaximalMarginalRelevance(queryEmbedding, candidates, candidateEmbeddings, numDocuments, mmr.Lambda)
+}
+
+// withoutMMROptions returns an option setting the options of the search of the
+// candidates: the options of the search without the maximal marginal relevance
+// parameters.
+func withoutMMROptions(opts Options) Option {
+	opts.MMR = nil
+	return func(o *Options) {
+		*o = opts
+	}
+}
+
+// SelectByMaximalMarginalRelevance selects up to k documents out of the candidates
+// with MaximalMarginalRelevance, given the embedding of each candidate.
+func SelectByMaximalMarginalRelevance(
+	queryEmbedding []float32,
+	candidates []schema.Document,
+	candidateEmbeddings [][]float32,
+	k int,
+	lambda float32,
+) ([]schema.Document, error) {
+	if len(candidates) != len(candidateEmbeddings) {
+		return nil, ErrMMREmbeddingsMismatch
+	}
+
+	selected := MaximalMarginalRelevance(queryEmbedding, candidateEmbeddings, k, lambda)
+	docs := make([]schema.Document, 0, len(selected))
+	for _, idx := range selected {
+		docs = append(docs, candidates[idx])
+	}
+	return docs, nil
+}
+
+// MaximalMarginalRelevance returns the indices of up to k embeddings selected by
+// maximal marginal relevance, in the order of selection. Each step picks the embedding
+// maximizing lambda * sim(query, e) - (1 - lambda) * max(sim(e, selected)), where sim
+// is the cosine similarity.
+func MaximalMarginalRelevance(queryEmbedding []float32, candidateEmbeddings [][]float32, k int, lambda float32) []int {
+	k = min(k, len(candidateEmbeddings))
+	if k <= 0 {
+		return []int{}
+	}
+
+	relevance := make([]float32, len(candidateEmbeddings))
+	for i, embedding := range candidateEmbeddings {
+		relevance[i] = embeddings.CosineSimilarity(queryEmbedding, embedding)
+	}
+
+	// redundancy holds the highest similarity of each candidate to the selected ones.
+	redundancy := make([]float32, len(candidateEmbeddings))
+	for i := range redundancy {
+		redundancy[i] = float32(math.Inf(-1))
+	}
+	isSelected := make([]bool, len(candidateEmbeddings))
+	selected := make([]int, 0, k)
+	for len(selected) < k {
+		best, bestScore := -1, float32(math.Inf(-1))
+		for i := range candidateEmbeddings {
+			if isSelected[i] {
+				continue
+			}
+			score := lambda*relevance[i] - (1-lambda)*redundancy[i]
+			if len(selected) == 0 {
+				score = relevance[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		isSelected[best] = true
+		selected = append(selected, best)
+		for i, embedding := range candidateEmbeddings {
+			if !isSelected[i] {
+				redundancy[i] = max(redundancy[i], embeddings.CosineSimilarity(candidateEmbeddings[best], embedding))
+			}
+		}
+	}
+
+	return selected
+}
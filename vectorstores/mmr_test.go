@@ -0,0 +1,185 @@
+package vectorstores
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testEmbedder struct {
+	vectors map[string][]float32
+}
+
+func (e testEmbedder) EmbedDocuments(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		vectors = append(vectors, e.vectors[text])
+	}
+	return vectors, nil
+}
+
+func (e testEmbedder) EmbedQuery(_ context.Context, text string) ([]float32, error) {
+	return e.vectors[text], nil
+}
+
+// testStore returns the documents in the given order, ignoring the query.
+type testStore struct {
+	docs []schema.Document
+}
+
+func (s testStore) AddDocuments(context.Context, []schema.Document, ...Option) ([]string, error) {
+	return nil, nil
+}
+
+func (s testStore) SimilaritySearch(_ context.Context, _ string, numDocuments int, _ ...Option) ([]schema.Document, error) { //nolint:lll
+	return s.docs[:min(numDocuments, len(s.docs))], nil
+}
+
+// strictStore is a testStore rejecting the options it does not support, and recording
+// the options of the last search.
+type strictStore struct {
+	testStore
+	opts *Options
+}
+
+func (s strictStore) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...Option) ([]schema.Document, error) { //nolint:lll
+	opts := Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.MMR != nil {
+		return nil, errors.New("unsupported options")
+	}
+	*s.opts = opts
+	return s.testStore.SimilaritySearch(ctx, query, numDocuments)
+}
+
+// vectorStore is a testStore returning the stored vectors of the documents.
+type vectorStore struct {
+	testStore
+	vectors map[string][]float32
+}
+
+func (s vectorStore) SimilaritySearchWithVectors(ctx context.Context, query string, numDocuments int, options ...Option) (VectorSearchResult, error) { //nolint:lll
+	docs, err := s.SimilaritySearch(ctx, query, numDocuments, options...)
+	if err != nil {
+		return VectorSearchResult{}, err
+	}
+	result := VectorSearchResult{QueryVector: s.vectors[query], Documents: docs}
+	for _, doc := range docs {
+		result.Vectors = append(result.Vectors, s.vectors[doc.PageContent])
+	}
+	return result, nil
+}
+
+func TestMaximalMarginalRelevance(t *testing.T) {
+	t.Parallel()
+
+	query := []float32{1, 0}
+	candidates := [][]float32{
+		{1, 0.1},
+		{1, 0.11},
+		{1, -0.5},
+		{0, 1},
+	}
+
+	require.Equal(t, []int{0, 1, 2}, MaximalMarginalRelevance(query, candidates, 3, 1))
+	require.Equal(t, []int{0, 2, 1}, MaximalMarginalRelevance(query, candidates, 3, 0.5))
+	require.Equal(t, []int{0, 3, 2, 1}, MaximalMarginalRelevance(query, candidates, 10, 0))
+	require.Empty(t, MaximalMarginalRelevance(query, nil, 3, 0.5))
+}
+
+func TestMaxMarginalRelevanceSearchFallback(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	embedder := testEmbedder{vectors: map[string][]float32{
+		"query": {1, 0},
+		"a":     {1, 0.1},
+		"a2":    {1, 0.11},
+		"b":     {1, -0.5},
+	}}
+	store := testStore{docs: []schema.Document{
+		{PageContent: "a"},
+		{PageContent: "a2"},
+		{PageContent: "b"},
+	}}
+
+	_, err := MaxMarginalRelevanceSearch(ctx, store, "query", 2)
+	require.ErrorIs(t, err, ErrMMRUnsupported)
+
+	docs, err := MaxMarginalRelevanceSearch(ctx, store, "query", 2,
+		WithEmbedder(embedder), WithMaxMarginalRelevance(3, 0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "a", docs[0].PageContent)
+	require.Equal(t, "b", docs[1].PageContent)
+
+	docs, err = ToRetriever(store, 2, WithEmbedder(embedder), WithMaxMarginalRelevance(3, 0.5)).
+		GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Equal(t, "b", docs[1].PageContent)
+
+	docs, err = ToRetriever(store, 2).GetRelevantDocuments(ctx, "query")
+	require.NoError(t, err)
+	require.Equal(t, "a2", docs[1].PageContent)
+}
+
+func TestMaxMarginalRelevanceSearchFallbackOptions(t *testing.T) {
+	t.Parallel()
+
+	embedder := testEmbedder{vectors: map[string][]float32{
+		"query": {1, 0},
+		"a":     {1, 0.1},
+		"a2":    {1, 0.11},
+		"b":     {1, -0.5},
+	}}
+	var opts Options
+	store := strictStore{
+		testStore: testStore{docs: []schema.Document{
+			{PageContent: "a"},
+			{PageContent: "a2"},
+			{PageContent: "b"},
+		}},
+		opts: &opts,
+	}
+
+	docs, err := ToRetriever(store, 2,
+		WithEmbedder(embedder), WithMaxMarginalRelevance(3, 0.5), WithFilters(map[string]any{"k": "v"})).
+		GetRelevantDocuments(t.Context(), "query")
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "b", docs[1].PageContent)
+	require.Equal(t, map[string]any{"k": "v"}, opts.Filters)
+	// the store embeds the query with the embedder of the search
+	require.Equal(t, embedder, opts.Embedder)
+}
+
+func TestMaxMarginalRelevanceSearchStoredVectors(t *testing.T) {
+	t.Parallel()
+
+	store := vectorStore{
+		testStore: testStore{docs: []schema.Document{
+			{PageContent: "a"},
+			{PageContent: "a2"},
+			{PageContent: "b"},
+		}},
+		vectors: map[string][]float32{
+			"query": {1, 0},
+			"a":     {1, 0.1},
+			"a2":    {1, 0.11},
+			"b":     {1, -0.5},
+		},
+	}
+
+	// the candidates are selected with their stored vectors, without embedder
+	docs, err := MaxMarginalRelevanceSearch(t.Context(), store, "query", 2, WithMaxMarginalRelevance(3, 0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Equal(t, "a", docs[0].PageContent)
+	require.Equal(t, "b", docs[1].PageContent)
+}
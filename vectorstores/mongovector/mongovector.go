@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/vxcontrol/langchaingo/embeddings"
 	"github.com/vxcontrol/langchaingo/schema"
@@ -26,6 +27,7 @@ var (
 	ErrEmbedderWrongNumberVectors = errors.New("number of vectors from embedder does not match number of documents")
 	ErrUnsupportedOptions         = errors.New("unsupported options")
 	ErrInvalidScoreThreshold      = errors.New("score threshold must be between 0 and 1")
+	ErrInvalidFilters             = errors.New("invalid filters")
 )
 
 // Store wraps a Mongo collection for writing to and searching an Atlas
@@ -38,7 +40,10 @@ type Store struct {
 	numCandidates int
 }
 
-var _ vectorstores.VectorStore = &Store{}
+var (
+	_ vectorstores.VectorStore     = &Store{}
+	_ vectorstores.DocumentManager = &Store{}
+)
 
 // New returns a Store that can read and write to the vector store.
 func New(coll *mongo.Collection, embedder embeddings.Embedder, opts ...Option) Store {
@@ -249,3 +254,161 @@ func (store *Store) SimilaritySearch(
 
 	return found, nil
 }
+
+// storedDocument is a document of the collection as read by GetDocuments.
+type storedDocument struct {
+	ID          any            `bson:"_id"`
+	PageContent string         `bson:"pageContent"`
+	Metadata    map[string]any `bson:"metadata"`
+}
+
+// documentID returns the _id of the document with the given id. The ids returned by
+// AddDocuments are object ids, either hex or formatted as ObjectID("hex"), other ids are
+// stored as strings.
+func documentID(id string) any {
+	hex := strings.TrimSuffix(strings.TrimPrefix(id, `ObjectID("`), `")`)
+	if oid, err := bson.ObjectIDFromHex(hex); err == nil {
+		return oid
+	}
+	return id
+}
+
+// idKey returns a comparable key of a document _id.
+func idKey(id any) string {
+	if oid, ok := id.(bson.ObjectID); ok {
+		return oid.Hex()
+	}
+	return fmt.Sprint(id)
+}
+
+// GetDocuments returns the documents with the given ids in the order of ids.
+func (store *Store) GetDocuments(
+	ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	docIDs := make([]any, 0, len(ids))
+	for _, id := range ids {
+		docIDs = append(docIDs, documentID(id))
+	}
+	cur, err := store.coll.Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: docIDs}}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	found := make(map[string]schema.Document, len(ids))
+	for cur.Next(ctx) {
+		var stored storedDocument
+		if err := cur.Decode(&stored); err != nil {
+			return nil, err
+		}
+		found[idKey(stored.ID)] = schema.Document{PageContent: stored.PageContent, Metadata: stored.Metadata}
+	}
+	if err := cur.Err(); err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, docID := range docIDs {
+		if doc, ok := found[idKey(docID)]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// UpsertDocuments embeds the documents and stores them under the given ids,
+// replacing the documents that already exist with the same ids.
+func (store *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	opts ...vectorstores.Option,
+) ([]string, error) {
+	cfg, err := mergeAddOpts(store, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+	if len(docs) == 0 {
+		return []string{}, nil
+	}
+
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+	vectors, err := cfg.Embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+
+	models := make([]mongo.WriteModel, 0, len(docs))
+	for i := range docs {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: documentID(ids[i])}}).
+			SetReplacement(bson.D{
+				{Key: pageContentName, Value: docs[i].PageContent},
+				{Key: store.path, Value: vectors[i]},
+				{Key: metadataName, Value: docs[i].Metadata},
+			}).
+			SetUpsert(true))
+	}
+	if _, err := store.coll.BulkWrite(ctx, models); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteDocuments removes the documents with the given ids.
+func (store *Store) DeleteDocuments(
+	ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	docIDs := make([]any, 0, len(ids))
+	for _, id := range ids {
+		docIDs = append(docIDs, documentID(id))
+	}
+	_, err := store.coll.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: docIDs}}}})
+	return err
+}
+
+// DeleteDocumentsByFilter removes the documents matching the filters, a non-empty
+// MQL matching expression as for SimilaritySearch. A vectorstores.Filter is not
+// translated and is rejected with vectorstores.ErrUnsupportedFilter.
+func (store *Store) DeleteDocumentsByFilter(
+	ctx context.Context,
+	filters any,
+	_ ...vectorstores.Option,
+) error {
+	if filters == nil {
+		return ErrInvalidFilters
+	}
+	if _, ok := vectorstores.GetFilter(filters); ok {
+		return fmt.Errorf("%w: mongovector filters are MQL expressions", vectorstores.ErrUnsupportedFilter)
+	}
+	// an empty expression matches, and would delete, the whole collection
+	raw, err := bson.Marshal(filters)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+	}
+	if elements, err := bson.Raw(raw).Elements(); err != nil || len(elements) == 0 {
+		return ErrInvalidFilters
+	}
+	_, err = store.coll.DeleteMany(ctx, filters)
+	return err
+}
@@ -0,0 +1,22 @@
+package mongovector
+
+import (
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestDeleteDocumentsByFilterRejectsFilters(t *testing.T) {
+	t.Parallel()
+
+	// the filters are rejected before the collection is used
+	store := &Store{}
+	for _, filters := range []any{nil, bson.D{}, bson.M{}, map[string]any{}, "not a document"} {
+		require.ErrorIs(t, store.DeleteDocumentsByFilter(t.Context(), filters), ErrInvalidFilters, "%v", filters)
+	}
+	err := store.DeleteDocumentsByFilter(t.Context(), vectorstores.Eq("year", 2024))
+	require.ErrorIs(t, err, vectorstores.ErrUnsupportedFilter)
+}
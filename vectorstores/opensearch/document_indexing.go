@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+
+	"github.com/vxcontrol/langchaingo/schema"
 
 	"github.com/opensearch-project/opensearch-go/opensearchapi"
 )
@@ -43,3 +46,88 @@ func (s *Store) documentIndexing(
 
 	return indice.Do(ctx, s.client)
 }
+
+type mgetResults struct {
+	Docs []struct {
+		ID     string   `json:"_id"`
+		Found  bool     `json:"found"`
+		Source document `json:"_source"`
+	} `json:"docs"`
+}
+
+func (s *Store) documentsGet(
+	ctx context.Context,
+	indexName string,
+	ids []string,
+) ([]schema.Document, error) {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]interface{}{"ids": ids}); err != nil {
+		return nil, fmt.Errorf("error encoding ids to json buffer %w", err)
+	}
+
+	mget := opensearchapi.MgetRequest{
+		Index: indexName,
+		Body:  buf,
+	}
+	response, err := mget.Do(ctx, s.client)
+	if err != nil {
+		return nil, fmt.Errorf("mget.Do err: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading mget response body: %w", err)
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("error getting documents: %s", body)
+	}
+
+	results := mgetResults{}
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("error unmarshalling mget response body: %w %s", err, body)
+	}
+
+	docs := make([]schema.Document, 0, len(results.Docs))
+	for _, doc := range results.Docs {
+		if !doc.Found {
+			continue
+		}
+		docs = append(docs, schema.Document{
+			PageContent: doc.Source.FieldsContent,
+			Metadata:    doc.Source.FieldsMetadata,
+		})
+	}
+
+	return docs, nil
+}
+
+func (s *Store) documentsDeleteByQuery(
+	ctx context.Context,
+	indexName string,
+	query any,
+) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(map[string]interface{}{"query": query}); err != nil {
+		return fmt.Errorf("error encoding query to json buffer %w", err)
+	}
+
+	refresh := true
+	deleteByQuery := opensearchapi.DeleteByQueryRequest{
+		Index:   []string{indexName},
+		Body:    buf,
+		Refresh: &refresh,
+	}
+	response, err := deleteByQuery.Do(ctx, s.client)
+	if err != nil {
+		return fmt.Errorf("deleteByQuery.Do err: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.IsError() {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("error deleting documents: %s", body)
+	}
+
+	return nil
+}
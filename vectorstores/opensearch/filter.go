@@ -0,0 +1,93 @@
+package opensearch
+
+import (
+	"fmt"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+// convertFilter returns the filters as an OpenSearch query clause.
+// A vectorstores.Filter is translated, any other value is passed as is.
+func convertFilter(filters any) (any, error) {
+	filter, ok := vectorstores.GetFilter(filters)
+	if !ok {
+		return filters, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return filterToQuery(filter)
+}
+
+// filterToQuery translates the filter into an OpenSearch query clause on the
+// metadata field. Strings are matched on the "keyword" sub-field created by the
+// default dynamic mapping, so the comparison is exact.
+//
+//nolint:cyclop
+func filterToQuery(filter vectorstores.Filter) (map[string]any, error) {
+	switch filter.Operator {
+	case vectorstores.FilterAnd, vectorstores.FilterOr, vectorstores.FilterNot:
+		clauses := make([]any, 0, len(filter.Filters))
+		for _, f := range filter.Filters {
+			clause, err := filterToQuery(f)
+			if err != nil {
+				return nil, err
+			}
+			clauses = append(clauses, clause)
+		}
+		switch filter.Operator { //nolint:exhaustive
+		case vectorstores.FilterAnd:
+			return boolQuery("filter", clauses), nil
+		case vectorstores.FilterOr:
+			query := boolQuery("should", clauses)
+			query["bool"].(map[string]any)["minimum_should_match"] = 1
+			return query, nil
+		default:
+			return boolQuery("must_not", clauses), nil
+		}
+	case vectorstores.FilterEq:
+		return termQuery(filter.Key, filter.Value), nil
+	case vectorstores.FilterNe:
+		return boolQuery("must_not", []any{termQuery(filter.Key, filter.Value)}), nil
+	case vectorstores.FilterIn, vectorstores.FilterNin:
+		values, _ := filter.Values()
+		terms := make([]any, 0, len(values))
+		for _, value := range values {
+			terms = append(terms, termQuery(filter.Key, value))
+		}
+		if filter.Operator == vectorstores.FilterNin {
+			return boolQuery("must_not", terms), nil
+		}
+		query := boolQuery("should", terms)
+		query["bool"].(map[string]any)["minimum_should_match"] = 1
+		return query, nil
+	case vectorstores.FilterGt, vectorstores.FilterGte, vectorstores.FilterLt, vectorstores.FilterLte:
+		return map[string]any{
+			"range": map[string]any{
+				metadataField(filter.Key, filter.Value): map[string]any{string(filter.Operator): filter.Value},
+			},
+		}, nil
+	case vectorstores.FilterExists:
+		return map[string]any{"exists": map[string]any{"field": "metadata." + filter.Key}}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", vectorstores.ErrInvalidFilter, filter.Operator)
+	}
+}
+
+// boolQuery returns a bool query with the clauses in the given occurrence type.
+func boolQuery(occur string, clauses []any) map[string]any {
+	return map[string]any{"bool": map[string]any{occur: clauses}}
+}
+
+// termQuery returns a term query matching the metadata value at key.
+func termQuery(key string, value any) map[string]any {
+	return map[string]any{"term": map[string]any{metadataField(key, value): value}}
+}
+
+// metadataField returns the field of the metadata key to query for the value type.
+func metadataField(key string, value any) string {
+	if _, ok := value.(string); ok {
+		return "metadata." + key + ".keyword"
+	}
+	return "metadata." + key
+}
@@ -33,6 +33,10 @@ var (
 	ErrAssertingMetadata = errors.New(
 		"couldn't assert metadata to map",
 	)
+	// ErrMissingFilters filters must be provided to delete documents by filter.
+	ErrMissingFilters = errors.New(
+		"missing filters",
+	)
 )
 
 // New creates and returns a vectorstore object for Opensearch
@@ -49,7 +53,10 @@ func New(client *opensearchgo.Client, opts ...Option) (Store, error) {
 	return s, nil
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore     = Store{}
+	_ vectorstores.DocumentManager = Store{}
+)
 
 // AddDocuments adds the text and metadata from the documents to the Chroma collection associated with 'Store'.
 // and returns the ids of the added documents.
@@ -59,7 +66,94 @@ func (s Store) AddDocuments(
 	options ...vectorstores.Option,
 ) ([]string, error) {
 	opts := s.getOptions(options...)
-	ids := []string{}
+	ids := make([]string, 0, len(docs))
+	for range docs {
+		ids = append(ids, uuid.NewString())
+	}
+
+	return s.indexDocuments(ctx, opts.NameSpace, ids, docs)
+}
+
+// GetDocuments returns the documents with the given ids from the index set with
+// vectorstores.WithNameSpace, in the order of ids. Ids that are not found are skipped.
+func (s Store) GetDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	return s.documentsGet(ctx, opts.NameSpace, ids)
+}
+
+// UpsertDocuments indexes the documents with the given ids in the index set with
+// vectorstores.WithNameSpace, replacing the documents that already exist.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	return s.indexDocuments(ctx, opts.NameSpace, ids, docs)
+}
+
+// DeleteDocuments deletes the documents with the given ids from the index set with
+// vectorstores.WithNameSpace.
+func (s Store) DeleteDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := map[string]interface{}{
+		"ids": map[string]interface{}{
+			"values": ids,
+		},
+	}
+	return s.documentsDeleteByQuery(ctx, opts.NameSpace, query)
+}
+
+// DeleteDocumentsByFilter deletes the documents matching filters, an OpenSearch
+// query clause (eg: {"term": {"metadata.source": "a.txt"}}) or a vectorstores.Filter,
+// from the index set with vectorstores.WithNameSpace.
+func (s Store) DeleteDocumentsByFilter(
+	ctx context.Context,
+	filters any,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if filters == nil {
+		return ErrMissingFilters
+	}
+
+	query, err := convertFilter(filters)
+	if err != nil {
+		return err
+	}
+
+	return s.documentsDeleteByQuery(ctx, opts.NameSpace, query)
+}
+
+// indexDocuments embeds the documents and indexes them with the given ids.
+func (s Store) indexDocuments(
+	ctx context.Context,
+	indexName string,
+	ids []string,
+	docs []schema.Document,
+) ([]string, error) {
+	indexed := []string{}
 	texts := []string{}
 
 	for _, doc := range docs {
@@ -68,27 +162,27 @@ func (s Store) AddDocuments(
 
 	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
 	if err != nil {
-		return ids, err
+		return indexed, err
 	}
 
 	if len(vectors) != len(docs) {
-		return ids, ErrNumberOfVectorDoesNotMatch
+		return indexed, ErrNumberOfVectorDoesNotMatch
 	}
 
 	for i, doc := range docs {
-		id := uuid.NewString()
-		_, err := s.documentIndexing(ctx, id, opts.NameSpace, doc.PageContent, vectors[i], doc.Metadata)
+		_, err := s.documentIndexing(ctx, ids[i], indexName, doc.PageContent, vectors[i], doc.Metadata)
 		if err != nil {
-			return ids, err
+			return indexed, err
 		}
-		ids = append(ids, id)
+		indexed = append(indexed, ids[i])
 	}
 
-	return ids, nil
+	return indexed, nil
 }
 
 // SimilaritySearch creates a vector embedding from the query using the embedder
-// and queries to find the most similar documents.
+// and queries to find the most similar documents. Filters set with vectorstores.WithFilters,
+// an OpenSearch query clause or a vectorstores.Filter, are applied to the nearest neighbors.
 func (s Store) SimilaritySearch(
 	ctx context.Context,
 	query string,
@@ -102,17 +196,31 @@ func (s Store) SimilaritySearch(
 		return nil, err
 	}
 
-	searchPayload := map[string]interface{}{
-		"size": numDocuments,
-		"query": map[string]interface{}{
-			"knn": map[string]interface{}{
-				"contentVector": map[string]interface{}{
-					"vector": queryVector,
-					"k":      numDocuments,
-				},
+	var searchQuery any = map[string]interface{}{
+		"knn": map[string]interface{}{
+			"contentVector": map[string]interface{}{
+				"vector": queryVector,
+				"k":      numDocuments,
 			},
 		},
 	}
+	if opts.Filters != nil {
+		filter, err := convertFilter(opts.Filters)
+		if err != nil {
+			return nil, err
+		}
+		searchQuery = map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   []any{searchQuery},
+				"filter": []any{filter},
+			},
+		}
+	}
+
+	searchPayload := map[string]interface{}{
+		"size":  numDocuments,
+		"query": searchQuery,
+	}
 
 	buf := new(bytes.Buffer)
 	if err := json.NewEncoder(buf).Encode(searchPayload); err != nil {
@@ -17,6 +17,7 @@ type Options struct {
 	Filters        any
 	Embedder       embeddings.Embedder
 	Deduplicater   func(context.Context, schema.Document) bool
+	MMR            *MMROptions
 }
 
 // WithNameSpace returns an Option for setting the name space.
@@ -59,3 +60,16 @@ func WithDeduplicater(fn func(ctx context.Context, doc schema.Document) bool) Op
 		o.Deduplicater = fn
 	}
 }
+
+// WithMaxMarginalRelevance returns an Option for searching with maximal marginal relevance:
+// fetchK candidates are fetched and the results are selected among them balancing relevance
+// to the query (lambda = 1) and diversity (lambda = 0). It is used by MaxMarginalRelevanceSearch
+// and makes the Retriever returned by ToRetriever search with maximal marginal relevance.
+func WithMaxMarginalRelevance(fetchK int, lambda float32) Option {
+	return func(o *Options) {
+		o.MMR = &MMROptions{
+			FetchK: fetchK,
+			Lambda: lambda,
+		}
+	}
+}
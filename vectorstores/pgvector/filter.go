@@ -0,0 +1,100 @@
+package pgvector
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+// filterToSQL translates the filter into an SQL condition on the jsonb metadata column.
+// The filter values are appended to args and referenced as positional parameters.
+// A metadata list is expanded so that a comparison matches any of its elements.
+//
+//nolint:cyclop
+func filterToSQL(filter vectorstores.Filter, column string, args []any) (string, []any, error) {
+	switch filter.Operator {
+	case vectorstores.FilterAnd, vectorstores.FilterOr:
+		conditions := make([]string, 0, len(filter.Filters))
+		for _, f := range filter.Filters {
+			condition, newArgs, err := filterToSQL(f, column, args)
+			if err != nil {
+				return "", nil, err
+			}
+			conditions = append(conditions, condition)
+			args = newArgs
+		}
+		separator := " AND "
+		if filter.Operator == vectorstores.FilterOr {
+			separator = " OR "
+		}
+		return "(" + strings.Join(conditions, separator) + ")", args, nil
+	case vectorstores.FilterNot:
+		condition, args, err := filterToSQL(filter.Filters[0], column, args)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + condition, args, nil
+	case vectorstores.FilterNe:
+		condition, args, err := filterToSQL(vectorstores.Eq(filter.Key, filter.Value), column, args)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + condition, args, nil
+	case vectorstores.FilterNin:
+		in := vectorstores.Filter{Operator: vectorstores.FilterIn, Key: filter.Key, Value: filter.Value}
+		condition, args, err := filterToSQL(in, column, args)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + condition, args, nil
+	}
+
+	// jsonb_extract_path returns NULL when the key is missing
+	args = append(args, strings.Split(filter.Key, "."))
+	path := fmt.Sprintf("jsonb_extract_path(%s, VARIADIC $%d::text[])", column, len(args))
+	if strings.Contains(filter.Key, ".") {
+		// a key that exists verbatim takes precedence over the nested lookup
+		args = append(args, filter.Key)
+		path = fmt.Sprintf("(CASE WHEN %s ? $%d::text THEN %s->$%d::text ELSE %s END)",
+			column, len(args), column, len(args), path)
+	}
+
+	if filter.Operator == vectorstores.FilterExists {
+		return fmt.Sprintf("(%s IS NOT NULL AND %s <> 'null'::jsonb)", path, path), args, nil
+	}
+
+	var value any = filter.Value
+	if filter.Operator == vectorstores.FilterIn {
+		value, _ = filter.Values()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", vectorstores.ErrInvalidFilter, err)
+	}
+	args = append(args, string(data))
+	param := fmt.Sprintf("$%d::jsonb", len(args))
+
+	var condition string
+	switch filter.Operator { //nolint:exhaustive
+	case vectorstores.FilterEq:
+		condition = "e = " + param
+	case vectorstores.FilterIn:
+		condition = fmt.Sprintf("e IN (SELECT jsonb_array_elements(%s))", param)
+	case vectorstores.FilterGt:
+		condition = fmt.Sprintf("jsonb_typeof(e) = jsonb_typeof(%s) AND e > %s", param, param)
+	case vectorstores.FilterGte:
+		condition = fmt.Sprintf("jsonb_typeof(e) = jsonb_typeof(%s) AND e >= %s", param, param)
+	case vectorstores.FilterLt:
+		condition = fmt.Sprintf("jsonb_typeof(e) = jsonb_typeof(%s) AND e < %s", param, param)
+	case vectorstores.FilterLte:
+		condition = fmt.Sprintf("jsonb_typeof(e) = jsonb_typeof(%s) AND e <= %s", param, param)
+	default:
+		return "", nil, fmt.Errorf("%w: unknown operator %q", vectorstores.ErrInvalidFilter, filter.Operator)
+	}
+
+	return fmt.Sprintf(`EXISTS (SELECT 1 FROM jsonb_array_elements(
+	CASE WHEN jsonb_typeof(%s) = 'array' THEN %s ELSE jsonb_build_array(%s) END) AS e WHERE %s)`,
+		path, path, path, condition), args, nil
+}
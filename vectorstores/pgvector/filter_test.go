@@ -0,0 +1,42 @@
+package pgvector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterToSQL(t *testing.T) {
+	t.Parallel()
+
+	filter := vectorstores.And(
+		vectorstores.Eq("author.name", "ann"),
+		vectorstores.Not(vectorstores.In("year", 2023, 2024)),
+		vectorstores.Exists("tags"),
+	)
+	condition, args, err := filterToSQL(filter, "cmetadata", []any{"collection"})
+	require.NoError(t, err)
+
+	require.Equal(t, []any{
+		"collection",
+		[]string{"author", "name"}, "author.name", `"ann"`,
+		[]string{"year"}, `[2023,2024]`,
+		[]string{"tags"},
+	}, args)
+	require.True(t, strings.HasPrefix(condition, "(EXISTS"))
+	require.Contains(t, condition, "(CASE WHEN cmetadata ? $3::text THEN cmetadata->$3::text "+
+		"ELSE jsonb_extract_path(cmetadata, VARIADIC $2::text[]) END)")
+	require.Contains(t, condition, "WHERE e = $4::jsonb)")
+	require.Contains(t, condition, " AND NOT EXISTS")
+	require.Contains(t, condition, "jsonb_extract_path(cmetadata, VARIADIC $5::text[])")
+	require.Contains(t, condition, "WHERE e IN (SELECT jsonb_array_elements($6::jsonb)))")
+	require.Contains(t, condition, "(jsonb_extract_path(cmetadata, VARIADIC $7::text[]) IS NOT NULL")
+
+	condition, args, err = filterToSQL(vectorstores.Lt("score", 0.5), "data.cmetadata", nil)
+	require.NoError(t, err)
+	require.Equal(t, []any{[]string{"score"}, "0.5"}, args)
+	require.Contains(t, condition, "jsonb_typeof(e) = jsonb_typeof($2::jsonb) AND e < $2::jsonb")
+}
@@ -72,7 +72,11 @@ type HNSWIndex struct {
 	distanceFunction string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore                  = Store{}
+	_ vectorstores.DocumentManager              = Store{}
+	_ vectorstores.MaxMarginalRelevanceSearcher = Store{}
+)
 
 // New creates a new Store with options.
 func New(ctx context.Context, opts ...Option) (Store, error) {
@@ -238,24 +242,11 @@ func (s Store) AddDocuments(
 
 	docs = s.deduplicate(ctx, opts, docs)
 
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
-	}
-
-	embedder := s.embedder
-	if opts.Embedder != nil {
-		embedder = opts.Embedder
-	}
-	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	vectors, err := s.embedDocuments(ctx, opts, docs)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(vectors) != len(docs) {
-		return nil, ErrEmbedderWrongNumberVectors
-	}
-
 	b := &pgx.Batch{}
 	sql := fmt.Sprintf(`INSERT INTO %s (uuid, document, embedding, cmetadata, collection_id)
 		VALUES($1, $2, $3, $4, $5)`, s.embeddingTableName)
@@ -269,7 +260,6 @@ func (s Store) AddDocuments(
 	return ids, s.conn.SendBatch(ctx, b).Close()
 }
 
-//nolint:cyclop
 func (s Store) SimilaritySearch(
 	ctx context.Context,
 	query string,
@@ -277,14 +267,50 @@ func (s Store) SimilaritySearch(
 	options ...vectorstores.Option,
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
+	_, docs, _, err := s.similaritySearch(ctx, query, numDocuments, opts, false)
+	return docs, err
+}
+
+// MaxMarginalRelevanceSearch fetches the candidates most similar to the query
+// (see vectorstores.WithMaxMarginalRelevance) with their embeddings and selects
+// numDocuments of them with maximal marginal relevance.
+func (s Store) MaxMarginalRelevanceSearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	mmr, err := vectorstores.GetMMROptions(opts, numDocuments)
+	if err != nil {
+		return nil, err
+	}
+	queryVector, docs, vectors, err := s.similaritySearch(ctx, query, mmr.FetchK, opts, true)
+	if err != nil {
+		return nil, err
+	}
+	return vectorstores.SelectByMaximalMarginalRelevance(queryVector, docs, vectors, numDocuments, mmr.Lambda)
+}
+
+// similaritySearch returns the query embedding and the documents most similar to it,
+// with their embeddings when withEmbeddings is set.
+//
+//nolint:cyclop,funlen
+func (s Store) similaritySearch(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	opts vectorstores.Options,
+	withEmbeddings bool,
+) ([]float32, []schema.Document, [][]float32, error) {
 	collectionName := s.getNameSpace(opts)
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	filter, err := s.getFilters(opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	embedder := s.embedder
 	if opts.Embedder != nil {
@@ -292,8 +318,9 @@ func (s Store) SimilaritySearch(
 	}
 	embedderData, err := embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	dims := len(embedderData)
 	whereQuerys := make([]string, 0)
 	if scoreThreshold != 0 {
 		whereQuerys = append(whereQuerys, fmt.Sprintf("data.distance < %f", 1-scoreThreshold))
@@ -301,11 +328,22 @@ func (s Store) SimilaritySearch(
 	for k, v := range filter {
 		whereQuerys = append(whereQuerys, fmt.Sprintf("(data.cmetadata ->> '%s') = '%s'", k, v))
 	}
+	condition, args, err := s.filterCondition(opts.Filters, "data.cmetadata",
+		[]any{dims, pgvector.NewVector(embedderData), numDocuments})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if condition != "" {
+		whereQuerys = append(whereQuerys, condition)
+	}
 	whereQuery := strings.Join(whereQuerys, " AND ")
 	if len(whereQuery) == 0 {
 		whereQuery = "TRUE"
 	}
-	dims := len(embedderData)
+	embeddingColumn := ""
+	if withEmbeddings {
+		embeddingColumn = ",\n\tdata.embedding"
+	}
 	sql := fmt.Sprintf(`WITH filtered_embedding_dims AS MATERIALIZED (
     SELECT
         *
@@ -319,7 +357,7 @@ func (s Store) SimilaritySearch(
 SELECT
 	data.document,
 	data.cmetadata,
-	(1 - data.distance) AS score
+	(1 - data.distance) AS score%s
 FROM (
 	SELECT
 		filtered_embedding_dims.*,
@@ -330,24 +368,33 @@ FROM (
 WHERE %s
 ORDER BY
 	data.distance
-LIMIT $3`, s.embeddingTableName,
+LIMIT $3`, s.embeddingTableName, embeddingColumn,
 		s.collectionTableName, s.collectionTableName, s.collectionTableName, collectionName,
 		whereQuery)
-	rows, err := s.conn.Query(ctx, sql, dims, pgvector.NewVector(embedderData), numDocuments)
+	rows, err := s.conn.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	defer rows.Close()
 
 	docs := make([]schema.Document, 0)
+	vectors := make([][]float32, 0)
 	for rows.Next() {
 		doc := schema.Document{}
-		if err := rows.Scan(&doc.PageContent, &doc.Metadata, &doc.Score); err != nil {
-			return nil, err
+		dest := []any{&doc.PageContent, &doc.Metadata, &doc.Score}
+		var vector pgvector.Vector
+		if withEmbeddings {
+			dest = append(dest, &vector)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, nil, err
 		}
 		docs = append(docs, doc)
+		if withEmbeddings {
+			vectors = append(vectors, vector.Slice())
+		}
 	}
-	return docs, rows.Err()
+	return embedderData, docs, vectors, rows.Err()
 }
 
 //nolint:cyclop
@@ -366,6 +413,13 @@ func (s Store) Search(
 	for k, v := range filter {
 		whereQuerys = append(whereQuerys, fmt.Sprintf("(%s.cmetadata ->> '%s') = '%s'", s.embeddingTableName, k, v))
 	}
+	condition, args, err := s.filterCondition(opts.Filters, s.embeddingTableName+".cmetadata", []any{numDocuments})
+	if err != nil {
+		return nil, err
+	}
+	if condition != "" {
+		whereQuerys = append(whereQuerys, condition)
+	}
 	whereQuery := strings.Join(whereQuerys, " AND ")
 	if len(whereQuery) == 0 {
 		whereQuery = "TRUE"
@@ -379,7 +433,7 @@ WHERE %s.name='%s' AND %s
 LIMIT $1`, s.embeddingTableName, s.embeddingTableName, s.embeddingTableName,
 		s.collectionTableName, s.embeddingTableName, s.collectionTableName, s.collectionTableName, collectionName,
 		whereQuery)
-	rows, err := s.conn.Query(ctx, sql, numDocuments)
+	rows, err := s.conn.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -396,6 +450,146 @@ LIMIT $1`, s.embeddingTableName, s.embeddingTableName, s.embeddingTableName,
 	return docs, rows.Err()
 }
 
+// GetDocuments returns the documents with the given ids from the collection
+// (or the collection set with WithNameSpace) in the order of ids.
+func (s Store) GetDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	collectionName := s.getNameSpace(opts)
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	sql := fmt.Sprintf(`SELECT
+	%s.uuid::text,
+	%s.document,
+	%s.cmetadata
+FROM %s
+JOIN %s ON %s.collection_id=%s.uuid
+WHERE %s.name=$1 AND %s.uuid::text = ANY($2)`, s.embeddingTableName, s.embeddingTableName, s.embeddingTableName,
+		s.embeddingTableName, s.collectionTableName, s.embeddingTableName, s.collectionTableName,
+		s.collectionTableName, s.embeddingTableName)
+	rows, err := s.conn.Query(ctx, sql, collectionName, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]schema.Document, len(ids))
+	for rows.Next() {
+		var id string
+		doc := schema.Document{}
+		if err := rows.Scan(&id, &doc.PageContent, &doc.Metadata); err != nil {
+			return nil, err
+		}
+		found[id] = doc
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// UpsertDocuments embeds the documents and stores them under the given ids,
+// replacing the documents that already exist with the same ids.
+func (s Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+	if opts.ScoreThreshold != 0 || opts.Filters != nil || opts.NameSpace != "" {
+		return nil, ErrUnsupportedOptions
+	}
+
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	vectors, err := s.embedDocuments(ctx, opts, docs)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &pgx.Batch{}
+	sql := fmt.Sprintf(`INSERT INTO %s (uuid, document, embedding, cmetadata, collection_id)
+		VALUES($1, $2, $3, $4, $5) ON CONFLICT (uuid) DO
+		UPDATE SET document = $2, embedding = $3, cmetadata = $4, collection_id = $5`, s.embeddingTableName)
+
+	for docIdx, doc := range docs {
+		b.Queue(sql, ids[docIdx], doc.PageContent, pgvector.NewVector(vectors[docIdx]), doc.Metadata, s.collectionUUID)
+	}
+	return ids, s.conn.SendBatch(ctx, b).Close()
+}
+
+// DeleteDocuments removes the documents with the given ids from the collection
+// (or the collection set with WithNameSpace).
+func (s Store) DeleteDocuments(
+	ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	collectionName := s.getNameSpace(opts)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s
+WHERE collection_id = (SELECT uuid FROM %s WHERE name = $1) AND uuid::text = ANY($2)`,
+		s.embeddingTableName, s.collectionTableName)
+	_, err := s.conn.Exec(ctx, sql, collectionName, ids)
+	return err
+}
+
+// DeleteDocumentsByFilter removes the documents which metadata matches the filters
+// from the collection (or the collection set with WithNameSpace). As for SimilaritySearch
+// filters must be a vectorstores.Filter or a map[key]value, an empty map is rejected.
+func (s Store) DeleteDocumentsByFilter(
+	ctx context.Context,
+	filters any,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	collectionName := s.getNameSpace(opts)
+	args := []any{collectionName}
+	whereQuerys := make([]string, 0)
+	if _, ok := vectorstores.GetFilter(filters); ok {
+		condition, filterArgs, err := s.filterCondition(filters, "cmetadata", args)
+		if err != nil {
+			return err
+		}
+		args = filterArgs
+		whereQuerys = append(whereQuerys, condition)
+	} else {
+		filter, ok := filters.(map[string]any)
+		if !ok || len(filter) == 0 {
+			return ErrInvalidFilters
+		}
+		for k, v := range filter {
+			args = append(args, k, fmt.Sprint(v))
+			whereQuerys = append(whereQuerys, fmt.Sprintf("(cmetadata ->> $%d) = $%d", len(args)-1, len(args)))
+		}
+	}
+
+	sql := fmt.Sprintf(`DELETE FROM %s
+WHERE collection_id = (SELECT uuid FROM %s WHERE name = $1) AND %s`,
+		s.embeddingTableName, s.collectionTableName, strings.Join(whereQuerys, " AND "))
+	_, err := s.conn.Exec(ctx, sql, args...)
+	return err
+}
+
 func (s Store) DropTables(ctx context.Context) error {
 	if _, err := s.conn.Exec(ctx, fmt.Sprintf(`DROP TABLE IF EXISTS %s`, s.embeddingTableName)); err != nil {
 		return err
@@ -446,18 +640,62 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-// getFilters return metadata filters, now only support map[key]value pattern
-// TODO: should support more types like {"key1": {"key2":"values2"}} or {"key": ["value1", "values2"]}.
+// getFilters return metadata filters of the map[key]value pattern. A vectorstores.Filter
+// is translated by filterCondition instead, so an empty map is returned for it.
 func (s Store) getFilters(opts vectorstores.Options) (map[string]any, error) {
 	if opts.Filters != nil {
 		if filters, ok := opts.Filters.(map[string]any); ok {
 			return filters, nil
 		}
+		if _, ok := vectorstores.GetFilter(opts.Filters); ok {
+			return map[string]any{}, nil
+		}
 		return nil, ErrInvalidFilters
 	}
 	return map[string]any{}, nil
 }
 
+// filterCondition returns the SQL condition on the metadata column for a
+// vectorstores.Filter, with its parameters appended to args. An empty condition
+// is returned when filters is not a vectorstores.Filter.
+func (s Store) filterCondition(filters any, column string, args []any) (string, []any, error) {
+	filter, ok := vectorstores.GetFilter(filters)
+	if !ok {
+		return "", args, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+	}
+	return filterToSQL(filter, column, args)
+}
+
+// embedDocuments returns the embeddings of the documents using the embedder
+// from the options or the store embedder.
+func (s Store) embedDocuments(
+	ctx context.Context,
+	opts vectorstores.Options,
+	docs []schema.Document,
+) ([][]float32, error) {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	embedder := s.embedder
+	if opts.Embedder != nil {
+		embedder = opts.Embedder
+	}
+	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vectors) != len(docs) {
+		return nil, ErrEmbedderWrongNumberVectors
+	}
+	return vectors, nil
+}
+
 func (s Store) deduplicate(
 	ctx context.Context,
 	opts vectorstores.Options,
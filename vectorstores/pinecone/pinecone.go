@@ -29,6 +29,8 @@ var (
 	ErrEmptyResponse         = errors.New("empty response")
 	ErrInvalidScoreThreshold = errors.New(
 		"score threshold must be between 0 and 1")
+	// ErrMissingFilters is returned in DeleteDocumentsByFilter if no filters are given.
+	ErrMissingFilters = errors.New("missing filters")
 )
 
 // Store is a wrapper around the pinecone rest API and grpc client.
@@ -43,6 +45,12 @@ type Store struct {
 	httpClient *http.Client
 }
 
+var (
+	_ vectorstores.VectorStore                  = Store{}
+	_ vectorstores.DocumentManager              = Store{}
+	_ vectorstores.MaxMarginalRelevanceSearcher = Store{}
+)
+
 // New creates a new Store with options. Options for WithAPIKey, WithHost and WithEmbedder must be set.
 func New(opts ...Option) (Store, error) {
 	s, err := applyClientOptions(opts...)
@@ -66,6 +74,124 @@ func New(opts ...Option) (Store, error) {
 func (s Store) AddDocuments(ctx context.Context,
 	docs []schema.Document,
 	options ...vectorstores.Option,
+) ([]string, error) {
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+
+	return s.upsertDocuments(ctx, ids, docs, options...)
+}
+
+// GetDocuments fetches the vectors with the given ids from the pinecone index
+// and returns them as documents in the order of ids. Missing ids are skipped.
+func (s Store) GetDocuments(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.Index(pinecone.NewIndexConnParams{
+		Host:      s.host,
+		Namespace: s.getNameSpace(opts),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer indexConn.Close()
+
+	fetchResult, err := indexConn.FetchVectors(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, 0, len(fetchResult.Vectors))
+	for _, id := range ids {
+		vector, ok := fetchResult.Vectors[id]
+		if !ok || vector == nil {
+			continue
+		}
+
+		metadata := vector.Metadata.AsMap()
+		pageContent, ok := metadata[s.textKey].(string)
+		if !ok {
+			return nil, ErrMissingTextKey
+		}
+		delete(metadata, s.textKey)
+
+		docs = append(docs, schema.Document{
+			PageContent: pageContent,
+			Metadata:    metadata,
+		})
+	}
+	return docs, nil
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the embedder
+// and upsert the vectors with the given ids to the pinecone index.
+func (s Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+
+	return s.upsertDocuments(ctx, ids, docs, options...)
+}
+
+// DeleteDocuments deletes the vectors with the given ids from the pinecone index.
+func (s Store) DeleteDocuments(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+
+	indexConn, err := s.client.Index(pinecone.NewIndexConnParams{
+		Host:      s.host,
+		Namespace: s.getNameSpace(opts),
+	})
+	if err != nil {
+		return err
+	}
+	defer indexConn.Close()
+
+	return indexConn.DeleteVectorsById(ctx, ids)
+}
+
+// DeleteDocumentsByFilter deletes the vectors matching the pinecone metadata filter
+// from the pinecone index.
+func (s Store) DeleteDocumentsByFilter(ctx context.Context,
+	filters any,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if filters == nil {
+		return ErrMissingFilters
+	}
+
+	protoFilterStruct, err := s.createProtoStructFilter(filters)
+	if err != nil {
+		return err
+	}
+
+	indexConn, err := s.client.Index(pinecone.NewIndexConnParams{
+		Host:      s.host,
+		Namespace: s.getNameSpace(opts),
+	})
+	if err != nil {
+		return err
+	}
+	defer indexConn.Close()
+
+	return indexConn.DeleteVectorsByFilter(ctx, protoFilterStruct)
+}
+
+func (s Store) upsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
 ) ([]string, error) {
 	opts := s.getOptions(options...)
 
@@ -105,19 +231,16 @@ func (s Store) AddDocuments(ctx context.Context,
 
 	pineconeVectors := make([]*pinecone.Vector, 0, len(vectors))
 
-	ids := make([]string, len(vectors))
 	for i := 0; i < len(vectors); i++ {
 		metadataStruct, err := structpb.NewStruct(metadatas[i])
 		if err != nil {
 			return nil, err
 		}
 
-		id := uuid.New().String()
-		ids[i] = id
 		pineconeVectors = append(
 			pineconeVectors,
 			&pinecone.Vector{
-				Id:       id,
+				Id:       ids[i],
 				Values:   &vectors[i],
 				Metadata: metadataStruct,
 			},
@@ -137,12 +260,57 @@ func (s Store) AddDocuments(ctx context.Context,
 func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
 	opts := s.getOptions(options...)
 
+	_, queryResult, scoreThreshold, err := s.query(ctx, query, numDocuments, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(queryResult.Matches) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	docs, _, err := s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	return docs, err
+}
+
+// MaxMarginalRelevanceSearch queries the candidates most similar to the query
+// (see vectorstores.WithMaxMarginalRelevance) with their values and selects
+// numDocuments of them with maximal marginal relevance.
+func (s Store) MaxMarginalRelevanceSearch(ctx context.Context, query string, numDocuments int, options ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	opts := s.getOptions(options...)
+
+	mmr, err := vectorstores.GetMMROptions(opts, numDocuments)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, queryResult, scoreThreshold, err := s.query(ctx, query, mmr.FetchK, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, vectors, err := s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	return vectorstores.SelectByMaximalMarginalRelevance(vector, docs, vectors, numDocuments, mmr.Lambda)
+}
+
+// query embeds the query and queries the index for the most similar vectors.
+// It returns the query vector, the query result and the score threshold.
+func (s Store) query(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	opts vectorstores.Options,
+) ([]float32, *pinecone.QueryVectorsResponse, float32, error) {
 	indexConn, err := s.client.Index(pinecone.NewIndexConnParams{
 		Host:      s.host,
 		Namespace: s.getNameSpace(opts),
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 	defer indexConn.Close()
 
@@ -151,18 +319,18 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	if filters != nil {
 		protoFilterStruct, err = s.createProtoStructFilter(filters)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 	}
 
 	scoreThreshold, err := s.getScoreThreshold(opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 
 	vector, err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 
 	queryResult, err := indexConn.QueryByVectorValues(
@@ -176,23 +344,23 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 		},
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 
-	if len(queryResult.Matches) == 0 {
-		return []schema.Document{}, nil
-	}
-
-	return s.getDocumentsFromMatches(queryResult, scoreThreshold)
+	return vector, queryResult, scoreThreshold, nil
 }
 
-func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsResponse, scoreThreshold float32) ([]schema.Document, error) {
+func (s Store) getDocumentsFromMatches(
+	queryResult *pinecone.QueryVectorsResponse,
+	scoreThreshold float32,
+) ([]schema.Document, [][]float32, error) {
 	resultDocuments := make([]schema.Document, 0)
+	resultVectors := make([][]float32, 0)
 	for _, match := range queryResult.Matches {
 		metadata := match.Vector.Metadata.AsMap()
 		pageContent, ok := metadata[s.textKey].(string)
 		if !ok {
-			return nil, ErrMissingTextKey
+			return nil, nil, ErrMissingTextKey
 		}
 		delete(metadata, s.textKey)
 
@@ -203,13 +371,19 @@ func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsRespons
 		}
 
 		// If scoreThreshold is not 0, we only return matches with a score above the threshold.
-		if scoreThreshold != 0 && match.Score >= scoreThreshold {
-			resultDocuments = append(resultDocuments, doc)
-		} else if scoreThreshold == 0 { // If scoreThreshold is 0, we return all matches.
-			resultDocuments = append(resultDocuments, doc)
+		// If scoreThreshold is 0, we return all matches.
+		if scoreThreshold != 0 && match.Score < scoreThreshold {
+			continue
+		}
+
+		var values []float32
+		if match.Vector.Values != nil {
+			values = *match.Vector.Values
 		}
+		resultDocuments = append(resultDocuments, doc)
+		resultVectors = append(resultVectors, values)
 	}
-	return resultDocuments, nil
+	return resultDocuments, resultVectors, nil
 }
 
 func (s Store) getNameSpace(opts vectorstores.Options) string {
@@ -0,0 +1,132 @@
+package qdrant
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/vxcontrol/langchaingo/vectorstores"
+)
+
+// convertFilter returns the filters given to WithFilters in the Qdrant format.
+// A vectorstores.Filter is translated, any other value is passed as is. Ranges on
+// strings are datetime ranges: the values must be dates such as 2024-01-31 or RFC 3339
+// datetimes, and other strings are rejected with vectorstores.ErrUnsupportedFilter.
+func convertFilter(filters any) (any, error) {
+	filter, ok := vectorstores.GetFilter(filters)
+	if !ok {
+		return filters, nil
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilters, err)
+	}
+
+	condition, err := filterToQdrant(filter)
+	if err != nil {
+		return nil, err
+	}
+	if _, isField := condition["key"]; !isField {
+		return condition, nil
+	}
+	// the top level must be a filter, not a field condition
+	return map[string]any{"must": []any{condition}}, nil
+}
+
+// filterToQdrant translates the filter into a Qdrant condition: either a nested
+// filter with must/should/must_not clauses, or a field condition.
+//
+//nolint:cyclop
+func filterToQdrant(filter vectorstores.Filter) (map[string]any, error) {
+	switch filter.Operator {
+	case vectorstores.FilterAnd, vectorstores.FilterOr, vectorstores.FilterNot:
+		conditions := make([]any, 0, len(filter.Filters))
+		for _, f := range filter.Filters {
+			condition, err := filterToQdrant(f)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+		clause := map[vectorstores.FilterOperator]string{
+			vectorstores.FilterAnd: "must",
+			vectorstores.FilterOr:  "should",
+			vectorstores.FilterNot: "must_not",
+		}[filter.Operator]
+		return map[string]any{clause: conditions}, nil
+	case vectorstores.FilterEq:
+		return matchCondition(filter.Key, filter.Value), nil
+	case vectorstores.FilterNe:
+		return map[string]any{"must_not": []any{matchCondition(filter.Key, filter.Value)}}, nil
+	case vectorstores.FilterIn, vectorstores.FilterNin:
+		values, _ := filter.Values()
+		conditions := make([]any, 0, len(values))
+		for _, value := range values {
+			conditions = append(conditions, matchCondition(filter.Key, value))
+		}
+		if filter.Operator == vectorstores.FilterNin {
+			return map[string]any{"must_not": conditions}, nil
+		}
+		return map[string]any{"should": conditions}, nil
+	case vectorstores.FilterGt, vectorstores.FilterGte, vectorstores.FilterLt, vectorstores.FilterLte:
+		value := filter.Value
+		if text, ok := value.(string); ok {
+			date, ok := parseDatetime(text)
+			if !ok {
+				return nil, fmt.Errorf("%w: range on string value %q, only dates and datetimes are supported",
+					vectorstores.ErrUnsupportedFilter, text)
+			}
+			value = date
+		}
+		return map[string]any{
+			"key":   filter.Key,
+			"range": map[string]any{string(filter.Operator): value},
+		}, nil
+	case vectorstores.FilterExists:
+		return map[string]any{
+			"must_not": []any{
+				map[string]any{"is_empty": map[string]any{"key": filter.Key}},
+				map[string]any{"is_null": map[string]any{"key": filter.Key}},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown operator %q", vectorstores.ErrInvalidFilter, filter.Operator)
+	}
+}
+
+// matchCondition returns the field condition matching the value. Qdrant matches
+// only strings, integers and booleans, so other numbers are matched with a range.
+func matchCondition(key string, value any) map[string]any {
+	if f, ok := value.(float64); ok && f != math.Trunc(f) {
+		return map[string]any{"key": key, "range": map[string]any{"gte": f, "lte": f}}
+	}
+	if f, ok := value.(float32); ok && float64(f) != math.Trunc(float64(f)) {
+		return map[string]any{"key": key, "range": map[string]any{"gte": f, "lte": f}}
+	}
+	if f, ok := value.(float64); ok {
+		value = int64(f)
+	}
+	if f, ok := value.(float32); ok {
+		value = int64(f)
+	}
+	return map[string]any{"key": key, "match": map[string]any{"value": value}}
+}
+
+// datetimeLayouts are the layouts of the string values compared by a range, from
+// the most to the least precise.
+var datetimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	time.DateOnly,
+}
+
+// parseDatetime returns the value as an RFC 3339 datetime, as compared by a Qdrant
+// datetime range. Dates without a time zone are taken as UTC, like Qdrant does.
+func parseDatetime(value string) (string, bool) {
+	for _, layout := range datetimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC().Format(time.RFC3339Nano), true
+		}
+	}
+	return "", false
+}
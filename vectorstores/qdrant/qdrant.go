@@ -8,8 +8,13 @@ import (
 	"github.com/vxcontrol/langchaingo/embeddings"
 	"github.com/vxcontrol/langchaingo/schema"
 	"github.com/vxcontrol/langchaingo/vectorstores"
+
+	"github.com/google/uuid"
 )
 
+// ErrInvalidFilters is returned when deleting documents without a filter.
+var ErrInvalidFilters = errors.New("invalid filters")
+
 type Store struct {
 	embedder       embeddings.Embedder
 	collectionName string
@@ -18,7 +23,11 @@ type Store struct {
 	contentKey     string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore                  = Store{}
+	_ vectorstores.DocumentManager              = Store{}
+	_ vectorstores.MaxMarginalRelevanceSearcher = Store{}
+)
 
 func New(opts ...Option) (Store, error) {
 	s, err := applyClientOptions(opts...)
@@ -36,33 +45,82 @@ func (s Store) AddDocuments(ctx context.Context,
 		return []string{}, nil
 	}
 
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
+	vectors, metadatas, err := s.preparePoints(ctx, docs)
+	if err != nil {
+		return nil, err
 	}
 
-	vectors,
-		err := s.embedder.EmbedDocuments(ctx, texts)
+	ids := make([]string, len(vectors))
+	for i := range ids {
+		ids[i] = uuid.NewString()
+	}
+
+	return s.upsertPoints(ctx, &s.qdrantURL, ids, vectors, metadatas)
+}
+
+// GetDocuments retrieves the points with the given ids from the collection.
+// Ids that are not present in the collection are skipped.
+func (s Store) GetDocuments(ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) ([]schema.Document, error) {
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	return s.retrievePoints(ctx, &s.qdrantURL, ids)
+}
+
+// UpsertDocuments embeds the documents and upserts them as points with the given ids.
+// Qdrant only accepts unsigned integers and UUIDs as point ids.
+func (s Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+	if len(docs) == 0 {
+		return []string{}, nil
+	}
+
+	vectors, metadatas, err := s.preparePoints(ctx, docs)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(vectors) != len(docs) {
-		return nil, errors.New("number of vectors from embedder does not match number of documents")
+	return s.upsertPoints(ctx, &s.qdrantURL, ids, vectors, metadatas)
+}
+
+// DeleteDocuments deletes the points with the given ids from the collection.
+func (s Store) DeleteDocuments(ctx context.Context,
+	ids []string,
+	_ ...vectorstores.Option,
+) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	metadatas := make([]map[string]interface{}, 0, len(docs))
-	for i := 0; i < len(docs); i++ {
-		metadata := make(map[string]interface{}, len(docs[i].Metadata))
-		for key, value := range docs[i].Metadata {
-			metadata[key] = value
-		}
-		metadata[s.contentKey] = texts[i]
+	return s.deletePoints(ctx, &s.qdrantURL, deleteBody{Points: ids})
+}
 
-		metadatas = append(metadatas, metadata)
+// DeleteDocumentsByFilter deletes the points matching the Qdrant filter or the
+// vectorstores.Filter, given in the same format as for WithFilters.
+func (s Store) DeleteDocumentsByFilter(ctx context.Context,
+	filters any,
+	_ ...vectorstores.Option,
+) error {
+	if filters == nil {
+		return ErrInvalidFilters
 	}
 
-	return s.upsertPoints(ctx, &s.qdrantURL, vectors, metadatas)
+	filter, err := convertFilter(filters)
+	if err != nil {
+		return err
+	}
+
+	return s.deletePoints(ctx, &s.qdrantURL, deleteBody{Filter: filter})
 }
 
 func (s Store) SimilaritySearch(ctx context.Context,
@@ -71,7 +129,40 @@ func (s Store) SimilaritySearch(ctx context.Context,
 ) ([]schema.Document, error) {
 	opts := s.getOptions(options...)
 
-	filters := s.getFilters(opts)
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreThreshold,
+		err := s.getScoreThreshold(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	vector,
+		err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, _, err := s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters, false)
+	return docs, err
+}
+
+// MaxMarginalRelevanceSearch searches the candidates most similar to the query
+// (see vectorstores.WithMaxMarginalRelevance) with their vectors and selects
+// numDocuments of them with maximal marginal relevance.
+func (s Store) MaxMarginalRelevanceSearch(ctx context.Context,
+	query string, numDocuments int,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+
+	filters, err := s.getFilters(opts)
+	if err != nil {
+		return nil, err
+	}
 
 	scoreThreshold,
 		err := s.getScoreThreshold(opts)
@@ -79,13 +170,61 @@ func (s Store) SimilaritySearch(ctx context.Context,
 		return nil, err
 	}
 
+	mmr,
+		err := vectorstores.GetMMROptions(opts, numDocuments)
+	if err != nil {
+		return nil, err
+	}
+
 	vector,
 		err := s.embedder.EmbedQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters)
+	docs,
+		vectors,
+		err := s.searchPoints(ctx, &s.qdrantURL, vector, mmr.FetchK, scoreThreshold, filters, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return vectorstores.SelectByMaximalMarginalRelevance(vector, docs, vectors, numDocuments, mmr.Lambda)
+}
+
+// preparePoints embeds the documents and builds the payloads holding
+// the metadata and the content of each document.
+func (s Store) preparePoints(
+	ctx context.Context,
+	docs []schema.Document,
+) ([][]float32, []map[string]interface{}, error) {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors,
+		err := s.embedder.EmbedDocuments(ctx, texts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(vectors) != len(docs) {
+		return nil, nil, errors.New("number of vectors from embedder does not match number of documents")
+	}
+
+	metadatas := make([]map[string]interface{}, 0, len(docs))
+	for i := 0; i < len(docs); i++ {
+		metadata := make(map[string]interface{}, len(docs[i].Metadata))
+		for key, value := range docs[i].Metadata {
+			metadata[key] = value
+		}
+		metadata[s.contentKey] = texts[i]
+
+		metadatas = append(metadatas, metadata)
+	}
+
+	return vectors, metadatas, nil
 }
 
 func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
@@ -95,12 +234,12 @@ func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
 	return opts.ScoreThreshold, nil
 }
 
-func (s Store) getFilters(opts vectorstores.Options) any {
+func (s Store) getFilters(opts vectorstores.Options) (any, error) {
 	if opts.Filters != nil {
-		return opts.Filters
+		return convertFilter(opts.Filters)
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
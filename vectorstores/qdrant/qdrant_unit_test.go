@@ -628,15 +628,67 @@ func TestGetFilters(t *testing.T) {
 				Year:     2024,
 			},
 		},
+		{
+			name: "with vectorstores filter",
+			opts: vectorstores.Options{
+				Filters: vectorstores.And(
+					vectorstores.Eq("category", "tech"),
+					vectorstores.Gte("year", 2024),
+					vectorstores.Not(vectorstores.In("tags", "draft", 1.5)),
+				),
+			},
+			expectedFilter: map[string]any{
+				"must": []any{
+					map[string]any{"key": "category", "match": map[string]any{"value": "tech"}},
+					map[string]any{"key": "year", "range": map[string]any{"gte": 2024}},
+					map[string]any{"must_not": []any{
+						map[string]any{"should": []any{
+							map[string]any{"key": "tags", "match": map[string]any{"value": "draft"}},
+							map[string]any{"key": "tags", "range": map[string]any{"gte": 1.5, "lte": 1.5}},
+						}},
+					}},
+				},
+			},
+		},
+		{
+			name: "with vectorstores leaf filter",
+			opts: vectorstores.Options{
+				Filters: vectorstores.Eq("author.name", 2.0),
+			},
+			expectedFilter: map[string]any{
+				"must": []any{
+					map[string]any{"key": "author.name", "match": map[string]any{"value": int64(2)}},
+				},
+			},
+		},
+		{
+			name: "with vectorstores date range",
+			opts: vectorstores.Options{
+				Filters: vectorstores.And(
+					vectorstores.Gt("published", "2024-01-31"),
+					vectorstores.Lte("published", "2024-03-01T10:00:00+01:00"),
+				),
+			},
+			expectedFilter: map[string]any{
+				"must": []any{
+					map[string]any{"key": "published", "range": map[string]any{"gt": "2024-01-31T00:00:00Z"}},
+					map[string]any{"key": "published", "range": map[string]any{"lte": "2024-03-01T09:00:00Z"}},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			store := &Store{}
-			filter := store.getFilters(tt.opts)
+			filter, err := store.getFilters(tt.opts)
+			require.NoError(t, err)
 			assert.Equal(t, tt.expectedFilter, filter)
 		})
 	}
+
+	_, err := (&Store{}).getFilters(vectorstores.Options{Filters: vectorstores.Gt("author", "M")})
+	require.ErrorIs(t, err, vectorstores.ErrUnsupportedFilter)
 }
 
 func TestNewAPIError(t *testing.T) {
@@ -888,3 +940,116 @@ func TestDoRequest_Unit(t *testing.T) { //nolint:funlen // comprehensive test
 		})
 	}
 }
+
+func TestStore_DocumentManager_Unit(t *testing.T) { //nolint:funlen // comprehensive test
+	t.Parallel()
+
+	ctx := t.Context()
+
+	var deleted []deleteBody
+	var upserted upsertBody
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/collections/test-collection/points":
+			var req retrieveBody
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.WithPayload)
+			assert.Equal(t, []string{"id-2", "missing", "id-1"}, req.IDs)
+
+			err := json.NewEncoder(w).Encode(map[string]any{
+				"result": []map[string]any{
+					{"id": "id-1", "payload": map[string]any{"content": "doc1", "key": "value1"}},
+					{"id": "id-2", "payload": map[string]any{"content": "doc2"}},
+				},
+			})
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut && r.URL.Path == "/collections/test-collection/points":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&upserted))
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/collections/test-collection/points/delete":
+			var req deleteBody
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			deleted = append(deleted, req)
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	store, err := New(
+		WithURL(*serverURL),
+		WithCollectionName("test-collection"),
+		WithEmbedder(&testEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	docs, err := store.GetDocuments(ctx, []string{"id-2", "missing", "id-1"})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "doc2", docs[0].PageContent)
+	assert.Equal(t, "doc1", docs[1].PageContent)
+	assert.Equal(t, map[string]any{"key": "value1"}, docs[1].Metadata)
+
+	ids, err := store.UpsertDocuments(ctx, []string{"id-1"}, []schema.Document{{PageContent: "doc1 updated"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id-1"}, ids)
+	assert.Equal(t, []string{"id-1"}, upserted.Batch.IDs)
+	assert.Equal(t, "doc1 updated", upserted.Batch.Payloads[0]["content"])
+
+	_, err = store.UpsertDocuments(ctx, []string{"id-1", "id-2"}, []schema.Document{{PageContent: "doc"}})
+	require.ErrorIs(t, err, vectorstores.ErrIDsDocumentsMismatch)
+
+	require.NoError(t, store.DeleteDocuments(ctx, []string{"id-1", "id-2"}))
+	filter := map[string]any{"must": []any{map[string]any{"key": "key", "match": map[string]any{"value": "value1"}}}}
+	require.NoError(t, store.DeleteDocumentsByFilter(ctx, filter))
+	require.ErrorIs(t, store.DeleteDocumentsByFilter(ctx, nil), ErrInvalidFilters)
+
+	require.Len(t, deleted, 2)
+	assert.Equal(t, []string{"id-1", "id-2"}, deleted[0].Points)
+	assert.Nil(t, deleted[0].Filter)
+	assert.Empty(t, deleted[1].Points)
+	assert.NotNil(t, deleted[1].Filter)
+}
+
+func TestStore_MaxMarginalRelevanceSearch_Unit(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req searchBody
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.WithVector)
+		assert.Equal(t, 10, req.Limit)
+
+		err := json.NewEncoder(w).Encode(map[string]any{
+			"result": []map[string]any{
+				{"score": 0.96, "payload": map[string]any{"content": "a"}, "vector": []float32{3, 1, 0.2, 0.3}},
+				{"score": 0.96, "payload": map[string]any{"content": "a copy"}, "vector": []float32{3, 1, 0.25, 0.3}},
+				{"score": 0.94, "payload": map[string]any{"content": "b"}, "vector": []float32{3, -1, 0.2, 0.3}},
+			},
+		})
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	store, err := New(
+		WithURL(*serverURL),
+		WithCollectionName("test-collection"),
+		WithEmbedder(&testEmbedder{}),
+	)
+	require.NoError(t, err)
+
+	docs, err := store.MaxMarginalRelevanceSearch(ctx, "abc", 2, vectorstores.WithMaxMarginalRelevance(10, 0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "a", docs[0].PageContent)
+	assert.Equal(t, "b", docs[1].PageContent)
+
+	_, err = store.MaxMarginalRelevanceSearch(ctx, "abc", 2, vectorstores.WithMaxMarginalRelevance(10, 2))
+	require.ErrorIs(t, err, vectorstores.ErrInvalidMMRLambda)
+}
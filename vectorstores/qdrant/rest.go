@@ -11,22 +11,16 @@ import (
 
 	"github.com/vxcontrol/langchaingo/httputil"
 	"github.com/vxcontrol/langchaingo/schema"
-
-	"github.com/google/uuid"
 )
 
 // upsertPoints updates or inserts points into the Qdrant collection.
 func (s Store) upsertPoints(
 	ctx context.Context,
 	baseURL *url.URL,
+	ids []string,
 	vectors [][]float32,
 	payloads []map[string]interface{},
 ) ([]string, error) {
-	ids := make([]string, len(vectors))
-	for i := range ids {
-		ids[i] = uuid.NewString()
-	}
-
 	payload := upsertBody{
 		Batch: upsertBatch{
 			IDs:      ids,
@@ -65,10 +59,11 @@ func (s Store) searchPoints(
 	numVectors int,
 	scoreThreshold float32,
 	filter any,
-) ([]schema.Document, error) {
+	withVector bool,
+) ([]schema.Document, [][]float32, error) {
 	payload := searchBody{
 		WithPayload: true,
-		WithVector:  false,
+		WithVector:  withVector,
 		Vector:      vector,
 		Limit:       numVectors,
 		Filter:      filter,
@@ -88,12 +83,12 @@ func (s Store) searchPoints(
 		payload,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer body.Close()
 
 	if statusCode != http.StatusOK {
-		return nil, newAPIError("querying collection", body)
+		return nil, nil, newAPIError("querying collection", body)
 	}
 
 	var response searchResponse
@@ -101,13 +96,14 @@ func (s Store) searchPoints(
 	decoder := json.NewDecoder(body)
 	err = decoder.Decode(&response)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	docs := make([]schema.Document, len(response.Result))
+	vectors := make([][]float32, len(response.Result))
 	for i, match := range response.Result {
 		pageContent, ok := match.Payload[s.contentKey].(string)
 		if !ok {
-			return nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
+			return nil, nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
 		}
 		delete(match.Payload, s.contentKey)
 
@@ -118,11 +114,102 @@ func (s Store) searchPoints(
 		}
 
 		docs[i] = doc
+		vectors[i] = match.Vector
+	}
+
+	return docs, vectors, nil
+}
+
+// retrievePoints fetches the points with the given ids from the Qdrant collection.
+func (s Store) retrievePoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	ids []string,
+) ([]schema.Document, error) {
+	payload := retrieveBody{
+		IDs:         ids,
+		WithPayload: true,
+		WithVector:  false,
+	}
+
+	url := baseURL.JoinPath("collections", s.collectionName, "points")
+	body,
+		statusCode,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("retrieving points", body)
+	}
+
+	var response retrieveResponse
+
+	decoder := json.NewDecoder(body)
+	err = decoder.Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]schema.Document, len(response.Result))
+	for _, point := range response.Result {
+		pageContent, ok := point.Payload[s.contentKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
+		}
+		delete(point.Payload, s.contentKey)
+
+		found[fmt.Sprint(point.ID)] = schema.Document{
+			PageContent: pageContent,
+			Metadata:    point.Payload,
+		}
+	}
+
+	// keep the order of the requested ids
+	docs := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			docs = append(docs, doc)
+		}
 	}
 
 	return docs, nil
 }
 
+// deletePoints deletes points selected by ids or by filter from the Qdrant collection.
+func (s Store) deletePoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	payload deleteBody,
+) error {
+	url := baseURL.JoinPath("collections", s.collectionName, "points", "delete")
+	body,
+		statusCode,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if statusCode != http.StatusOK {
+		return newAPIError("deleting points", body)
+	}
+
+	return nil
+}
+
 // doRequest performs an HTTP request to the Qdrant API.
 func DoRequest(ctx context.Context,
 	url url.URL,
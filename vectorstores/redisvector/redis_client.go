@@ -24,6 +24,10 @@ type RedisClient interface {
 	AddDocsWithHash(ctx context.Context, prefix string, docs []schema.Document) ([]string, error)
 	// TODO AddDocsWithJSON
 	Search(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, error)
+	GetDocsWithHash(ctx context.Context, docIDs []string) ([]schema.Document, error)
+	SetDocsWithHash(ctx context.Context, docIDs []string, docs []schema.Document) error
+	DeleteDocs(ctx context.Context, docIDs []string) error
+	SearchDocIDs(ctx context.Context, index string, query string, limit int) ([]string, error)
 }
 
 type RueidisClient struct {
@@ -99,6 +103,77 @@ func (c RueidisClient) AddDocsWithHash(ctx context.Context, prefix string, docs
 	return docIDs, errors.Join(errs...)
 }
 
+// GetDocsWithHash returns the documents stored in the given hash keys in the order of docIDs,
+// keys that do not exist are skipped.
+func (c RueidisClient) GetDocsWithHash(ctx context.Context, docIDs []string) ([]schema.Document, error) {
+	cmds := make([]rueidis.Completed, 0, len(docIDs))
+	for _, docID := range docIDs {
+		cmds = append(cmds, c.client.B().Hgetall().Key(docID).Build())
+	}
+
+	docs := make([]schema.Document, 0, len(docIDs))
+	for i, res := range c.client.DoMulti(ctx, cmds...) {
+		fields, err := res.AsStrMap()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		docs = append(docs, convertHashIntoDocSchema(docIDs[i], fields))
+	}
+	return docs, nil
+}
+
+// SetDocsWithHash replaces the hashes stored in the given keys with the documents.
+func (c RueidisClient) SetDocsWithHash(ctx context.Context, docIDs []string, docs []schema.Document) error {
+	cmds := make([]rueidis.Completed, 0, len(docs)*2)
+	for i, doc := range docs {
+		cmds = append(cmds, c.client.B().Del().Key(docIDs[i]).Build())
+		cmds = append(cmds, c.generateHSetCMDWithID(docIDs[i], doc))
+	}
+
+	errs := make([]error, 0)
+	for _, res := range c.client.DoMulti(ctx, cmds...) {
+		if res.Error() != nil {
+			errs = append(errs, res.Error())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteDocs deletes the given keys.
+func (c RueidisClient) DeleteDocs(ctx context.Context, docIDs []string) error {
+	cmds := make([]rueidis.Completed, 0, len(docIDs))
+	for _, docID := range docIDs {
+		cmds = append(cmds, c.client.B().Del().Key(docID).Build())
+	}
+
+	errs := make([]error, 0)
+	for _, res := range c.client.DoMulti(ctx, cmds...) {
+		if res.Error() != nil {
+			errs = append(errs, res.Error())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SearchDocIDs returns up to limit keys of the documents matching the redis search query.
+func (c RueidisClient) SearchDocIDs(ctx context.Context, index string, query string, limit int) ([]string, error) {
+	cmd := c.client.B().FtSearch().Index(index).Query(query).Nocontent().
+		Limit().OffsetNum(0, int64(limit)).Dialect(2).Build()
+	_, docs, err := c.client.Do(ctx, cmd).AsFtSearch()
+	if err != nil {
+		return nil, err
+	}
+
+	docIDs := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		docIDs = append(docIDs, doc.Key)
+	}
+	return docIDs, nil
+}
+
 func (c RueidisClient) Search(ctx context.Context, search IndexVectorSearch) (int64, []schema.Document, error) {
 	cmds := search.AsCommand()
 	// fmt.Println(strings.Join(cmds, " "))
@@ -111,6 +186,11 @@ func (c RueidisClient) Search(ctx context.Context, search IndexVectorSearch) (in
 }
 
 func (c RueidisClient) generateHSetCMD(prefix string, doc schema.Document) (string, rueidis.Completed) {
+	docID := getDocIDWithMetaData(prefix, doc.Metadata)
+	return docID, c.generateHSetCMDWithID(docID, doc)
+}
+
+func (c RueidisClient) generateHSetCMDWithID(docID string, doc schema.Document) rueidis.Completed {
 	kvs := make([]string, 0, len(doc.Metadata)*2)
 	for k, v := range doc.Metadata {
 		kvs = append(kvs, k)
@@ -127,8 +207,7 @@ func (c RueidisClient) generateHSetCMD(prefix string, doc schema.Document) (stri
 			kvs = append(kvs, fmt.Sprintf("%v", v))
 		}
 	}
-	docID := getDocIDWithMetaData(prefix, doc.Metadata)
-	return docID, c.client.B().Arbitrary("Hmset").Keys(docID).Args(kvs...).Build()
+	return c.client.B().Arbitrary("Hmset").Keys(docID).Args(kvs...).Build()
 }
 
 // getPrefix get prefix with index name.
@@ -175,3 +254,23 @@ func convertFTSearchResIntoDocSchema(docs []rueidis.FtSearchDoc) []schema.Docume
 	}
 	return res
 }
+
+// convertHashIntoDocSchema converts the fields of a document hash into a document.
+func convertHashIntoDocSchema(docID string, fields map[string]string) schema.Document {
+	doc := schema.Document{}
+	metadata := make(map[string]any, len(fields))
+	for k, v := range fields {
+		switch k {
+		case defaultContentFieldKey:
+			doc.PageContent = v
+		case defaultContentVectorFieldKey:
+		default:
+			metadata[k] = v
+		}
+	}
+	if _, ok := metadata["id"]; !ok {
+		metadata["id"] = docID
+	}
+	doc.Metadata = metadata
+	return doc
+}
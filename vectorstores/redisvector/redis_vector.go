@@ -3,6 +3,8 @@ package redisvector
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/vxcontrol/langchaingo/embeddings"
 	"github.com/vxcontrol/langchaingo/schema"
@@ -22,6 +24,7 @@ var (
 	ErrInvalidEmbeddingVector = errors.New("embedding vector error")
 	ErrInvalidScoreThreshold  = errors.New("score threshold must be between 0 and 1")
 	ErrInvalidFilters         = errors.New("invalid filters")
+	ErrInvalidDocumentID      = errors.New("document id does not belong to the index")
 )
 
 // Store is a wrapper around the redis client.
@@ -35,7 +38,13 @@ type Store struct {
 	schemaGenerator        *schemaGenerator
 }
 
-var _ vectorstores.VectorStore = &Store{}
+var (
+	_ vectorstores.VectorStore     = &Store{}
+	_ vectorstores.DocumentManager = &Store{}
+)
+
+// deleteByFilterBatchSize is the number of keys removed per search in DeleteDocumentsByFilter.
+const deleteByFilterBatchSize = 1000
 
 // New creates a new Store with options.
 func New(ctx context.Context, opts ...Option) (*Store, error) {
@@ -157,6 +166,80 @@ func (s *Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	return docs, nil
 }
 
+// GetDocuments returns the documents with the given ids, as returned by AddDocuments
+// (`doc:{index_name}:{id}`), in the order of ids. Ids that do not exist are skipped.
+func (s *Store) GetDocuments(ctx context.Context, ids []string, _ ...vectorstores.Option) ([]schema.Document, error) {
+	if err := s.checkDocIDs(ids); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+	return s.client.GetDocsWithHash(ctx, ids)
+}
+
+// UpsertDocuments embeds the documents and stores them under the given ids,
+// as returned by AddDocuments (`doc:{index_name}:{id}`), replacing existing documents.
+func (s *Store) UpsertDocuments(
+	ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	_ ...vectorstores.Option,
+) ([]string, error) {
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+	if err := s.checkDocIDs(ids); err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return []string{}, nil
+	}
+
+	if err := s.appendDocumentsWithVectors(ctx, docs); err != nil {
+		return nil, err
+	}
+
+	if err := s.client.SetDocsWithHash(ctx, ids, docs); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteDocuments deletes the documents with the given ids,
+// as returned by AddDocuments (`doc:{index_name}:{id}`).
+func (s *Store) DeleteDocuments(ctx context.Context, ids []string, _ ...vectorstores.Option) error {
+	if err := s.checkDocIDs(ids); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.client.DeleteDocs(ctx, ids)
+}
+
+// DeleteDocumentsByFilter deletes the documents matching the filters, a redis search
+// query string in the same format as for WithFilters (eg: @title:Dune).
+func (s *Store) DeleteDocumentsByFilter(ctx context.Context, filters any, _ ...vectorstores.Option) error {
+	filter, ok := filters.(string)
+	if !ok || filter == "" {
+		return ErrInvalidFilters
+	}
+
+	for {
+		docIDs, err := s.client.SearchDocIDs(ctx, s.indexName, filter, deleteByFilterBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(docIDs) == 0 {
+			return nil
+		}
+		if err := s.client.DeleteDocs(ctx, docIDs); err != nil {
+			return err
+		}
+	}
+}
+
 func (s *Store) DropIndex(ctx context.Context, index string, deleteDocuments bool) error {
 	if !s.client.CheckIndexExists(ctx, index) {
 		return ErrNotExistedIndex
@@ -190,6 +273,17 @@ func (s Store) getFilters(opts vectorstores.Options) (string, error) {
 	return "", nil
 }
 
+// checkDocIDs checks that the ids belong to the store index.
+func (s Store) checkDocIDs(ids []string) error {
+	prefix := getPrefix(s.indexName) + ":"
+	for _, id := range ids {
+		if !strings.HasPrefix(id, prefix) {
+			return fmt.Errorf("%w: %s", ErrInvalidDocumentID, id)
+		}
+	}
+	return nil
+}
+
 // append content & content_vector into doc.Metadata.
 func (s Store) appendDocumentsWithVectors(ctx context.Context, docs []schema.Document) error {
 	if len(docs) == 0 {
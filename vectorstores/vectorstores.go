@@ -2,6 +2,7 @@ package vectorstores
 
 import (
 	"context"
+	"errors"
 
 	"github.com/vxcontrol/langchaingo/callbacks"
 	"github.com/vxcontrol/langchaingo/schema"
@@ -14,6 +15,27 @@ type VectorStore interface {
 	SimilaritySearch(ctx context.Context, query string, numDocuments int, options ...Option) ([]schema.Document, error) //nolint:lll
 }
 
+// ErrIDsDocumentsMismatch is returned by UpsertDocuments when the number of ids
+// does not match the number of documents.
+var ErrIDsDocumentsMismatch = errors.New("number of ids does not match number of documents")
+
+// DocumentManager is an optional interface for vector stores that can fetch,
+// replace and remove documents previously added with AddDocuments. The ids are
+// the ones returned by AddDocuments or passed to UpsertDocuments.
+type DocumentManager interface {
+	// GetDocuments returns the stored documents with the given ids in the order
+	// of ids. Ids that are not present in the store are skipped.
+	GetDocuments(ctx context.Context, ids []string, options ...Option) ([]schema.Document, error)
+	// UpsertDocuments embeds the documents and stores them under the given ids,
+	// replacing documents that already exist with the same ids.
+	UpsertDocuments(ctx context.Context, ids []string, docs []schema.Document, options ...Option) ([]string, error) //nolint:lll
+	// DeleteDocuments removes the documents with the given ids.
+	DeleteDocuments(ctx context.Context, ids []string, options ...Option) error
+	// DeleteDocumentsByFilter removes all documents matching the metadata filters.
+	// The format of filters is the same as the one accepted by WithFilters.
+	DeleteDocumentsByFilter(ctx context.Context, filters any, options ...Option) error
+}
+
 // Retriever is a retriever for vector stores.
 type Retriever struct {
 	CallbacksHandler callbacks.Handler
@@ -30,7 +52,13 @@ func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]sc
 		r.CallbacksHandler.HandleRetrieverStart(ctx, query)
 	}
 
-	docs, err := r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	var docs []schema.Document
+	var err error
+	if r.useMMR() {
+		docs, err = MaxMarginalRelevanceSearch(ctx, r.v, query, r.numDocs, r.options...)
+	} else {
+		docs, err = r.v.SimilaritySearch(ctx, query, r.numDocs, r.options...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +70,15 @@ func (r Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]sc
 	return docs, nil
 }
 
+// useMMR returns true if the retriever options enable maximal marginal relevance search.
+func (r Retriever) useMMR() bool {
+	opts := Options{}
+	for _, opt := range r.options {
+		opt(&opts)
+	}
+	return opts.MMR != nil
+}
+
 // ToRetriever takes a vector store and returns a retriever using the
 // vector store to retrieve documents.
 func ToRetriever(vectorStore VectorStore, numDocuments int, options ...Option) Retriever {
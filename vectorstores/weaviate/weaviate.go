@@ -62,7 +62,10 @@ type Store struct {
 	additionalFields []string
 }
 
-var _ vectorstores.VectorStore = Store{}
+var (
+	_ vectorstores.VectorStore     = Store{}
+	_ vectorstores.DocumentManager = Store{}
+)
 
 // New creates a new Store with options.
 // When using weaviate,
@@ -95,7 +98,6 @@ func (s Store) AddDocuments(ctx context.Context,
 	options ...vectorstores.Option,
 ) ([]string, error) {
 	opts := s.getOptions(options...)
-	nameSpace := s.getNameSpace(opts)
 
 	docs = s.deduplicate(ctx, opts, docs)
 
@@ -105,6 +107,127 @@ func (s Store) AddDocuments(ctx context.Context,
 		return nil, nil
 	}
 
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+	return s.upsertDocuments(ctx, opts, ids, docs)
+}
+
+// GetDocuments returns the objects with the given ids from the weaviate index
+// (and the store or WithNameSpace nameSpace) in the order of ids.
+// Ids that are not found are skipped.
+func (s Store) GetDocuments(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) ([]schema.Document, error) {
+	opts := s.getOptions(options...)
+	if len(ids) == 0 {
+		return []schema.Document{}, nil
+	}
+
+	whereBuilder, err := s.createWhereBuilder(s.getNameSpace(opts), s.createIDsFilter(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := s.createFields()
+	additional := &fields[len(fields)-1]
+	additional.Fields = append(additional.Fields, graphql.Field{Name: "id"})
+
+	res, err := s.client.GraphQL().
+		Get().
+		WithWhere(whereBuilder).
+		WithClassName(s.indexName).
+		WithLimit(len(ids)).
+		WithFields(fields...).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := s.parseDocumentsByGraphQLResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]schema.Document, len(docs))
+	for _, doc := range docs {
+		if additional, ok := doc.Metadata["_additional"].(map[string]any); ok {
+			if id, ok := additional["id"].(string); ok {
+				found[id] = doc
+			}
+		}
+	}
+
+	ordered := make([]schema.Document, 0, len(found))
+	for _, id := range ids {
+		if doc, ok := found[id]; ok {
+			ordered = append(ordered, doc)
+		}
+	}
+	return ordered, nil
+}
+
+// UpsertDocuments creates vector embeddings from the documents using the embedder
+// and upserts them to the weaviate index with the given ids (UUIDs).
+func (s Store) UpsertDocuments(ctx context.Context,
+	ids []string,
+	docs []schema.Document,
+	options ...vectorstores.Option,
+) ([]string, error) {
+	opts := s.getOptions(options...)
+	if len(ids) != len(docs) {
+		return nil, vectorstores.ErrIDsDocumentsMismatch
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	return s.upsertDocuments(ctx, opts, ids, docs)
+}
+
+// DeleteDocuments deletes the objects with the given ids from the weaviate index
+// (and the store or WithNameSpace nameSpace).
+func (s Store) DeleteDocuments(ctx context.Context,
+	ids []string,
+	options ...vectorstores.Option,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.DeleteDocumentsByFilter(ctx, s.createIDsFilter(ids), options...)
+}
+
+// DeleteDocumentsByFilter deletes the objects matching the filter, a *filters.WhereBuilder
+// as for WithFilters, from the weaviate index (and the store or WithNameSpace nameSpace).
+func (s Store) DeleteDocumentsByFilter(ctx context.Context,
+	filter any,
+	options ...vectorstores.Option,
+) error {
+	opts := s.getOptions(options...)
+	if filter == nil {
+		return ErrInvalidFilter
+	}
+
+	whereBuilder, err := s.createWhereBuilder(s.getNameSpace(opts), filter)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Batch().ObjectsBatchDeleter().
+		WithClassName(s.indexName).
+		WithWhere(whereBuilder).
+		Do(ctx)
+	return err
+}
+
+func (s Store) upsertDocuments(ctx context.Context,
+	opts vectorstores.Options,
+	ids []string,
+	docs []schema.Document,
+) ([]string, error) {
+	nameSpace := s.getNameSpace(opts)
+
 	texts := make([]string, 0, len(docs))
 	for _, doc := range docs {
 		texts = append(texts, doc.PageContent)
@@ -132,13 +255,10 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	objects := make([]*models.Object, 0, len(docs))
-	ids := make([]string, len(docs))
 	for i := range docs {
-		id := strfmt.UUID(uuid.New().String())
-		ids[i] = id.String()
 		objects = append(objects, &models.Object{
 			Class:      s.indexName,
-			ID:         id,
+			ID:         strfmt.UUID(ids[i]),
 			Vector:     vectors[i],
 			Properties: metadatas[i],
 		})
@@ -328,6 +448,10 @@ func (s Store) createWhereBuilder(namespace string, filter any) (*filters.WhereB
 	}), nil
 }
 
+func (s Store) createIDsFilter(ids []string) *filters.WhereBuilder {
+	return filters.Where().WithPath([]string{"id"}).WithOperator(filters.ContainsAny).WithValueText(ids...)
+}
+
 func (s Store) createFields() []graphql.Field {
 	fields := make([]graphql.Field, 0, len(s.queryAttrs))
 	for _, attr := range s.queryAttrs {